@@ -137,6 +137,29 @@ type Status struct {
 
 	// +listType=atomic
 	Conditions []Condition `json:"conditions,omitempty"`
+
+	// The resources deployed by the resource controller as part of the
+	// last reconciliation.
+	// +optional
+	// +listType=atomic
+	DeployedResources []DeployedResource `json:"deployedResources,omitempty"`
+}
+
+// DeployedResource identifies a resource deployed by a resource controller.
+// +kubebuilder:object:generate=true
+type DeployedResource struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Degraded is set when the resource failed to deploy, but is not critical
+	// for the resource controller to work.
+	// +optional
+	Degraded bool `json:"degraded,omitempty"`
+	// Message is the reason why the resource failed to deploy.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 func (s *Status) GetConditions() []Condition {
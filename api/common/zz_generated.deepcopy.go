@@ -77,6 +77,21 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployedResource) DeepCopyInto(out *DeployedResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployedResource.
+func (in *DeployedResource) DeepCopy() *DeployedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DevFlags) DeepCopyInto(out *DevFlags) {
 	*out = *in
@@ -158,6 +173,11 @@ func (in *Status) DeepCopyInto(out *Status) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DeployedResources != nil {
+		in, out := &in.DeployedResources, &out.DeployedResources
+		*out = make([]DeployedResource, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Status.
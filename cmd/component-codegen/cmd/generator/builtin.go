@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// addComponentHandler appends the handler of the component to the list of
+// built-in components registered at startup.
+func addComponentHandler(logger *logrus.Logger, componentName string) error {
+	content, err := os.ReadFile(builtinFilePath)
+	if err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+
+	src := string(content)
+
+	start := strings.Index(src, "return []cr.ComponentHandler{")
+	if start == -1 {
+		return fmt.Errorf("component handlers list not found in %s", builtinFilePath)
+	}
+
+	end := strings.Index(src[start:], "\n\t}\n")
+	if end == -1 {
+		return fmt.Errorf("end of component handlers list not found in %s", builtinFilePath)
+	}
+
+	end += start + 1
+	src = src[:end] + fmt.Sprintf("\t\t%s.NewComponentHandler(),\n", strings.ToLower(componentName)) + src[end:]
+
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return fmt.Errorf("error formatting code: %w", err)
+	}
+
+	if err := os.WriteFile(builtinFilePath, formatted, FilePerm); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+
+	logger.Infof("Successfully registered the component handler in %s", builtinFilePath)
+	return nil
+}
@@ -12,7 +12,7 @@ const (
 	Controllers     = "internal/controller/components"
 	DscTypesPath    = "api/datasciencecluster/v1/datasciencecluster_types.go"
 	templatesDir    = cmdDir + "/templates"
-	mainFilePath    = "cmd/main.go"
+	builtinFilePath = Controllers + "/builtin/builtin.go"
 	projectFilePath = "PROJECT"
 )
 
@@ -37,13 +37,17 @@ func GenerateComponent(logger *logrus.Logger, componentName string) error {
 		}
 	}
 
-	dirs := []string{DscTypesPath, mainFilePath}
+	dirs := []string{DscTypesPath, builtinFilePath}
 	for _, dir := range dirs {
 		if err := addFieldsToStruct(logger, componentName, dir); err != nil {
 			return err
 		}
 	}
 
+	if err := addComponentHandler(logger, componentName); err != nil {
+		return err
+	}
+
 	if err := addKubeBuilderRBAC(logger, componentName); err != nil {
 		return err
 	}
@@ -14,8 +14,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// addImportField imports the package of the component in the builtin package.
 func addImportField(file *ast.File, componentName string) error {
-	if file.Name.Name+".go" != "main.go" {
+	if file.Name.Name != "builtin" {
 		return nil
 	}
 	var importDecl *ast.GenDecl
@@ -30,7 +31,6 @@ func addImportField(file *ast.File, componentName string) error {
 	}
 
 	newImport := &ast.ImportSpec{
-		Name: ast.NewIdent("_"),
 		Path: &ast.BasicLit{
 			Kind:  token.STRING,
 			Value: fmt.Sprintf("\"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/%s\"", strings.ToLower(componentName)),
@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	ocappsv1 "github.com/openshift/api/apps/v1" //nolint:importas //reason: conflicts with appsv1 "k8s.io/api/apps/v1"
 	buildv1 "github.com/openshift/api/build/v1"
@@ -52,6 +54,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -74,6 +77,7 @@ import (
 	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/api/infrastructure/v1"
 	infrav1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/infrastructure/v1alpha1"
 	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/builtin"
 	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
 	dscctrl "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/datasciencecluster"
 	dscictrl "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/dscinitialization"
@@ -81,25 +85,18 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/webhook"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/indexes"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/reconciler"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/flags"
 
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/dashboard"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/datasciencepipelines"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/feastoperator"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/kserve"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/kueue"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/llamastackoperator"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/modelcontroller"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/modelmeshserving"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/modelregistry"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/ray"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/trainingoperator"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/trustyai"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/workbenches"
 	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/services/auth"
 	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/services/certconfigmapgenerator"
 	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/services/gateway"
@@ -148,12 +145,61 @@ func init() { //nolint:gochecknoinits
 	utilruntime.Must(gwapiv1.Install(scheme))
 }
 
-func initComponents(_ context.Context, p common.Platform) error {
+func initComponents(ctx context.Context, p common.Platform, policy cr.StartupPolicy) error {
 	return cr.ForEach(func(ch cr.ComponentHandler) error {
-		return ch.Init(p)
+		return startComponent(ctx, ch, policy, func() error {
+			return ch.Init(p)
+		})
 	})
 }
 
+// startComponent runs the given startup step of a component, with the degraded
+// policy a failure marks the component as failed instead of being returned, and
+// the remaining steps of failed components are skipped.
+func startComponent(ctx context.Context, ch cr.ComponentHandler, policy cr.StartupPolicy, fn func() error) error {
+	if cr.Failure(ch.GetName()) != nil {
+		return nil
+	}
+
+	err := fn()
+	if err == nil || policy != cr.StartupPolicyDegraded {
+		return err
+	}
+
+	logf.FromContext(ctx).Error(err, "component failed to start", "name", ch.GetName())
+	cr.MarkFailed(ch.GetName(), err)
+
+	return nil
+}
+
+// restrictCacheNamespaces restricts the watches of namespaced resources to the
+// given namespaces, including the resources only watched in some namespaces.
+func restrictCacheNamespaces(opts *cache.Options, namespaces []string) {
+	opts.DefaultNamespaces = make(map[string]cache.Config, len(namespaces))
+	for _, ns := range namespaces {
+		opts.DefaultNamespaces[ns] = cache.Config{}
+	}
+
+	for obj, byObject := range opts.ByObject {
+		if len(byObject.Namespaces) == 0 {
+			continue
+		}
+
+		for ns := range byObject.Namespaces {
+			if !slices.Contains(namespaces, ns) {
+				delete(byObject.Namespaces, ns)
+			}
+		}
+
+		// no namespace left, fall back to the default ones
+		if len(byObject.Namespaces) == 0 {
+			byObject.Namespaces = nil
+		}
+
+		opts.ByObject[obj] = byObject
+	}
+}
+
 func initServices(_ context.Context, p common.Platform) error {
 	return sr.ForEach(func(sh sr.ServiceHandler) error {
 		return sh.Init(p)
@@ -162,12 +208,19 @@ func initServices(_ context.Context, p common.Platform) error {
 
 // Create a config struct with viper's mapstructure.
 type OperatorConfig struct {
-	MetricsAddr         string `mapstructure:"metrics-bind-address"`
-	HealthProbeAddr     string `mapstructure:"health-probe-bind-address"`
-	LeaderElection      bool   `mapstructure:"leader-elect"`
-	MonitoringNamespace string `mapstructure:"dsc-monitoring-namespace"`
-	LogMode             string `mapstructure:"log-mode"`
-	PprofAddr           string `mapstructure:"pprof-bind-address"`
+	MetricsAddr          string        `mapstructure:"metrics-bind-address"`
+	HealthProbeAddr      string        `mapstructure:"health-probe-bind-address"`
+	LeaderElection       bool          `mapstructure:"leader-elect"`
+	MonitoringNamespace  string        `mapstructure:"dsc-monitoring-namespace"`
+	LogMode              string        `mapstructure:"log-mode"`
+	PprofAddr            string        `mapstructure:"pprof-bind-address"`
+	TracingEndpoint      string        `mapstructure:"tracing-endpoint"`
+	MaxConcurrentRenders int           `mapstructure:"max-concurrent-renders"`
+	APICacheTTL          time.Duration `mapstructure:"api-cache-ttl"`
+	StartupPolicy        string        `mapstructure:"component-startup-policy"`
+	WatchNamespaces      []string      `mapstructure:"watch-namespaces"`
+	ClusterScoped        string        `mapstructure:"cluster-scoped-resources"`
+	ResyncPeriod         time.Duration `mapstructure:"resync-period"`
 
 	// Zap logging configuration
 	ZapDevel        bool   `mapstructure:"zap-devel"`
@@ -227,6 +280,40 @@ func main() { //nolint:funlen,maintidx,gocyclo
 	// root context
 	ctx := ctrl.SetupSignalHandler()
 	ctx = logf.IntoContext(ctx, setupLog)
+
+	render.SetMaxConcurrency(oconfig.MaxConcurrentRenders)
+	apiCache := cluster.NewAPICache(cluster.WithAPICacheTTL(oconfig.APICacheTTL))
+
+	scopeMode := deploy.ScopeMode(oconfig.ClusterScoped)
+	if scopeMode != deploy.ScopeReject && scopeMode != deploy.ScopeStrip {
+		setupLog.Error(fmt.Errorf("unsupported cluster-scoped resources mode %q", scopeMode), "invalid configuration")
+		os.Exit(1)
+	}
+
+	// the reconcilers are built with the settings carried by the root context
+	ctx = odhtypes.SettingsIntoContext(ctx, odhtypes.Settings{
+		WatchNamespaces: oconfig.WatchNamespaces,
+		ClusterScoped:   string(scopeMode),
+		ResyncPeriod:    oconfig.ResyncPeriod,
+	})
+
+	startupPolicy := cr.StartupPolicy(oconfig.StartupPolicy)
+	if startupPolicy != cr.StartupPolicyFailFast && startupPolicy != cr.StartupPolicyDegraded {
+		setupLog.Error(fmt.Errorf("unsupported component startup policy %q", startupPolicy), "invalid configuration")
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, oconfig.TracingEndpoint)
+	if err != nil {
+		setupLog.Error(err, "unable to setup tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			setupLog.Error(err, "unable to shutdown tracing")
+		}
+	}()
+
 	// Create new uncached client to run initial setup
 	setupCfg, err := config.GetConfig()
 	if err != nil {
@@ -255,7 +342,9 @@ func main() { //nolint:funlen,maintidx,gocyclo
 		os.Exit(1)
 	}
 
-	if err := initComponents(ctx, platform); err != nil {
+	builtin.RegisterBuiltinComponents(cr.DefaultRegistry())
+
+	if err := initComponents(ctx, platform, startupPolicy); err != nil {
 		setupLog.Error(err, "unable to init components")
 		os.Exit(1)
 	}
@@ -329,6 +418,10 @@ func main() { //nolint:funlen,maintidx,gocyclo
 		},
 	}
 
+	if len(oconfig.WatchNamespaces) > 0 {
+		restrictCacheNamespaces(&cacheOptions, oconfig.WatchNamespaces)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{ // single pod does not need to have LeaderElection
 		Scheme:  scheme,
 		Metrics: ctrlmetrics.Options{BindAddress: oconfig.MetricsAddr},
@@ -352,6 +445,10 @@ func main() { //nolint:funlen,maintidx,gocyclo
 		// if you are doing or is intended to do any operation such as perform cleanups
 		// after the manager stops then its usage might be unsafe.
 		// LeaderElectionReleaseOnCancel: true,
+
+		// A resettable mapper, so that the dynamic watches can drop the
+		// discovery data once the CRD of a watched kind gets established
+		MapperProvider: cluster.NewResettableRESTMapper,
 		Client: client.Options{
 			Cache: &client.CacheOptions{
 				DisableFor: []client.Object{
@@ -369,12 +466,35 @@ func main() { //nolint:funlen,maintidx,gocyclo
 				Unstructured: true,
 			},
 		},
+		// The manager client caches the outcome of the optional APIs lookups
+		NewClient: func(config *rest.Config, options client.Options) (client.Client, error) {
+			cli, err := client.New(config, options)
+			if err != nil {
+				return nil, err
+			}
+
+			return cluster.WithAPICache(cli, apiCache), nil
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// Index the resources deployed by the controllers, so they can be
+	// efficiently looked up by the controller they are part of
+	if err := indexes.Register(ctx, mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to register field indexes")
+		os.Exit(1)
+	}
+
+	// Drop the cached APIs as soon as a CRD changes, so that dynamic watches and
+	// optional resources don't wait for the TTL to expire
+	if err := apiCache.InvalidateOnCRDChanges(ctx, mgr.GetCache()); err != nil {
+		setupLog.Error(err, "unable to watch CRD changes")
+		os.Exit(1)
+	}
+
 	// Register all webhooks using the helper
 	if err := webhook.RegisterAllWebhooks(mgr); err != nil {
 		setupLog.Error(err, "unable to register webhooks")
@@ -402,7 +522,7 @@ func main() { //nolint:funlen,maintidx,gocyclo
 	}
 
 	// Initialize component reconcilers
-	if err = CreateComponentReconcilers(ctx, mgr); err != nil {
+	if err = CreateComponentReconcilers(ctx, mgr, startupPolicy); err != nil {
 		setupLog.Error(err, "unable to create component controllers")
 		os.Exit(1)
 	}
@@ -501,6 +621,10 @@ func main() { //nolint:funlen,maintidx,gocyclo
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddMetricsServerExtraHandler(reconciler.IntrospectionPath, reconciler.IntrospectionHandler()); err != nil {
+		setupLog.Error(err, "unable to set up controllers introspection")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -577,16 +701,18 @@ func createODHGeneralCacheConfig(ctx context.Context, cli client.Client, platfor
 	return namespaceConfigs, nil
 }
 
-func CreateComponentReconcilers(ctx context.Context, mgr manager.Manager) error {
+func CreateComponentReconcilers(ctx context.Context, mgr manager.Manager, policy cr.StartupPolicy) error {
 	l := logf.FromContext(ctx)
 
 	return cr.ForEach(func(ch cr.ComponentHandler) error {
-		l.Info("creating reconciler", "type", "component", "name", ch.GetName())
-		if err := ch.NewComponentReconciler(ctx, mgr); err != nil {
-			return fmt.Errorf("error creating %s component reconciler: %w", ch.GetName(), err)
-		}
+		return startComponent(ctx, ch, policy, func() error {
+			l.Info("creating reconciler", "type", "component", "name", ch.GetName())
+			if err := ch.NewComponentReconciler(ctx, mgr); err != nil {
+				return fmt.Errorf("error creating %s component reconciler: %w", ch.GetName(), err)
+			}
 
-		return nil
+			return nil
+		})
 	})
 }
 
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// volatileFields are removed from both the live and the dry-run objects as they
+// change on every write and are not relevant to the diff.
+var volatileFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "uid"},
+	// set by the deploy action for the types the controller of the component
+	// owns, which are not known here
+	{"metadata", "ownerReferences"},
+	{"status"},
+}
+
+// diff performs a server side dry-run apply of the given resources and writes
+// a unified diff between the live objects and the result of the dry-run, which
+// is what a reconciliation would change.
+func diff(ctx context.Context, cli client.Client, fieldOwner string, objs []unstructured.Unstructured, out io.Writer) (int, error) {
+	changed := 0
+
+	for i := range objs {
+		desired := objs[i].DeepCopy()
+		name := fmt.Sprintf("%s/%s", strings.ToLower(desired.GetKind()), client.ObjectKeyFromObject(desired))
+
+		live := resources.GvkToUnstructured(desired.GroupVersionKind())
+		err := cli.Get(ctx, client.ObjectKeyFromObject(desired), live)
+
+		switch {
+		case k8serr.IsNotFound(err):
+			live = nil
+		case err != nil:
+			return changed, fmt.Errorf("unable to get %s: %w", name, err)
+		}
+
+		// as for the deploy action, CRDs are not bound to a component and are
+		// owned by the platform itself
+		fo := fieldOwner
+		if desired.GroupVersionKind() == gvk.CustomResourceDefinition {
+			fo = resources.PlatformFieldOwner
+		}
+
+		err = cli.Patch(ctx, desired, client.Apply,
+			client.DryRunAll,
+			client.ForceOwnership,
+			client.FieldOwner(fo),
+		)
+		if err != nil {
+			return changed, fmt.Errorf("unable to dry-run apply %s: %w", name, err)
+		}
+
+		from, err := toYAML(live)
+		if err != nil {
+			return changed, err
+		}
+
+		to, err := toYAML(desired)
+		if err != nil {
+			return changed, err
+		}
+
+		if from == to {
+			continue
+		}
+
+		changed++
+
+		ud, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(from),
+			B:        difflib.SplitLines(to),
+			FromFile: "live/" + name,
+			ToFile:   "rendered/" + name,
+			Context:  3,
+		})
+		if err != nil {
+			return changed, fmt.Errorf("unable to compute diff of %s: %w", name, err)
+		}
+
+		if _, err := io.WriteString(out, ud); err != nil {
+			return changed, err
+		}
+	}
+
+	return changed, nil
+}
+
+func toYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	c := obj.DeepCopy()
+	for _, f := range volatileFields {
+		unstructured.RemoveNestedField(c.Object, f...)
+	}
+
+	data, err := yaml.Marshal(c.Object)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return string(data), nil
+}
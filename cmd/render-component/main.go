@@ -0,0 +1,402 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// render-component renders the manifests of a component for a given component
+// spec, without requiring a cluster, so that the output of the operator can be
+// reproduced locally:
+//
+//	render-component \
+//	  --spec dashboard.yaml \
+//	  --manifests-path ./opt/manifests \
+//	  --namespace opendatahub
+//
+// The component is looked up in the registry of the built-in components by the
+// kind of the spec, the images of its params.env are set as on the operator
+// startup and the actions of its controller are run up to the deploy one, so
+// the dev flags, the parameters and the customizations of the component are
+// honored. Without a cluster, the actions run against an in-memory client only
+// holding a DSCInitialization for the given namespace and the cluster ingress
+// for the given domain.
+//
+// With --diff, the actions run against the current cluster in dry-run mode, for
+// its DSCInitialization and the live instance, the rendered resources are
+// labeled and annotated as the deploy action does, applied in server side
+// dry-run mode and the differences with the live objects are printed instead.
+// As for kubectl diff, the exit code is 1 if any difference is found and greater
+// than 1 on failure.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	ofapiv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/spf13/pflag"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/api/infrastructure/v1"
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/builtin"
+	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/reconciler"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	odhdeploy "github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+type options struct {
+	spec             string
+	manifestsPath    string
+	namespace        string
+	platform         string
+	domain           string
+	diff             bool
+	fieldOwner       string
+	fieldOwnerFormat string
+}
+
+func main() {
+	o := options{}
+
+	pflag.StringVar(&o.spec, "spec", "", "Path to the YAML file of the component resource to render (required).")
+	pflag.StringVar(&o.manifestsPath, "manifests-path", odhdeploy.DefaultManifestPath, "Root path of the component manifests, the params.env files are updated as the operator does.")
+	pflag.StringVar(&o.namespace, "namespace", "opendatahub", "Applications namespace the resources are rendered for, with --diff the one of the cluster is used.")
+	pflag.StringVar(&o.platform, "platform", string(cluster.OpenDataHub), "Platform the resources are rendered for, with --diff the one of the cluster is used.")
+	pflag.StringVar(&o.domain, "domain", "apps.example.com", "Domain of the cluster ingress the resources are rendered for, with --diff the one of the cluster is used.")
+	pflag.BoolVar(&o.diff, "diff", false, "Diff the rendered resources against the live objects of the current cluster.")
+	pflag.StringVar(&o.fieldOwner, "field-owner", "", "Field manager used for the dry-run apply, defaults to the one computed from --field-manager-format.")
+	pflag.StringVar(&o.fieldOwnerFormat, "field-manager-format", deploy.StandardFieldOwnerFormat, "Format of the field manager the component applies resources with, {component} is replaced by the lowercase kind of the component.")
+	pflag.Parse()
+
+	changed, err := run(context.Background(), o, os.Stdout)
+	switch {
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	case changed > 0:
+		os.Exit(1)
+	}
+}
+
+// run renders the component and either prints the rendered resources or, in
+// diff mode, the differences with the live cluster. It returns the number of
+// resources that would be changed on the cluster.
+func run(ctx context.Context, o options, out io.Writer) (int, error) {
+	if o.spec == "" {
+		return 0, errors.New("the --spec flag is required")
+	}
+
+	s, err := newScheme()
+	if err != nil {
+		return 0, err
+	}
+
+	instance, err := decodeSpec(s, o.spec)
+	if err != nil {
+		return 0, err
+	}
+
+	if !o.diff {
+		cli, err := newOfflineClient(s, o)
+		if err != nil {
+			return 0, err
+		}
+
+		rr, err := renderComponent(ctx, &rest.Config{}, cli, instance, common.Release{Name: common.Platform(o.platform)}, o.manifestsPath)
+		if err != nil {
+			return 0, err
+		}
+
+		return 0, write(rr, out)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return 0, fmt.Errorf("unable to load cluster configuration: %w", err)
+	}
+
+	cli, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return 0, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	return renderDiff(ctx, cfg, cli, instance, o, out)
+}
+
+// write prints the resources rendered for the given request.
+func write(rr *types.ReconciliationRequest, out io.Writer) error {
+	for i := range rr.Resources {
+		data, err := yaml.Marshal(rr.Resources[i].Object)
+		if err != nil {
+			return fmt.Errorf("unable to marshal %s %s: %w", rr.Resources[i].GetKind(), rr.Resources[i].GetName(), err)
+		}
+
+		if _, err := fmt.Fprintf(out, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderDiff renders the resources for the DSCInitialization and the live
+// instance of the given cluster, as the operator would, and diffs them against
+// the live objects.
+func renderDiff(ctx context.Context, cfg *rest.Config, cli client.Client, instance common.PlatformObject, o options, out io.Writer) (int, error) {
+	dsci, err := cluster.GetDSCI(ctx, cli)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get DSCInitialization: %w", err)
+	}
+
+	// the resources are tracked with the identity and the generation of the
+	// live instance, if any
+	live, ok := instance.DeepCopyObject().(common.PlatformObject)
+	if !ok {
+		return 0, fmt.Errorf("resource %s is not a component", instance.GetObjectKind().GroupVersionKind())
+	}
+
+	err = cli.Get(ctx, client.ObjectKeyFromObject(instance), live)
+	switch {
+	case k8serr.IsNotFound(err):
+		// not created yet, all its resources are new
+	case err != nil:
+		return 0, fmt.Errorf("unable to get %s: %w", instance.GetName(), err)
+	default:
+		instance.SetUID(live.GetUID())
+		instance.SetGeneration(live.GetGeneration())
+	}
+
+	// the actions may write to the cluster, i.e. to create the resources the
+	// component depends on, which must be left untouched
+	rr, err := renderComponent(ctx, cfg, client.NewDryRunClient(cli), instance, dsci.Status.Release, o.manifestsPath)
+	if err != nil {
+		return 0, err
+	}
+
+	kind, err := resources.KindForObject(cli.Scheme(), rr.Instance)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range rr.Resources {
+		deploy.SetPlatformMetadata(rr, kind, &rr.Resources[i])
+	}
+
+	fieldOwner := o.fieldOwner
+	if fieldOwner == "" {
+		fieldOwner = deploy.FieldOwner(o.fieldOwnerFormat, kind)
+	}
+
+	return diff(ctx, cli, fieldOwner, rr.Resources, out)
+}
+
+// renderComponent looks up the built-in component of the given instance, sets
+// it up as the operator does and runs the actions of its controller up to the
+// deploy one, against the given client.
+func renderComponent(
+	ctx context.Context,
+	cfg *rest.Config,
+	cli client.Client,
+	instance common.PlatformObject,
+	release common.Release,
+	manifestsPath string,
+) (*types.ReconciliationRequest, error) {
+	kind, err := resources.KindForObject(cli.Scheme(), instance)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := cr.Registry{}
+	builtin.RegisterBuiltinComponents(&registry)
+
+	var handler cr.ComponentHandler
+
+	err = registry.ForEach(func(ch cr.ComponentHandler) error {
+		hkind, err := resources.KindForObject(cli.Scheme(), ch.NewCRObject(&dscv2.DataScienceCluster{}))
+		if err != nil {
+			return err
+		}
+
+		if hkind == kind {
+			handler = ch
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if handler == nil {
+		return nil, fmt.Errorf("%s is not a built-in component", kind)
+	}
+
+	// the components look up the release and the manifests on construction
+	// and on startup, as set by the operator
+	cluster.SetRelease(release)
+	odhdeploy.DefaultManifestPath = manifestsPath
+
+	if err := handler.Init(release.Name); err != nil {
+		return nil, fmt.Errorf("unable to initialize component %s: %w", handler.GetName(), err)
+	}
+
+	var r *reconciler.Reconciler
+
+	rctx := reconciler.RenderHookIntoContext(ctx, func(in *reconciler.Reconciler) {
+		r = in
+	})
+
+	err = handler.NewComponentReconciler(rctx, &renderManager{cfg: cfg, cli: cli})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create reconciler for component %s: %w", handler.GetName(), err)
+	}
+
+	if r == nil {
+		return nil, fmt.Errorf("component %s did not build a reconciler", handler.GetName())
+	}
+
+	return r.Render(ctx, instance)
+}
+
+// renderManager is the manager the reconcilers of the components are built
+// with, it only provides what the builder needs as the controllers are not set
+// up when rendering.
+type renderManager struct {
+	manager.Manager
+
+	cfg *rest.Config
+	cli client.Client
+}
+
+func (m *renderManager) GetConfig() *rest.Config {
+	return m.cfg
+}
+
+func (m *renderManager) GetClient() client.Client {
+	return m.cli
+}
+
+func (m *renderManager) GetScheme() *runtime.Scheme {
+	return m.cli.Scheme()
+}
+
+func (m *renderManager) GetRESTMapper() meta.RESTMapper {
+	return m.cli.RESTMapper()
+}
+
+func (m *renderManager) GetEventRecorderFor(string) record.EventRecorder {
+	// events are discarded
+	return &record.FakeRecorder{}
+}
+
+// newOfflineClient returns an in-memory client holding the DSCInitialization
+// and the cluster ingress the resources are rendered for.
+func newOfflineClient(s *runtime.Scheme, o options) (client.Client, error) {
+	dsci := dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default-dsci",
+		},
+		Spec: dsciv2.DSCInitializationSpec{
+			ApplicationsNamespace: o.namespace,
+		},
+	}
+
+	ingress := configv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Spec: configv1.IngressSpec{
+			Domain: o.domain,
+		},
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(&dsci, &ingress).
+		Build(), nil
+}
+
+// newScheme returns a scheme with the built-in types, needed to decode the
+// rendered resources, and the APIs the components interact with.
+func newScheme() (*runtime.Scheme, error) {
+	s := runtime.NewScheme()
+
+	for _, fn := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		apiextensionsv1.AddToScheme,
+		componentApi.AddToScheme,
+		dsciv2.AddToScheme,
+		dscv2.AddToScheme,
+		infrav1.AddToScheme,
+		serviceApi.AddToScheme,
+		configv1.Install,
+		operatorv1.Install,
+		routev1.Install,
+		ofapiv1alpha1.AddToScheme,
+		promv1.AddToScheme,
+	} {
+		if err := fn(s); err != nil {
+			return nil, fmt.Errorf("unable to create scheme: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func decodeSpec(s *runtime.Scheme, path string) (common.PlatformObject, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spec %s: %w", path, err)
+	}
+
+	obj, _, err := serializer.NewCodecFactory(s).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode spec %s: %w", path, err)
+	}
+
+	instance, ok := obj.(common.PlatformObject)
+	if !ok {
+		return nil, fmt.Errorf("resource %s is not a component", obj.GetObjectKind().GroupVersionKind())
+	}
+
+	return instance, nil
+}
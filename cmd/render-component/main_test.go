@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/operator-framework/api/pkg/lib/version"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+const testSpec = `
+apiVersion: components.platform.opendatahub.io/v1alpha1
+kind: Dashboard
+metadata:
+  name: default-dashboard
+`
+
+const testKustomization = `
+apiVersion: kustomize.config.k8s.io/v1beta1
+resources:
+- configmaps.yaml
+- dashboardconfig.yaml
+`
+
+const testConfigMaps = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: in-sync
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed
+data:
+  key: value
+`
+
+const testDashboardConfig = `
+apiVersion: opendatahub.io/v1alpha
+kind: OdhDashboardConfig
+metadata:
+  name: odh-dashboard-config
+`
+
+const testParams = `odh-dashboard-image=quay.io/opendatahub/odh-dashboard:latest
+dashboard-url=
+section-title=
+`
+
+func newTestOptions(t *testing.T) options {
+	t.Helper()
+
+	g := NewWithT(t)
+	dir := t.TempDir()
+	overlay := filepath.Join(dir, "manifests", "dashboard", "odh")
+
+	g.Expect(os.MkdirAll(overlay, 0o755)).Should(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "dashboard.yaml"), []byte(testSpec), 0o600)).Should(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(overlay, "kustomization.yaml"), []byte(testKustomization), 0o600)).Should(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(overlay, "configmaps.yaml"), []byte(testConfigMaps), 0o600)).Should(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(overlay, "dashboardconfig.yaml"), []byte(testDashboardConfig), 0o600)).Should(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(overlay, "params.env"), []byte(testParams), 0o600)).Should(Succeed())
+
+	return options{
+		spec:             filepath.Join(dir, "dashboard.yaml"),
+		manifestsPath:    filepath.Join(dir, "manifests"),
+		namespace:        "opendatahub",
+		platform:         string(cluster.OpenDataHub),
+		domain:           "apps.example.com",
+		fieldOwnerFormat: deploy.StandardFieldOwnerFormat,
+	}
+}
+
+func TestRun(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Setenv("RELATED_IMAGE_ODH_DASHBOARD_IMAGE", "quay.io/opendatahub/odh-dashboard:v2.30")
+
+	o := newTestOptions(t)
+	out := bytes.Buffer{}
+
+	changed, err := run(t.Context(), o, &out)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changed).Should(BeZero())
+
+	// rendered from the platform overlay, labeled by the kustomize action of
+	// the component and customized by its controller
+	g.Expect(out.String()).Should(And(
+		ContainSubstring("name: in-sync"),
+		ContainSubstring("name: changed"),
+		ContainSubstring("namespace: opendatahub"),
+		ContainSubstring("app.opendatahub.io/dashboard: \"true\""),
+		ContainSubstring("opendatahub.io/managed: \"false\""),
+	))
+
+	// the images are set on startup, the parameters by the controller
+	params, err := os.ReadFile(filepath.Join(o.manifestsPath, "dashboard", "odh", "params.env"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(params)).Should(And(
+		ContainSubstring("odh-dashboard-image=quay.io/opendatahub/odh-dashboard:v2.30"),
+		ContainSubstring("dashboard-url=https://data-science-gateway.apps.example.com/"),
+		ContainSubstring("section-title=OpenShift Open Data Hub"),
+	))
+}
+
+func TestRunUnknownComponent(t *testing.T) {
+	g := NewWithT(t)
+
+	o := newTestOptions(t)
+	g.Expect(os.WriteFile(o.spec, []byte(`
+apiVersion: services.platform.opendatahub.io/v1alpha1
+kind: Monitoring
+metadata:
+  name: default-monitoring
+`), 0o600)).Should(Succeed())
+
+	_, err := run(t.Context(), o, &bytes.Buffer{})
+	g.Expect(err).Should(MatchError(ContainSubstring("not a built-in component")))
+}
+
+func TestRenderDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	o := newTestOptions(t)
+
+	// the dashboard config is not part of the scheme of the fake client
+	overlay := filepath.Join(o.manifestsPath, "dashboard", "odh")
+	g.Expect(os.WriteFile(filepath.Join(overlay, "kustomization.yaml"), []byte("resources:\n- configmaps.yaml\n"), 0o600)).Should(Succeed())
+
+	release := common.Release{
+		Name:    cluster.OpenDataHub,
+		Version: version.OperatorVersion{Version: semver.Version{Major: 2, Minor: 30}},
+	}
+
+	dsci := dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+		Spec: dsciv2.DSCInitializationSpec{
+			ApplicationsNamespace: "odh-apps",
+		},
+	}
+	dsci.Status.Release = release
+
+	dashboard := componentApi.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       componentApi.DashboardInstanceName,
+			UID:        "dashboard-uid",
+			Generation: 3,
+		},
+	}
+
+	// as last deployed by the operator for the live instance
+	newLive := func(name string, value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "odh-apps",
+				Labels: map[string]string{
+					labels.PlatformPartOf:             "dashboard",
+					labels.ODH.Component("dashboard"): labels.True,
+					labels.K8SCommon.PartOf:           "dashboard",
+				},
+				Annotations: map[string]string{
+					annotations.InstanceGeneration: "3",
+					annotations.InstanceName:       componentApi.DashboardInstanceName,
+					annotations.InstanceUID:        "dashboard-uid",
+					annotations.PlatformType:       string(release.Name),
+					annotations.PlatformVersion:    release.Version.String(),
+					// left by the namespace transformer of kustomize
+					"internal.config.kubernetes.io/previousKinds":      "ConfigMap",
+					"internal.config.kubernetes.io/previousNames":      name,
+					"internal.config.kubernetes.io/previousNamespaces": "default",
+				},
+			},
+			Data: map[string]string{"key": value},
+		}
+	}
+
+	s, err := newScheme()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	ingress := configv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       configv1.IngressSpec{Domain: "apps.example.com"},
+	}
+
+	cl, err := fakeclient.New(
+		fakeclient.WithScheme(s),
+		fakeclient.WithObjects(&dsci, &ingress, &dashboard, newLive("in-sync", "value"), newLive("changed", "previous")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	instance, err := decodeSpec(s, o.spec)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	out := bytes.Buffer{}
+
+	changed, err := renderDiff(ctx, &rest.Config{}, cl, instance, o, &out)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// only the data of the changed ConfigMap differs, the labels set by the
+	// component and the annotations set by the deploy action are rendered as
+	// well
+	g.Expect(changed).Should(Equal(1))
+	g.Expect(out.String()).Should(And(
+		ContainSubstring("live/configmap/odh-apps/changed"),
+		ContainSubstring("-  key: previous"),
+		ContainSubstring("+  key: value"),
+		Not(ContainSubstring("in-sync")),
+	))
+}
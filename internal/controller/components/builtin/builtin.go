@@ -0,0 +1,73 @@
+// Package builtin registers the components shipped with the operator.
+package builtin
+
+import (
+	"slices"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/datasciencepipelines"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/feastoperator"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/kueue"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/llamastackoperator"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/modelcontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/modelmeshserving"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/modelregistry"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/ray"
+	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/trainingoperator"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/trustyai"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/workbenches"
+)
+
+type registerOpts struct {
+	disabled []string
+}
+
+type RegisterOpts func(*registerOpts)
+
+// WithDisabled skips the registration of the components with the given names,
+// i.e. to feature gate components.
+func WithDisabled(names ...string) RegisterOpts {
+	return func(o *registerOpts) {
+		o.disabled = append(o.disabled, names...)
+	}
+}
+
+// ComponentHandlers returns the handlers of the components shipped with the
+// operator, in registration order.
+func ComponentHandlers() []cr.ComponentHandler {
+	return []cr.ComponentHandler{
+		dashboard.NewComponentHandler(),
+		datasciencepipelines.NewComponentHandler(),
+		feastoperator.NewComponentHandler(),
+		kserve.NewComponentHandler(),
+		kueue.NewComponentHandler(),
+		llamastackoperator.NewComponentHandler(),
+		modelcontroller.NewComponentHandler(),
+		modelmeshserving.NewComponentHandler(),
+		modelregistry.NewComponentHandler(),
+		ray.NewComponentHandler(),
+		trainingoperator.NewComponentHandler(),
+		trustyai.NewComponentHandler(),
+		workbenches.NewComponentHandler(),
+	}
+}
+
+// RegisterBuiltinComponents adds the components shipped with the operator to
+// the given registry. It is not thread safe, and is supposed to be called once
+// during startup.
+func RegisterBuiltinComponents(registry *cr.Registry, opts ...RegisterOpts) {
+	o := registerOpts{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for _, ch := range ComponentHandlers() {
+		if slices.Contains(o.disabled, ch.GetName()) {
+			continue
+		}
+
+		registry.Add(ch)
+	}
+}
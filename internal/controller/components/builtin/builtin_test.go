@@ -0,0 +1,30 @@
+package builtin_test
+
+import (
+	"testing"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/builtin"
+	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRegisterBuiltinComponents(t *testing.T) {
+	g := NewWithT(t)
+
+	registry := &cr.Registry{}
+	builtin.RegisterBuiltinComponents(registry, builtin.WithDisabled(componentApi.RayComponentName))
+
+	names := make([]string, 0)
+	g.Expect(registry.ForEach(func(ch cr.ComponentHandler) error {
+		names = append(names, ch.GetName())
+		return nil
+	})).Should(Succeed())
+
+	g.Expect(names).Should(And(
+		HaveLen(len(builtin.ComponentHandlers())-1),
+		ContainElements(componentApi.DashboardComponentName, componentApi.KserveComponentName),
+		Not(ContainElement(componentApi.RayComponentName)),
+	))
+}
@@ -53,6 +53,7 @@ var (
 
 	conditionTypes = []string{
 		status.ConditionDeploymentsAvailable,
+		status.ConditionWorkloadsAvailable,
 	}
 )
 
@@ -26,8 +26,10 @@ import (
 
 type componentHandler struct{}
 
-func init() { //nolint:gochecknoinits
-	cr.Add(&componentHandler{})
+// NewComponentHandler returns the handler of the component, to be added to the
+// component registry.
+func NewComponentHandler() cr.ComponentHandler {
+	return &componentHandler{}
 }
 
 func (s *componentHandler) GetName() string {
@@ -61,6 +61,7 @@ var (
 	conditionTypes = []string{
 		status.ConditionArgoWorkflowAvailable,
 		status.ConditionDeploymentsAvailable,
+		status.ConditionWorkloadsAvailable,
 	}
 
 	paramsPath = path.Join(odhdeploy.DefaultManifestPath, ComponentName, "base")
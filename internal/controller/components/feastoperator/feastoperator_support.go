@@ -28,6 +28,7 @@ var (
 
 	conditionTypes = []string{
 		status.ConditionDeploymentsAvailable,
+		status.ConditionWorkloadsAvailable,
 	}
 )
 
@@ -43,6 +43,7 @@ var (
 	conditionTypes = []string{
 		status.ConditionServingAvailable,
 		status.ConditionDeploymentsAvailable,
+		status.ConditionWorkloadsAvailable,
 	}
 )
 
@@ -56,8 +57,10 @@ var (
 
 type componentHandler struct{}
 
-func init() { //nolint:gochecknoinits
-	cr.Add(&componentHandler{})
+// NewComponentHandler returns the handler of the component, to be added to the
+// component registry.
+func NewComponentHandler() cr.ComponentHandler {
+	return &componentHandler{}
 }
 
 // Init to set oauth image.
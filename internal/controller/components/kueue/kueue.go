@@ -36,8 +36,10 @@ var (
 
 type componentHandler struct{}
 
-func init() { //nolint:gochecknoinits
-	cr.Add(&componentHandler{})
+// NewComponentHandler returns the handler of the component, to be added to the
+// component registry.
+func NewComponentHandler() cr.ComponentHandler {
+	return &componentHandler{}
 }
 
 func (s *componentHandler) GetName() string {
@@ -42,6 +42,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/kustomize"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/deployments"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/releases"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/workloads"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/handlers"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/predicates"
@@ -143,9 +144,12 @@ func (s *componentHandler) NewComponentReconciler(ctx context.Context, mgr ctrl.
 		WithAction(manageDefaultKueueResourcesAction).
 		WithAction(manageKueueAdminRoleBinding).
 		WithAction(deploy.NewAction(
+			deploy.WithSettings(types.SettingsFromContext(ctx)),
 			deploy.WithCache(),
+			deploy.WithInstanceUIDLabel(),
 		)).
 		WithAction(deployments.NewAction()).
+		WithAction(workloads.NewAction()).
 		WithAction(func(ctx context.Context, rr *types.ReconciliationRequest) error {
 			kueueCRInstance, ok := rr.Instance.(*componentApi.Kueue)
 			if !ok {
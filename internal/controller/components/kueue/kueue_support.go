@@ -58,6 +58,7 @@ var (
 
 	conditionTypes = []string{
 		status.ConditionDeploymentsAvailable,
+		status.ConditionWorkloadsAvailable,
 	}
 
 	supportedGPUMap = map[string]string{
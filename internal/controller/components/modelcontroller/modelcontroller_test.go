@@ -3,6 +3,7 @@ package modelcontroller
 
 import (
 	"encoding/json"
+	"os"
 	"testing"
 
 	gt "github.com/onsi/gomega/types"
@@ -12,6 +13,9 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
 	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/modelmeshserving"
+	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
@@ -20,13 +24,17 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
 
-	// side import for component registry.
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/kserve"
-	_ "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/modelmeshserving"
-
 	. "github.com/onsi/gomega"
 )
 
+func TestMain(m *testing.M) {
+	// the ModelController depends on whether KServe and ModelMesh are enabled
+	cr.Add(kserve.NewComponentHandler())
+	cr.Add(modelmeshserving.NewComponentHandler())
+
+	os.Exit(m.Run())
+}
+
 func TestGetName(t *testing.T) {
 	g := NewWithT(t)
 	handler := &componentHandler{}
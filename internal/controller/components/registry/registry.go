@@ -28,9 +28,22 @@ type ComponentHandler interface {
 	IsEnabled(dsc *dscv2.DataScienceCluster) bool
 }
 
+// StartupPolicy defines how the operator handles components failing to start.
+type StartupPolicy string
+
+const (
+	// StartupPolicyFailFast stops the operator when any component fails to start.
+	StartupPolicyFailFast StartupPolicy = "fail-fast"
+	// StartupPolicyDegraded marks the components failing to start as failed, so
+	// that they are reported in the DataScienceCluster status, and keeps the
+	// other components running.
+	StartupPolicyDegraded StartupPolicy = "degraded"
+)
+
 // Registry is a struct that maintains a list of registered ComponentHandlers.
 type Registry struct {
 	handlers []ComponentHandler
+	failed   map[string]error
 }
 
 var r = &Registry{}
@@ -53,6 +66,22 @@ func (r *Registry) ForEach(f func(ch ComponentHandler) error) error {
 	return errs.ErrorOrNil()
 }
 
+// MarkFailed records that the component with the given name failed to start.
+// not thread safe, supposed to be called during startup.
+func (r *Registry) MarkFailed(componentName string, err error) {
+	if r.failed == nil {
+		r.failed = make(map[string]error)
+	}
+
+	r.failed[componentName] = err
+}
+
+// Failure returns the error the component with the given name failed to start
+// with, or nil if it started successfully.
+func (r *Registry) Failure(componentName string) error {
+	return r.failed[componentName]
+}
+
 // IsComponentEnabled checks if a component with the given name is enabled in the DataScienceCluster.
 // Returns false if the component is not found.
 func (r *Registry) IsComponentEnabled(componentName string, dsc *dscv2.DataScienceCluster) bool {
@@ -72,6 +101,14 @@ func ForEach(f func(ch ComponentHandler) error) error {
 	return r.ForEach(f)
 }
 
+func MarkFailed(componentName string, err error) {
+	r.MarkFailed(componentName, err)
+}
+
+func Failure(componentName string) error {
+	return r.Failure(componentName)
+}
+
 func DefaultRegistry() *Registry {
 	return r
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/orphans"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/predicates/dependent"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/reconciler"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
@@ -63,6 +64,7 @@ func NewDataScienceClusterReconciler(ctx context.Context, mgr ctrl.Manager) erro
 		WithAction(updateStatus).
 		WithAction(provisionComponents).
 		WithAction(deploy.NewAction(
+			deploy.WithSettings(types.SettingsFromContext(ctx)),
 			deploy.WithCache()),
 		).
 		WithAction(gc.NewAction(
@@ -72,6 +74,11 @@ func NewDataScienceClusterReconciler(ctx context.Context, mgr ctrl.Manager) erro
 				},
 			),
 		)).
+		// collect cluster scoped resources that have been deployed by
+		// components that do not exist anymore
+		WithAction(orphans.NewAction(
+			orphans.WithOwnerTypesFn(componentTypes),
+		)).
 		WithConditions(status.ConditionTypeComponentsReady).
 		Build(ctx)
 
@@ -7,12 +7,14 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
 	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 )
 
 // computeComponentsStatus checks the status of all registered components in a DataScienceCluster instance
@@ -36,9 +38,20 @@ func computeComponentsStatus(
 	}
 
 	notReadyComponents := make([]string, 0)
+	failedComponents := make([]string, 0)
 	managedComponent := 0
 
 	err := reg.ForEach(func(component cr.ComponentHandler) error {
+		// components that failed to start have no running controller, so their
+		// status is stale
+		if failure := reg.Failure(component.GetName()); failure != nil {
+			if component.IsEnabled(instance) {
+				failedComponents = append(failedComponents, fmt.Sprintf("%s (%v)", component.GetName(), failure))
+			}
+
+			return nil
+		}
+
 		cs, err := component.UpdateDSCStatus(ctx, rr)
 		if err != nil {
 			notReadyComponents = append(notReadyComponents, component.GetName())
@@ -59,6 +72,13 @@ func computeComponentsStatus(
 	})
 
 	switch {
+	case len(failedComponents) > 0:
+		rr.Conditions.SetCondition(common.Condition{
+			Type:    status.ConditionTypeComponentsReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  status.ComponentsFailedReason,
+			Message: fmt.Sprintf("Some components failed to start: %s", strings.Join(failedComponents, ", ")),
+		})
 	case len(notReadyComponents) > 0:
 		rr.Conditions.SetCondition(common.Condition{
 			Type:    status.ConditionTypeComponentsReady,
@@ -84,3 +104,27 @@ func computeComponentsStatus(
 
 	return nil
 }
+
+// componentTypes returns the GroupVersionKind of all the registered components, it is
+// used to find out the resources left behind by components that have been removed.
+func componentTypes(_ context.Context, rr *types.ReconciliationRequest) ([]schema.GroupVersionKind, error) {
+	instance, ok := rr.Instance.(*dscv2.DataScienceCluster)
+	if !ok {
+		return nil, errors.New("failed to convert to DataScienceCluster")
+	}
+
+	result := make([]schema.GroupVersionKind, 0)
+
+	err := cr.ForEach(func(component cr.ComponentHandler) error {
+		ogvk, err := resources.GetGroupVersionKindForObject(rr.Client.Scheme(), component.NewCRObject(instance))
+		if err != nil {
+			return fmt.Errorf("unable to determine GVK for component %s: %w", component.GetName(), err)
+		}
+
+		result = append(result, ogvk)
+
+		return nil
+	})
+
+	return result, err
+}
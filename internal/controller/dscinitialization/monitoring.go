@@ -5,6 +5,7 @@ import (
 	b64 "encoding/base64"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -21,6 +22,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/common"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 )
 
 var (
@@ -435,6 +437,12 @@ func createMonitoringProxySecret(ctx context.Context, cli client.Client, name st
 
 func (r *DSCInitializationReconciler) configureSegmentIO(ctx context.Context, dsciInit *dsciv2.DSCInitialization) error {
 	log := logf.FromContext(ctx)
+	// remove segment.io when telemetry has been opted out, it may have been
+	// configured before
+	if enabled, err := strconv.ParseBool(dsciInit.GetAnnotations()[annotations.TelemetryEnabled]); err == nil && !enabled {
+		log.Info("telemetry disabled, removing segment.io configuration")
+		return r.removeSegmentIO(ctx, dsciInit.Spec.ApplicationsNamespace)
+	}
 	// create segment.io only when configmap does not exist in the cluster
 	segmentioConfigMap := &corev1.ConfigMap{}
 	if err := r.Client.Get(ctx, client.ObjectKey{
@@ -462,6 +470,21 @@ func (r *DSCInitializationReconciler) configureSegmentIO(ctx context.Context, ds
 	return nil
 }
 
+func (r *DSCInitializationReconciler) removeSegmentIO(ctx context.Context, namespace string) error {
+	segmentioObjects := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "odh-segment-key-config"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "odh-segment-key"}},
+	}
+
+	for _, obj := range segmentioObjects {
+		if err := r.Client.Delete(ctx, obj); err != nil && !k8serr.IsNotFound(err) {
+			return fmt.Errorf("error deleting segment.io resource %s/%s: %w", namespace, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
 func (r *DSCInitializationReconciler) configureCommonMonitoring(ctx context.Context, dsciInit *dsciv2.DSCInitialization) error {
 	log := logf.FromContext(ctx)
 	if err := r.configureSegmentIO(ctx, dsciInit); err != nil {
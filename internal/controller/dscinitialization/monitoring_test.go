@@ -0,0 +1,54 @@
+package dscinitialization
+
+import (
+	"testing"
+
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestConfigureSegmentIOOptOut(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	appsNS := xid.New().String()
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: appsNS, Name: "odh-segment-key-config"}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: appsNS, Name: "odh-segment-key"}}
+
+	cli, err := fakeclient.New(fakeclient.WithObjects(cm, secret))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// telemetry opted out after segment.io has been configured
+	dscInit := &dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-dsc",
+			Annotations: map[string]string{annotations.TelemetryEnabled: "false"},
+		},
+		Spec: dsciv2.DSCInitializationSpec{
+			ApplicationsNamespace: appsNS,
+		},
+	}
+
+	r := &DSCInitializationReconciler{Client: cli}
+
+	g.Expect(r.configureSegmentIO(ctx, dscInit)).Should(Succeed())
+
+	err = cli.Get(ctx, client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+	g.Expect(k8serr.IsNotFound(err)).Should(BeTrue())
+
+	err = cli.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	g.Expect(k8serr.IsNotFound(err)).Should(BeTrue())
+
+	// nothing left to remove
+	g.Expect(r.configureSegmentIO(ctx, dscInit)).Should(Succeed())
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/template"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/reconciler"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 )
 
 //nolint:gochecknoinits
@@ -94,14 +95,17 @@ func (h *ServiceHandler) NewReconciler(ctx context.Context, mgr ctrl.Manager) er
 
 	// Configure action chain for resource lifecycle
 	reconcilerBuilder = reconcilerBuilder.
-		WithAction(createGatewayInfrastructure).          // Core gateway setup
-		WithAction(createKubeAuthProxyInfrastructure).    // Authentication proxy
-		WithAction(createEnvoyFilter).                    // Service mesh integration
-		WithAction(createDestinationRule).                // Traffic management
-		WithAction(template.NewAction()).                 // Template rendering
-		WithAction(deploy.NewAction(deploy.WithCache())). // Resource deployment with caching
-		WithAction(syncGatewayConfigStatus).              // Status synchronization
-		WithAction(gc.NewAction())                        // Garbage collection
+		WithAction(createGatewayInfrastructure).       // Core gateway setup
+		WithAction(createKubeAuthProxyInfrastructure). // Authentication proxy
+		WithAction(createEnvoyFilter).                 // Service mesh integration
+		WithAction(createDestinationRule).             // Traffic management
+		WithAction(template.NewAction()).              // Template rendering
+		WithAction(deploy.NewAction(                   // Resource deployment with caching
+			deploy.WithSettings(odhtypes.SettingsFromContext(ctx)),
+			deploy.WithCache(),
+		)).
+		WithAction(syncGatewayConfigStatus). // Status synchronization
+		WithAction(gc.NewAction())           // Garbage collection
 
 	// Build and validate the reconciler
 	if _, err := reconcilerBuilder.Build(ctx); err != nil {
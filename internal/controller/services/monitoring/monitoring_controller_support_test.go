@@ -2,21 +2,31 @@
 package monitoring
 
 import (
+	"bytes"
+	"io/fs"
+	"path"
 	"reflect"
 	"strings"
 	"testing"
+	gt "text/template"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	yamlserializer "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
 	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	componentMonitoring "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/monitors"
 	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	templateutils "github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/template"
 )
 
 func TestGetTemplateDataAcceleratorMetrics(t *testing.T) {
@@ -440,3 +450,35 @@ func TestMonitoringStackThanosQuerierIntegration(t *testing.T) {
 		})
 	}
 }
+
+// TestComponentPrometheusRules validates the alerting rules bundled with the
+// components, so that malformed rules are caught before being shipped.
+func TestComponentPrometheusRules(t *testing.T) {
+	files, err := fs.Glob(componentMonitoring.ComponentRulesFS, "*/monitoring/*-prometheusrules.tmpl.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	decoder := yamlserializer.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	data := map[string]any{
+		"Namespace":            "opendatahub",
+		"ApplicationNamespace": "opendatahub",
+	}
+
+	for _, file := range files {
+		t.Run(path.Dir(path.Dir(file)), func(t *testing.T) {
+			tmpl, err := gt.New("").Option("missingkey=error").Funcs(templateutils.TextTemplateFuncMap()).ParseFS(componentMonitoring.ComponentRulesFS, file)
+			require.NoError(t, err)
+
+			var buffer bytes.Buffer
+			require.NoError(t, tmpl.Templates()[0].Execute(&buffer, data))
+
+			rules, err := resources.Decode(decoder, buffer.Bytes())
+			require.NoError(t, err)
+			require.NotEmpty(t, rules)
+
+			for i := range rules {
+				assert.NoError(t, monitors.ValidatePrometheusRule(&rules[i]))
+			}
+		})
+	}
+}
@@ -95,6 +95,13 @@ const (
 	ConditionTypeProvisioningSucceeded       = "ProvisioningSucceeded"
 	ConditionDeploymentsNotAvailableReason   = "DeploymentsNotReady"
 	ConditionDeploymentsAvailable            = "DeploymentsAvailable"
+	ConditionWorkloadsNotAvailableReason     = "WorkloadsNotReady"
+	ConditionWorkloadsAvailable              = "WorkloadsAvailable"
+	ConditionWorkloadsFailedReason           = "WorkloadFailed"
+	ConditionDependenciesReady               = "DependenciesReady"
+	ConditionDependenciesBlockedReason       = "Blocked"
+	ConditionFieldsOwned                     = "FieldsOwned"
+	ConditionFieldManagerConflictReason      = "FieldManagerConflict"
 	ConditionServerlessAvailable             = "ServerlessAvailable"
 	ConditionServiceMeshAvailable            = "ServiceMeshAvailable"
 	ConditionArgoWorkflowAvailable           = "ArgoWorkflowAvailable"
@@ -107,6 +114,7 @@ const (
 	ConditionInstrumentationAvailable        = "InstrumentationAvailable"
 	ConditionAlertingAvailable               = "AlertingAvailable"
 	ConditionThanosQuerierAvailable          = "ThanosQuerierAvailable"
+	ConditionSupportedConfiguration          = "SupportedConfiguration"
 )
 
 const (
@@ -130,6 +138,17 @@ const (
 	NotReadyReason  = "NotReady"
 	ErrorReason     = "Error"
 	ReadyReason     = "Ready"
+
+	RenderErrorReason           = "RenderError"
+	ApplyConflictReason         = "ApplyConflict"
+	ApplyRetriesExhaustedReason = "ApplyRetriesExhausted"
+	WaitingReason               = "Waiting"
+	ValidationFailedReason      = "ValidationFailed"
+	RenderLimitExceededReason   = "RenderLimitExceeded"
+	ComponentsFailedReason      = "ComponentsFailed"
+
+	DevFlagsSetReason  = "DevFlagsSet"
+	DevFlagsSetMessage = "Custom manifests are set through devFlags, this configuration is not supported"
 )
 
 const (
@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// Capability is an optional API a cluster may serve, identified by one of its
+// kinds.
+type Capability struct {
+	Name string
+	GVK  schema.GroupVersionKind
+}
+
+// DefaultCapabilities are the capabilities manifests most commonly depend on.
+var DefaultCapabilities = []Capability{
+	{Name: "Routes", GVK: gvk.Route},
+	{Name: "ServiceMonitors", GVK: gvk.ServiceMonitorServiceMesh},
+	{Name: "Istio", GVK: gvk.IstioGateway},
+	{Name: "GatewayAPI", GVK: gvk.KubernetesGateway},
+}
+
+// HasAPI returns whether the cluster serves the given kind, either as a CRD or
+// as a built-in or aggregated API. The outcome is cached if the client has an
+// APICache attached, see WithAPICache.
+func HasAPI(cli client.Client, kind schema.GroupVersionKind) (bool, error) {
+	return apiCacheFor(cli).cached(apiCacheKey{gvk: kind}, func() (bool, error) {
+		_, err := cli.RESTMapper().RESTMapping(kind.GroupKind(), kind.Version)
+		switch {
+		case meta.IsNoMatchError(err):
+			return false, nil
+		case err != nil:
+			return false, err
+		default:
+			return true, nil
+		}
+	})
+}
+
+// DetectCapabilities returns, for each of the given capabilities, whether the
+// cluster serves it.
+func DetectCapabilities(cli client.Client, capabilities ...Capability) (map[string]bool, error) {
+	result := make(map[string]bool, len(capabilities))
+
+	for _, c := range capabilities {
+		ok, err := HasAPI(cli, c.GVK)
+		if err != nil {
+			return nil, err
+		}
+
+		result[c.Name] = ok
+	}
+
+	return result, nil
+}
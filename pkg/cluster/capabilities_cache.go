@@ -0,0 +1,164 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type apiCacheKey struct {
+	gvk schema.GroupVersionKind
+	crd bool
+}
+
+type apiCacheEntry struct {
+	served  bool
+	expires time.Time
+}
+
+// APICache holds the outcome of the HasAPI and HasCRD lookups made with the
+// client it is attached to, so that actions and dynamic watches checking for
+// optional APIs on every reconciliation don't hit the discovery API each time.
+// It is disabled unless a TTL is set.
+type APICache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[apiCacheKey]apiCacheEntry
+}
+
+type APICacheOpt func(*APICache)
+
+// WithAPICacheTTL sets how long the outcome of HasAPI and HasCRD is cached, a
+// value of zero, the default, disables caching.
+func WithAPICacheTTL(ttl time.Duration) APICacheOpt {
+	return func(c *APICache) {
+		c.ttl = ttl
+	}
+}
+
+// NewAPICache returns an APICache, to be attached to a client with
+// WithAPICache.
+func NewAPICache(opts ...APICacheOpt) *APICache {
+	c := APICache{
+		entries: map[apiCacheKey]apiCacheEntry{},
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &c
+}
+
+func (c *APICache) get(key apiCacheKey) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return false, false
+	}
+
+	return e.served, true
+}
+
+func (c *APICache) set(key apiCacheKey, served bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.entries[key] = apiCacheEntry{
+		served:  served,
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+// cached returns the outcome of the given lookup, calling it only if there is
+// no valid cached value. Errors are not cached. A nil cache always calls the
+// lookup.
+func (c *APICache) cached(key apiCacheKey, lookup func() (bool, error)) (bool, error) {
+	if c == nil {
+		return lookup()
+	}
+
+	if served, ok := c.get(key); ok {
+		return served, nil
+	}
+
+	served, err := lookup()
+	if err != nil {
+		return false, err
+	}
+
+	c.set(key, served)
+
+	return served, nil
+}
+
+// Invalidate drops the cached outcome of HasAPI and HasCRD.
+func (c *APICache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// InvalidateOnCRDChanges registers a handler invalidating the cache each time a
+// CustomResourceDefinition is created, updated or deleted, so that newly
+// installed or removed APIs are detected before the TTL expires.
+func (c *APICache) InvalidateOnCRDChanges(ctx context.Context, informers cache.Informers) error {
+	informer, err := informers.GetInformer(ctx, &apiextensionsv1.CustomResourceDefinition{})
+	if err != nil {
+		return fmt.Errorf("unable to get the CustomResourceDefinition informer: %w", err)
+	}
+
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { c.Invalidate() },
+		UpdateFunc: func(any, any) { c.Invalidate() },
+		DeleteFunc: func(any) { c.Invalidate() },
+	})
+	if err != nil {
+		return fmt.Errorf("unable to register the CustomResourceDefinition handler: %w", err)
+	}
+
+	return nil
+}
+
+type apiCachingClient struct {
+	client.Client
+
+	apis *APICache
+}
+
+// WithAPICache returns a client caching the outcome of the HasAPI and HasCRD
+// lookups made with it in the given cache.
+func WithAPICache(cli client.Client, apis *APICache) client.Client {
+	return &apiCachingClient{Client: cli, apis: apis}
+}
+
+// apiCacheFor returns the cache attached to the given client, nil if none.
+func apiCacheFor(cli client.Client) *APICache {
+	if c, ok := cli.(*apiCachingClient); ok {
+		return c.apis
+	}
+
+	return nil
+}
+
+// InvalidateAPICache drops the outcome of HasAPI and HasCRD cached for the
+// given client, if it has an APICache attached.
+func InvalidateAPICache(cli client.Client) {
+	if c := apiCacheFor(cli); c != nil {
+		c.Invalidate()
+	}
+}
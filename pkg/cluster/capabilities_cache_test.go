@@ -0,0 +1,47 @@
+package cluster_test
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHasAPICache(t *testing.T) {
+	g := NewWithT(t)
+
+	without, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	s.AddKnownTypeWithName(gvk.KubernetesGateway, &unstructured.Unstructured{})
+
+	with, err := fakeclient.New(fakeclient.WithScheme(s))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	apis := cluster.NewAPICache(cluster.WithAPICacheTTL(time.Minute))
+
+	g.Expect(cluster.HasAPI(cluster.WithAPICache(without, apis), gvk.KubernetesGateway)).Should(BeFalse())
+
+	// the outcome is cached until the TTL expires, or the cache is invalidated
+	g.Expect(cluster.HasAPI(cluster.WithAPICache(with, apis), gvk.KubernetesGateway)).Should(BeFalse())
+
+	// the cache is only used by the clients it is attached to
+	g.Expect(cluster.HasAPI(with, gvk.KubernetesGateway)).Should(BeTrue())
+
+	apis.Invalidate()
+	g.Expect(cluster.HasAPI(cluster.WithAPICache(with, apis), gvk.KubernetesGateway)).Should(BeTrue())
+
+	// caching is disabled with a zero TTL
+	disabled := cluster.NewAPICache()
+	g.Expect(cluster.HasAPI(cluster.WithAPICache(without, disabled), gvk.KubernetesGateway)).Should(BeFalse())
+	g.Expect(cluster.HasAPI(cluster.WithAPICache(with, disabled), gvk.KubernetesGateway)).Should(BeTrue())
+}
@@ -90,6 +90,12 @@ func GetRelease() common.Release {
 	return clusterConfig.Release
 }
 
+// SetRelease overrides the release detected by Init, so that the components can
+// be rendered for a given platform out of the operator.
+func SetRelease(release common.Release) {
+	clusterConfig.Release = release
+}
+
 func GetClusterInfo() ClusterInfo {
 	return clusterConfig.ClusterInfo
 }
@@ -275,7 +281,7 @@ func getClusterInfo(ctx context.Context, cli client.Client) (ClusterInfo, error)
 		Version: version.OperatorVersion{
 			Version: semver.Version{},
 		},
-		Type:        "OpenShift",
+		Type:        ClusterTypeOpenShift,
 		FipsEnabled: false,
 	}
 	// Set OCP
@@ -23,6 +23,9 @@ const (
 	// Default cluster-scope Authentication CR name.
 	ClusterAuthenticationObj = "cluster"
 
+	// ClusterTypeOpenShift is the type of OpenShift clusters.
+	ClusterTypeOpenShift = "OpenShift"
+
 	// Default OpenShift version CR name.
 	OpenShiftVersionObj = "version"
 
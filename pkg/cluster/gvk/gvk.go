@@ -6,6 +6,7 @@ import (
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -108,6 +109,30 @@ var (
 		Kind:    "Deployment",
 	}
 
+	StatefulSet = schema.GroupVersionKind{
+		Group:   appsv1.SchemeGroupVersion.Group,
+		Version: appsv1.SchemeGroupVersion.Version,
+		Kind:    "StatefulSet",
+	}
+
+	DaemonSet = schema.GroupVersionKind{
+		Group:   appsv1.SchemeGroupVersion.Group,
+		Version: appsv1.SchemeGroupVersion.Version,
+		Kind:    "DaemonSet",
+	}
+
+	Job = schema.GroupVersionKind{
+		Group:   batchv1.SchemeGroupVersion.Group,
+		Version: batchv1.SchemeGroupVersion.Version,
+		Kind:    "Job",
+	}
+
+	CronJob = schema.GroupVersionKind{
+		Group:   batchv1.SchemeGroupVersion.Group,
+		Version: batchv1.SchemeGroupVersion.Version,
+		Kind:    "CronJob",
+	}
+
 	ResourceQuota = schema.GroupVersionKind{
 		Group:   corev1.SchemeGroupVersion.Group,
 		Version: corev1.SchemeGroupVersion.Version,
@@ -360,6 +385,12 @@ var (
 		Kind:    "AuthorizationPolicy",
 	}
 
+	PeerAuthentication = schema.GroupVersionKind{
+		Group:   "security.istio.io",
+		Version: "v1beta1",
+		Kind:    "PeerAuthentication",
+	}
+
 	IstioGateway = schema.GroupVersionKind{
 		Group:   "networking.istio.io",
 		Version: "v1beta1",
@@ -414,6 +445,18 @@ var (
 		Kind:    "MultiKueueCluster",
 	}
 
+	CertManagerCertificate = schema.GroupVersionKind{
+		Group:   "cert-manager.io",
+		Version: "v1",
+		Kind:    "Certificate",
+	}
+
+	ExternalSecret = schema.GroupVersionKind{
+		Group:   "external-secrets.io",
+		Version: "v1beta1",
+		Kind:    "ExternalSecret",
+	}
+
 	KueueConfigV1 = schema.GroupVersionKind{
 		Group:   "kueue.openshift.io",
 		Version: "v1",
@@ -588,6 +631,12 @@ var (
 		Kind:    "Authorino",
 	}
 
+	AuthConfig = schema.GroupVersionKind{
+		Group:   "authorino.kuadrant.io",
+		Version: "v1beta3",
+		Kind:    "AuthConfig",
+	}
+
 	ValidatingAdmissionPolicy = schema.GroupVersionKind{
 		Group:   "admissionregistration.k8s.io",
 		Version: "v1",
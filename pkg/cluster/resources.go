@@ -318,8 +318,13 @@ func GetCRD(ctx context.Context, cli client.Client, name string) (apiextensionsv
 	return obj, nil
 }
 
+// HasCRD checks if the CRD of the given kind exists and stores its version, see
+// HasCRDWithVersion. The outcome is cached if the client has an
+// APICache attached, see WithAPICache.
 func HasCRD(ctx context.Context, cli client.Client, gvk schema.GroupVersionKind) (bool, error) {
-	return HasCRDWithVersion(ctx, cli, gvk.GroupKind(), gvk.Version)
+	return apiCacheFor(cli).cached(apiCacheKey{gvk: gvk, crd: true}, func() (bool, error) {
+		return HasCRDWithVersion(ctx, cli, gvk.GroupKind(), gvk.Version)
+	})
 }
 
 // HasCRDWithVersion checks if a CustomResourceDefinition (CRD) exists with the specified version.
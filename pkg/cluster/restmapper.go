@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"net/http"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ResettableRESTMapper is a dynamic RESTMapper, as the one controller-runtime
+// creates by default, that can be reset.
+//
+// The controller-runtime mapper discovers the resources of a group lazily, but
+// it caches the versions of the groups it has already seen. A CRD adding a new
+// version to a known group is never picked up. Reset drops all the discovery
+// data, so the next lookup runs the discovery again.
+type ResettableRESTMapper struct {
+	mu     sync.RWMutex
+	newFn  func() (meta.RESTMapper, error)
+	mapper meta.RESTMapper
+}
+
+var _ meta.ResettableRESTMapper = &ResettableRESTMapper{}
+
+// NewResettableRESTMapper returns a ResettableRESTMapper for the given config.
+// It matches the manager MapperProvider signature.
+func NewResettableRESTMapper(cfg *rest.Config, httpClient *http.Client) (meta.RESTMapper, error) {
+	newFn := func() (meta.RESTMapper, error) {
+		return apiutil.NewDynamicRESTMapper(cfg, httpClient)
+	}
+
+	m, err := newFn()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResettableRESTMapper{newFn: newFn, mapper: m}, nil
+}
+
+// Reset replaces the underlying mapper with a new one, the current one is kept
+// if the new one cannot be created.
+func (m *ResettableRESTMapper) Reset() {
+	nm, err := m.newFn()
+	if err != nil {
+		logf.Log.Error(err, "unable to reset the RESTMapper, keeping the current one")
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mapper = nm
+}
+
+func (m *ResettableRESTMapper) current() meta.RESTMapper {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.mapper
+}
+
+func (m *ResettableRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return m.current().KindFor(resource)
+}
+
+func (m *ResettableRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	return m.current().KindsFor(resource)
+}
+
+func (m *ResettableRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	return m.current().ResourceFor(input)
+}
+
+func (m *ResettableRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	return m.current().ResourcesFor(input)
+}
+
+func (m *ResettableRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return m.current().RESTMapping(gk, versions...)
+}
+
+func (m *ResettableRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	return m.current().RESTMappings(gk, versions...)
+}
+
+func (m *ResettableRESTMapper) ResourceSingularizer(resource string) (string, error) {
+	return m.current().ResourceSingularizer(resource)
+}
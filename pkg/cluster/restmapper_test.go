@@ -0,0 +1,121 @@
+package cluster_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+
+	. "github.com/onsi/gomega"
+)
+
+// discoveryServer serves the legacy discovery endpoints of a single API group,
+// whose versions and kinds can be changed as a CRD would.
+type discoveryServer struct {
+	mu    sync.Mutex
+	group string
+	kinds map[string][]string
+}
+
+func (s *discoveryServer) set(version string, kinds ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.kinds[version] = kinds
+}
+
+func (s *discoveryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body any
+
+	switch r.URL.Path {
+	case "/api":
+		body = metav1.APIVersions{TypeMeta: metav1.TypeMeta{Kind: "APIVersions"}}
+	case "/apis":
+		group := metav1.APIGroup{Name: s.group}
+		for v := range s.kinds {
+			gv := metav1.GroupVersionForDiscovery{GroupVersion: s.group + "/" + v, Version: v}
+			group.Versions = append(group.Versions, gv)
+			group.PreferredVersion = gv
+		}
+
+		body = metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList"}, Groups: []metav1.APIGroup{group}}
+	default:
+		gv, err := schema.ParseGroupVersion(r.URL.Path[len("/apis/"):])
+		kinds, ok := s.kinds[gv.Version]
+		if err != nil || gv.Group != s.group || !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		list := metav1.APIResourceList{TypeMeta: metav1.TypeMeta{Kind: "APIResourceList"}, GroupVersion: gv.String()}
+		for _, k := range kinds {
+			list.APIResources = append(list.APIResources, metav1.APIResource{
+				Name:       strings.ToLower(k) + "s",
+				Kind:       k,
+				Namespaced: true,
+				Verbs:      metav1.Verbs{"get", "list", "watch"},
+			})
+		}
+
+		body = list
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestResettableRESTMapper(t *testing.T) {
+	g := NewWithT(t)
+
+	ds := &discoveryServer{group: "example.com", kinds: map[string][]string{"v1": {"Foo"}}}
+	srv := httptest.NewServer(ds)
+	defer srv.Close()
+
+	cfg := &rest.Config{Host: srv.URL}
+	hc, err := rest.HTTPClientFor(cfg)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	dynamic, err := apiutil.NewDynamicRESTMapper(cfg, hc)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	resettable, err := cluster.NewResettableRESTMapper(cfg, hc)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(resettable).Should(BeAssignableToTypeOf(&cluster.ResettableRESTMapper{}))
+
+	foo := schema.GroupKind{Group: "example.com", Kind: "Foo"}
+	bar := schema.GroupKind{Group: "example.com", Kind: "Bar"}
+
+	for _, m := range []meta.RESTMapper{dynamic, resettable} {
+		_, err := m.RESTMapping(foo)
+		g.Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	// a CRD adds a new version to the known group
+	ds.set("v2", "Bar")
+
+	// the controller-runtime mapper keeps using the versions it cached
+	_, err = dynamic.RESTMapping(bar)
+	g.Expect(meta.IsNoMatchError(err)).Should(BeTrue())
+
+	_, err = resettable.RESTMapping(bar)
+	g.Expect(meta.IsNoMatchError(err)).Should(BeTrue())
+
+	resettable.(meta.ResettableRESTMapper).Reset()
+
+	mapping, err := resettable.RESTMapping(bar)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(mapping.Resource).Should(Equal(schema.GroupVersionResource{Group: "example.com", Version: "v2", Resource: "bars"}))
+}
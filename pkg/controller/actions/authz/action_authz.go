@@ -0,0 +1,282 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	// AuthRefsConfigMapName is the ConfigMap, in the applications namespace,
+	// the ServiceMesh service publishes the settings of the platform auth stack
+	// in. The auth stack is considered disabled while it does not exist.
+	AuthRefsConfigMapName = "auth-refs"
+
+	authAudienceKey   = "AUTH_AUDIENCE"
+	authNamespaceKey  = "AUTH_NAMESPACE"
+	authorinoLabelKey = "AUTHORINO_LABEL"
+)
+
+// Action generates the resources having the endpoints of a component Service
+// authorized by the platform auth stack: an Istio AuthorizationPolicy
+// delegating the authorization of the requests to the Service workloads to
+// Authorino, and the Authorino AuthConfig authenticating them with a Kubernetes
+// TokenReview and authorizing them with a SubjectAccessReview. It must be placed
+// between the render and the deploy actions.
+//
+// Nothing is generated while the auth stack is not enabled and each resource
+// is only generated once its CRD exists, the component should then own them
+// with deferred watches so that it is reconciled once the CRDs are installed:
+//
+//	OwnsGVK(gvk.AuthConfig, reconciler.Dynamic(reconciler.CrdExists(gvk.AuthConfig)))
+//	OwnsGVK(gvk.AuthorizationPolicy, reconciler.Dynamic(reconciler.CrdExists(gvk.AuthorizationPolicy)))
+type Action struct {
+	service  string
+	hosts    []string
+	resource string
+	verb     string
+	notPaths []string
+}
+
+type ActionOpts func(*Action)
+
+// WithHosts sets the hosts the AuthConfig applies to, defaults to the in-cluster
+// host names of the Service.
+func WithHosts(values ...string) ActionOpts {
+	return func(action *Action) {
+		action.hosts = append(action.hosts, values...)
+	}
+}
+
+// WithResourceAttributes sets the resource and the verb of the SubjectAccessReview
+// users are authorized with, defaults to being allowed to get the Service.
+func WithResourceAttributes(resource string, verb string) ActionOpts {
+	return func(action *Action) {
+		action.resource = resource
+		action.verb = verb
+	}
+}
+
+// WithNotPaths sets the paths of the endpoints, such as health checks, which
+// are not subject to authorization.
+func WithNotPaths(values ...string) ActionOpts {
+	return func(action *Action) {
+		action.notPaths = append(action.notPaths, values...)
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	if rr.DSCI == nil {
+		return errors.New("unable to generate authorization resources: DSCInitialization is not set")
+	}
+
+	refs, err := authRefs(ctx, rr.Client, rr.DSCI.Spec.ApplicationsNamespace)
+	if err != nil || refs == nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(rr.Resources, func(u unstructured.Unstructured) bool {
+		return u.GroupVersionKind() == gvk.Service && u.GetName() == a.service
+	})
+	if idx < 0 {
+		return fmt.Errorf("unable to generate authorization resources: service %s not found in the rendered resources", a.service)
+	}
+
+	svc := rr.Resources[idx].DeepCopy()
+
+	hasPolicies, err := cluster.HasAPI(rr.Client, gvk.AuthorizationPolicy)
+	if err != nil {
+		return fmt.Errorf("unable to detect whether authorization policies are available: %w", err)
+	}
+
+	if hasPolicies {
+		policy, err := a.authorizationPolicy(svc, refs)
+		if err != nil {
+			return err
+		}
+
+		if err := rr.AddResources(policy); err != nil {
+			return fmt.Errorf("unable to add authorization policy %s: %w", policy.GetName(), err)
+		}
+	}
+
+	hasAuthConfigs, err := cluster.HasAPI(rr.Client, gvk.AuthConfig)
+	if err != nil {
+		return fmt.Errorf("unable to detect whether auth configs are available: %w", err)
+	}
+
+	if hasAuthConfigs {
+		if err := rr.AddResources(a.authConfig(svc, refs)); err != nil {
+			return fmt.Errorf("unable to add auth config %s: %w", svc.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// authorizationPolicy returns the AuthorizationPolicy delegating to Authorino
+// the authorization of the requests to the pods selected by the given Service.
+func (a *Action) authorizationPolicy(svc *unstructured.Unstructured, refs map[string]string) (*unstructured.Unstructured, error) {
+	selector, _, err := unstructured.NestedStringMap(svc.Object, "spec", "selector")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read selector of service %s: %w", a.service, err)
+	}
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("unable to generate authorization policy: service %s has no selector", a.service)
+	}
+
+	provider := refs[authNamespaceKey]
+	if provider == "" {
+		return nil, fmt.Errorf("unable to generate authorization policy: %s not set in configmap %s", authNamespaceKey, AuthRefsConfigMapName)
+	}
+
+	matchLabels := make(map[string]any, len(selector))
+	for k, v := range selector {
+		matchLabels[k] = v
+	}
+
+	operation := map[string]any{"paths": []any{"/*"}}
+	if len(a.notPaths) > 0 {
+		operation = map[string]any{"notPaths": toSlice(a.notPaths)}
+	}
+
+	policy := resources.GvkToUnstructured(gvk.AuthorizationPolicy)
+	policy.SetName(svc.GetName())
+	policy.SetNamespace(svc.GetNamespace())
+	policy.Object["spec"] = map[string]any{
+		"action": "CUSTOM",
+		"provider": map[string]any{
+			"name": provider,
+		},
+		"rules": []any{
+			map[string]any{
+				"to": []any{
+					map[string]any{"operation": operation},
+				},
+			},
+		},
+		"selector": map[string]any{
+			"matchLabels": matchLabels,
+		},
+	}
+
+	return policy, nil
+}
+
+// authConfig returns the AuthConfig authenticating and authorizing the requests
+// to the given Service.
+func (a *Action) authConfig(svc *unstructured.Unstructured, refs map[string]string) *unstructured.Unstructured {
+	name := svc.GetName()
+	ns := svc.GetNamespace()
+
+	hosts := a.hosts
+	if len(hosts) == 0 {
+		hosts = []string{
+			name + "." + ns + ".svc",
+			name + "." + ns + ".svc.cluster.local",
+		}
+	}
+
+	tokenReview := map[string]any{}
+	if audiences := refs[authAudienceKey]; audiences != "" {
+		tokenReview["audiences"] = toSlice(strings.Split(audiences, ","))
+	}
+
+	resource := a.resource
+	verb := a.verb
+	if resource == "" {
+		resource = "services"
+		verb = "get"
+	}
+
+	ac := resources.GvkToUnstructured(gvk.AuthConfig)
+	ac.SetName(name)
+	ac.SetNamespace(ns)
+
+	// the label Authorino instances of the platform select AuthConfigs with
+	if k, v, ok := strings.Cut(refs[authorinoLabelKey], "="); ok {
+		ac.SetLabels(map[string]string{k: v})
+	}
+
+	ac.Object["spec"] = map[string]any{
+		"hosts": toSlice(hosts),
+		"authentication": map[string]any{
+			"kubernetes-user": map[string]any{
+				"kubernetesTokenReview": tokenReview,
+			},
+		},
+		"authorization": map[string]any{
+			"kubernetes-rbac": map[string]any{
+				"kubernetesSubjectAccessReview": map[string]any{
+					"user": map[string]any{
+						"selector": "auth.identity.user.username",
+					},
+					"resourceAttributes": map[string]any{
+						"namespace": map[string]any{"value": ns},
+						"resource":  map[string]any{"value": resource},
+						"name":      map[string]any{"value": name},
+						"verb":      map[string]any{"value": verb},
+					},
+				},
+			},
+		},
+	}
+
+	return ac
+}
+
+// authRefs returns the settings of the platform auth stack, or nil if it is not
+// enabled.
+func authRefs(ctx context.Context, cli client.Client, ns string) (map[string]string, error) {
+	cm := corev1.ConfigMap{}
+
+	err := cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: AuthRefsConfigMapName}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("unable to get configmap %s: %w", AuthRefsConfigMapName, err)
+	}
+
+	if cm.Data == nil {
+		return map[string]string{}, nil
+	}
+
+	return cm.Data, nil
+}
+
+func toSlice(values []string) []any {
+	res := make([]any, 0, len(values))
+	for _, v := range values {
+		res = append(res, v)
+	}
+
+	return res
+}
+
+// NewAction creates an action generating the authorization resources of the
+// given Service.
+func NewAction(service string, opts ...ActionOpts) actions.Fn {
+	action := Action{
+		service: service,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
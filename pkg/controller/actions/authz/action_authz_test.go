@@ -0,0 +1,157 @@
+package authz_test
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/authz"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	authzNamespace = "opendatahub"
+	authzService   = "ui"
+)
+
+func newScheme(t *testing.T, kinds ...schema.GroupVersionKind) *runtime.Scheme {
+	t.Helper()
+
+	g := NewWithT(t)
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	for _, k := range kinds {
+		s.AddKnownTypeWithName(k, &unstructured.Unstructured{})
+	}
+
+	return s
+}
+
+func newAuthRefs() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      authz.AuthRefsConfigMapName,
+			Namespace: authzNamespace,
+		},
+		Data: map[string]string{
+			"AUTH_AUDIENCE":   "https://kubernetes.default.svc,api",
+			"AUTH_NAMESPACE":  "opendatahub-auth-provider",
+			"AUTH_PROVIDER":   "authorino",
+			"AUTHORINO_LABEL": "security.opendatahub.io/authorization-group=default",
+		},
+	}
+}
+
+func newService() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      authzService,
+			Namespace: authzNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "ui"},
+		},
+	}
+}
+
+func TestAuthzGenerate(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newScheme(t, gvk.AuthorizationPolicy, gvk.AuthConfig)
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s), fakeclient.WithObjects(newAuthRefs())),
+		fakerequest.WithDSCI(&dsciv2.DSCInitialization{Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: authzNamespace}}),
+		fakerequest.WithResources(newService()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = authz.NewAction(authzService, authz.WithNotPaths("/healthz"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(3),
+		ContainElement(And(
+			jq.Match(`.kind == "AuthorizationPolicy"`),
+			jq.Match(`.metadata.namespace == "%s"`, authzNamespace),
+			jq.Match(`.spec.action == "CUSTOM"`),
+			jq.Match(`.spec.provider.name == "opendatahub-auth-provider"`),
+			jq.Match(`.spec.selector.matchLabels == {"app": "ui"}`),
+			jq.Match(`.spec.rules[0].to[0].operation.notPaths == ["/healthz"]`),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "AuthConfig"`),
+			jq.Match(`.metadata.labels."security.opendatahub.io/authorization-group" == "default"`),
+			jq.Match(`.spec.hosts == ["ui.opendatahub.svc", "ui.opendatahub.svc.cluster.local"]`),
+			jq.Match(`.spec.authentication."kubernetes-user".kubernetesTokenReview.audiences == ["https://kubernetes.default.svc", "api"]`),
+			jq.Match(`.spec.authorization."kubernetes-rbac".kubernetesSubjectAccessReview.resourceAttributes.verb.value == "get"`),
+		)),
+	))
+}
+
+func TestAuthzDisabled(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newScheme(t, gvk.AuthorizationPolicy, gvk.AuthConfig)
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s)),
+		fakerequest.WithDSCI(&dsciv2.DSCInitialization{Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: authzNamespace}}),
+		fakerequest.WithResources(newService()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = authz.NewAction(authzService)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.Resources).Should(HaveLen(1))
+}
+
+func TestAuthzDeferredUntilCRDs(t *testing.T) {
+	g := NewWithT(t)
+
+	// only the AuthConfig CRD is installed
+	s := newScheme(t, gvk.AuthConfig)
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s), fakeclient.WithObjects(newAuthRefs())),
+		fakerequest.WithDSCI(&dsciv2.DSCInitialization{Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: authzNamespace}}),
+		fakerequest.WithResources(newService()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = authz.NewAction(authzService, authz.WithHosts("ui.example.com"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(2),
+		ContainElement(And(
+			jq.Match(`.kind == "AuthConfig"`),
+			jq.Match(`.spec.hosts == ["ui.example.com"]`),
+		)),
+	))
+}
+
+func TestAuthzMissingService(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithObjects(newAuthRefs())),
+		fakerequest.WithDSCI(&dsciv2.DSCInitialization{Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: authzNamespace}}),
+		fakerequest.WithResources(newService()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = authz.NewAction("missing")(t.Context(), rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("service missing not found")))
+}
@@ -11,7 +11,15 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 )
 
-type CachingKeyFn func(rr *types.ReconciliationRequest) ([]byte, error)
+type CachingKeyFn func(ctx context.Context, rr *types.ReconciliationRequest) ([]byte, error)
+
+// KeyFn adapts a caching key function that does not need a context, such as
+// types.Hash, to a CachingKeyFn.
+func KeyFn(fn func(rr *types.ReconciliationRequest) ([]byte, error)) CachingKeyFn {
+	return func(_ context.Context, rr *types.ReconciliationRequest) ([]byte, error) {
+		return fn(rr)
+	}
+}
 
 type Cacher[T any] struct {
 	cachingKeyFn    CachingKeyFn
@@ -67,7 +75,7 @@ func (s *Cacher[T]) Render(ctx context.Context, rr *types.ReconciliationRequest,
 		return s.reRender(ctx, nil, rr, r)
 	}
 
-	cachingKey, err = s.cachingKeyFn(rr)
+	cachingKey, err = s.cachingKeyFn(ctx, rr)
 	if err != nil {
 		return Zero[T](), false, fmt.Errorf("unable to calculate caching key: %w", err)
 	}
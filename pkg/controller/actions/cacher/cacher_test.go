@@ -49,7 +49,7 @@ func newTestCacher() *testCacher {
 	return c
 }
 
-func (s *testCacher) hash(rr *types.ReconciliationRequest) ([]byte, error) {
+func (s *testCacher) hash(_ context.Context, rr *types.ReconciliationRequest) ([]byte, error) {
 	args := s.Called(rr)
 	return args.Get(0).([]byte), args.Error(1) //nolint:errcheck,forcetypeassert
 }
@@ -0,0 +1,262 @@
+package confighash
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// Action sets the checksum/config annotation on the pod template of the rendered
+// workloads to a hash of the rendered ConfigMaps and Secrets they consume, through
+// volumes, envFrom or env, so that a change of their content rolls the pods.
+// ConfigMaps and Secrets which are not part of the rendered resources are only
+// accounted for when a Tracker is set. It must be placed between the render and
+// the deploy actions.
+type Action struct {
+	override bool
+	tracker  *Tracker
+}
+
+type ActionOpts func(*Action)
+
+// WithOverride makes the action replace the checksum annotation also when it is
+// already set by the manifests.
+func WithOverride() ActionOpts {
+	return func(action *Action) {
+		action.override = true
+	}
+}
+
+// WithTracker makes the action also account for the ConfigMaps and Secrets which
+// are consumed by the rendered workloads but not rendered themselves, i.e. those
+// holding credentials, reading them from the cluster. They are recorded in the
+// given tracker, whose Requests mapper is meant to be used to watch them so that
+// the instance is reconciled, and the pods rolled, when they change.
+func WithTracker(tracker *Tracker) ActionOpts {
+	return func(action *Action) {
+		action.tracker = tracker
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	configs := map[string]any{}
+	external := sets.New[string]()
+
+	for i := range rr.Resources {
+		obj := &rr.Resources[i]
+
+		switch obj.GroupVersionKind() {
+		case corev1.SchemeGroupVersion.WithKind("ConfigMap"):
+			configs[key("ConfigMap", obj.GetNamespace(), obj.GetName())] = []any{obj.Object["data"], obj.Object["binaryData"]}
+		case corev1.SchemeGroupVersion.WithKind("Secret"):
+			configs[key("Secret", obj.GetNamespace(), obj.GetName())] = []any{obj.Object["data"], obj.Object["stringData"]}
+		}
+	}
+
+	if len(configs) == 0 && a.tracker == nil {
+		return nil
+	}
+
+	lookup := func(ref string) (any, error) {
+		if config, ok := configs[ref]; ok || a.tracker == nil {
+			return config, nil
+		}
+
+		external.Insert(ref)
+
+		config, err := fetch(ctx, rr, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		// not found ones are recorded too, not to be looked up again
+		configs[ref] = config
+
+		return config, nil
+	}
+
+	for i := range rr.Resources {
+		if err := a.annotate(&rr.Resources[i], lookup); err != nil {
+			return fmt.Errorf("unable to compute config checksum of %s: %w",
+				resources.FormatObjectReference(&rr.Resources[i]), err)
+		}
+	}
+
+	if a.tracker != nil && rr.Instance != nil {
+		a.tracker.track(client.ObjectKeyFromObject(rr.Instance), external)
+	}
+
+	return nil
+}
+
+// fetch reads the ConfigMap or Secret with the given key from the cluster, and
+// returns nil if it does not exist.
+func fetch(ctx context.Context, rr *types.ReconciliationRequest, ref string) (any, error) {
+	kind, ns, name := parseKey(ref)
+	if ns == "" {
+		return nil, nil
+	}
+
+	switch kind {
+	case "ConfigMap":
+		cm := corev1.ConfigMap{}
+		if err := rr.Client.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &cm); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+
+		return []any{cm.Data, cm.BinaryData}, nil
+	default:
+		secret := corev1.Secret{}
+		if err := rr.Client.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &secret); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+
+		return []any{secret.Data}, nil
+	}
+}
+
+func (a *Action) annotate(obj *unstructured.Unstructured, lookup func(string) (any, error)) error {
+	path, ok := resources.PodSpecPath(obj.GroupVersionKind().GroupKind())
+	// the annotations of bare pods cannot trigger a rollout
+	if !ok || len(path) < 2 {
+		return nil
+	}
+
+	annotationsPath := append(slices.Clone(path[:len(path)-1]), "metadata", "annotations")
+
+	values, _, err := unstructured.NestedStringMap(obj.Object, annotationsPath...)
+	if err != nil {
+		return err
+	}
+	if _, found := values[annotations.ConfigChecksum]; found && !a.override {
+		return nil
+	}
+
+	content, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil || !found {
+		return err
+	}
+
+	spec := corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &spec); err != nil {
+		return err
+	}
+
+	consumed := map[string]any{}
+	for _, ref := range references(obj.GetNamespace(), &spec) {
+		config, err := lookup(ref)
+		if err != nil {
+			return err
+		}
+		if config != nil {
+			consumed[ref] = config
+		}
+	}
+
+	if len(consumed) == 0 {
+		return nil
+	}
+
+	// maps are marshaled with sorted keys, so the hash is stable
+	data, err := json.Marshal(consumed)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	values[annotations.ConfigChecksum] = hex.EncodeToString(sum[:])
+
+	return unstructured.SetNestedStringMap(obj.Object, values, annotationsPath...)
+}
+
+// references returns the keys of the ConfigMaps and Secrets referenced by the
+// given pod spec.
+func references(ns string, spec *corev1.PodSpec) []string {
+	refs := make([]string, 0)
+
+	for _, v := range spec.Volumes {
+		if v.ConfigMap != nil {
+			refs = append(refs, key("ConfigMap", ns, v.ConfigMap.Name))
+		}
+		if v.Secret != nil {
+			refs = append(refs, key("Secret", ns, v.Secret.SecretName))
+		}
+		if v.Projected == nil {
+			continue
+		}
+
+		for _, s := range v.Projected.Sources {
+			if s.ConfigMap != nil {
+				refs = append(refs, key("ConfigMap", ns, s.ConfigMap.Name))
+			}
+			if s.Secret != nil {
+				refs = append(refs, key("Secret", ns, s.Secret.Name))
+			}
+		}
+	}
+
+	containers := slices.Concat(spec.InitContainers, spec.Containers)
+	for _, c := range containers {
+		for _, e := range c.EnvFrom {
+			if e.ConfigMapRef != nil {
+				refs = append(refs, key("ConfigMap", ns, e.ConfigMapRef.Name))
+			}
+			if e.SecretRef != nil {
+				refs = append(refs, key("Secret", ns, e.SecretRef.Name))
+			}
+		}
+
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if e.ValueFrom.ConfigMapKeyRef != nil {
+				refs = append(refs, key("ConfigMap", ns, e.ValueFrom.ConfigMapKeyRef.Name))
+			}
+			if e.ValueFrom.SecretKeyRef != nil {
+				refs = append(refs, key("Secret", ns, e.ValueFrom.SecretKeyRef.Name))
+			}
+		}
+	}
+
+	return refs
+}
+
+func key(kind string, ns string, name string) string {
+	return kind + "/" + ns + "/" + name
+}
+
+func parseKey(key string) (string, string, string) {
+	parts := strings.SplitN(key, "/", 3)
+	return parts[0], parts[1], parts[2]
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
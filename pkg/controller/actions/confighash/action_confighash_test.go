@@ -0,0 +1,188 @@
+package confighash_test
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/confighash"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func newDeployment(name string, spec corev1.PodSpec, ann map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: ann,
+				},
+				Spec: spec,
+			},
+		},
+	}
+}
+
+func render(t *testing.T, value string, ann map[string]string, opts ...confighash.ActionOpts) []unstructured.Unstructured {
+	t.Helper()
+
+	g := NewWithT(t)
+
+	cm := corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "ns"},
+		Data:       map[string]string{"key": value},
+	}
+
+	secret := corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "ns"},
+		StringData: map[string]string{"password": "secret"},
+	}
+
+	consumer := newDeployment("consumer", corev1.PodSpec{
+		Volumes: []corev1.Volume{{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+				},
+			},
+		}},
+		Containers: []corev1.Container{{
+			Name: "app",
+			Env: []corev1.EnvVar{{
+				Name: "PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+						Key:                  "password",
+					},
+				},
+			}},
+		}},
+	}, ann)
+
+	other := newDeployment("other", corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "app"}},
+	}, nil)
+
+	rr := types.ReconciliationRequest{}
+	for _, obj := range []any{&cm, &secret, consumer, other} {
+		u, err := resources.ToUnstructured(obj)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rr.Resources = append(rr.Resources, *u)
+	}
+
+	err := confighash.NewAction(opts...)(t.Context(), &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return rr.Resources
+}
+
+func checksum(obj unstructured.Unstructured) string {
+	values, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "annotations")
+	return values[annotations.ConfigChecksum]
+}
+
+func TestConfigHash(t *testing.T) {
+	g := NewWithT(t)
+
+	res := render(t, "v1", nil)
+	g.Expect(checksum(res[2])).ShouldNot(BeEmpty())
+	g.Expect(checksum(res[3])).Should(BeEmpty())
+
+	g.Expect(checksum(render(t, "v1", nil)[2])).Should(Equal(checksum(res[2])))
+	g.Expect(checksum(render(t, "v2", nil)[2])).ShouldNot(Equal(checksum(res[2])))
+}
+
+func TestConfigHashExisting(t *testing.T) {
+	g := NewWithT(t)
+
+	ann := map[string]string{annotations.ConfigChecksum: "manual", "foo": "bar"}
+
+	res := render(t, "v1", ann)
+	g.Expect(checksum(res[2])).Should(Equal("manual"))
+
+	res = render(t, "v1", ann, confighash.WithOverride())
+	g.Expect(checksum(res[2])).ShouldNot(Equal("manual"))
+	g.Expect(res[2].Object).Should(HaveKeyWithValue("spec",
+		HaveKeyWithValue("template",
+			HaveKeyWithValue("metadata",
+				HaveKeyWithValue("annotations", HaveKeyWithValue("foo", "bar"))))))
+}
+
+func TestConfigHashTracker(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("v1")},
+	}
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(&secret))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	dp, err := resources.ToUnstructured(newDeployment("consumer", corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name: "app",
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "external"},
+				},
+			}},
+		}},
+	}, nil))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	tracker := confighash.NewTracker()
+	action := confighash.NewAction(confighash.WithTracker(tracker))
+
+	rr := types.ReconciliationRequest{
+		Client: cl,
+		Instance: &componentApi.Dashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+		},
+		Resources: []unstructured.Unstructured{*dp.DeepCopy()},
+	}
+
+	g.Expect(action(ctx, &rr)).Should(Succeed())
+	first := checksum(rr.Resources[0])
+	g.Expect(first).ShouldNot(BeEmpty())
+
+	g.Expect(tracker.Requests(ctx, &secret)).Should(ConsistOf(reconcile.Request{
+		NamespacedName: client.ObjectKey{Name: componentApi.DashboardInstanceName},
+	}))
+	g.Expect(tracker.Requests(ctx, &corev1.ConfigMap{ObjectMeta: secret.ObjectMeta})).Should(BeEmpty())
+
+	// rotating the secret changes the checksum
+	secret.Data["password"] = []byte("v2")
+	g.Expect(cl.Update(ctx, &secret)).Should(Succeed())
+
+	rr.Resources = []unstructured.Unstructured{*dp.DeepCopy()}
+	g.Expect(action(ctx, &rr)).Should(Succeed())
+	g.Expect(checksum(rr.Resources[0])).ShouldNot(Equal(first))
+
+	tracker.Forget(client.ObjectKeyFromObject(rr.Instance))
+	g.Expect(tracker.Requests(ctx, &secret)).Should(BeEmpty())
+}
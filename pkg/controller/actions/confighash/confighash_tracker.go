@@ -0,0 +1,78 @@
+package confighash
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Tracker records the ConfigMaps and Secrets consumed, but not rendered, by the
+// workloads of each instance of a controller, so that the instances can be
+// reconciled when they change, e.g. because credentials have been rotated.
+type Tracker struct {
+	mu   sync.RWMutex
+	refs map[client.ObjectKey]sets.Set[string]
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		refs: map[client.ObjectKey]sets.Set[string]{},
+	}
+}
+
+// track replaces the references recorded for the given instance.
+func (t *Tracker) track(instance client.ObjectKey, refs sets.Set[string]) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if refs.Len() == 0 {
+		delete(t.refs, instance)
+		return
+	}
+
+	t.refs[instance] = refs
+}
+
+// Forget drops the references recorded for the given instance, i.e. once it is
+// deleted.
+func (t *Tracker) Forget(instance client.ObjectKey) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.refs, instance)
+}
+
+// Requests maps a ConfigMap or a Secret to the requests of the instances whose
+// workloads consume it. It is meant to be used with handlers.Fn to watch them:
+//
+//	Watches(&corev1.Secret{}, reconciler.WithEventHandler(handlers.Fn(tracker.Requests)))
+func (t *Tracker) Requests(_ context.Context, obj client.Object) []reconcile.Request {
+	var kind string
+
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		kind = "ConfigMap"
+	case *corev1.Secret:
+		kind = "Secret"
+	default:
+		kind = obj.GetObjectKind().GroupVersionKind().Kind
+	}
+
+	ref := key(kind, obj.GetNamespace(), obj.GetName())
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	requests := make([]reconcile.Request, 0)
+	for instance, refs := range t.refs {
+		if refs.Has(ref) {
+			requests = append(requests, reconcile.Request{NamespacedName: instance})
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,152 @@
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// DefaultNamespace is the namespace the OpenShift console loads its dashboards
+// from.
+const DefaultNamespace = "openshift-config-managed"
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Action packages the Grafana dashboards of a component, JSON files matching a
+// pattern in a file system, in ConfigMaps labeled for the OpenShift console and
+// for the Grafana dashboards sidecar. It must be placed between the render and
+// the deploy actions, so that the ConfigMaps are deployed and garbage collected
+// along with the other resources of the component.
+//
+// Each dashboard is stored in its own ConfigMap named after the component and
+// the file, i.e. dashboard-overview for the overview.json file of the Dashboard
+// component.
+type Action struct {
+	fsys      fs.FS
+	pattern   string
+	namespace string
+	prefix    string
+	labels    map[string]string
+}
+
+type ActionOpts func(*Action)
+
+// WithNamespace sets the namespace of the ConfigMaps, defaults to
+// DefaultNamespace.
+func WithNamespace(value string) ActionOpts {
+	return func(action *Action) {
+		action.namespace = value
+	}
+}
+
+// WithPrefix sets the prefix of the ConfigMaps names, defaults to the kind of
+// the component.
+func WithPrefix(value string) ActionOpts {
+	return func(action *Action) {
+		action.prefix = value
+	}
+}
+
+// WithLabel adds a label to the ConfigMaps, i.e. to be selected by a specific
+// Grafana instance.
+func WithLabel(name string, value string) ActionOpts {
+	return func(action *Action) {
+		action.labels[name] = value
+	}
+}
+
+func (a *Action) run(_ context.Context, rr *types.ReconciliationRequest) error {
+	matches, err := fs.Glob(a.fsys, a.pattern)
+	if err != nil {
+		return fmt.Errorf("unable to find dashboards matching %s: %w", a.pattern, err)
+	}
+
+	if len(matches) == 0 {
+		return nil
+	}
+
+	prefix := a.prefix
+	if prefix == "" {
+		kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
+		if err != nil {
+			return fmt.Errorf("unable to determine the dashboards prefix: %w", err)
+		}
+
+		prefix = kind
+	}
+
+	for _, name := range matches {
+		data, err := fs.ReadFile(a.fsys, name)
+		if err != nil {
+			return fmt.Errorf("unable to read dashboard %s: %w", name, err)
+		}
+
+		if !json.Valid(data) {
+			return fmt.Errorf("dashboard %s is not a valid JSON document", name)
+		}
+
+		if err := rr.AddResources(a.configMap(prefix, name, data)); err != nil {
+			return fmt.Errorf("unable to add dashboard %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Action) configMap(prefix string, name string, data []byte) *corev1.ConfigMap {
+	key := path.Base(name)
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName(prefix, strings.TrimSuffix(key, path.Ext(key))),
+			Namespace: a.namespace,
+			Labels:    maps.Clone(a.labels),
+		},
+		Data: map[string]string{
+			key: string(data),
+		},
+	}
+}
+
+// configMapName returns a valid ConfigMap name made of the given parts.
+func configMapName(parts ...string) string {
+	name := strings.ToLower(strings.Join(parts, "-"))
+	name = invalidNameChars.ReplaceAllString(name, "-")
+
+	return strings.Trim(name, "-")
+}
+
+func NewAction(fsys fs.FS, pattern string, opts ...ActionOpts) actions.Fn {
+	action := Action{
+		fsys:      fsys,
+		pattern:   pattern,
+		namespace: DefaultNamespace,
+		labels: map[string]string{
+			labels.ConsoleDashboard: labels.True,
+			labels.GrafanaDashboard: "1",
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
@@ -0,0 +1,81 @@
+package dashboards_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/dashboards"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDashboards(t *testing.T) {
+	g := NewWithT(t)
+
+	fsys := fstest.MapFS{
+		"resources/dashboards/Overview_Metrics.json": {Data: []byte(`{"title": "Overview"}`)},
+		"resources/dashboards/README.md":             {Data: []byte(`# dashboards`)},
+	}
+
+	rr, err := fakerequest.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = dashboards.NewAction(fsys, "resources/dashboards/*.json", dashboards.WithLabel("team", "ml"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		ContainElement(And(
+			jq.Match(`.kind == "ConfigMap"`),
+			jq.Match(`.metadata.name == "dashboard-overview-metrics"`),
+			jq.Match(`.metadata.namespace == "%s"`, dashboards.DefaultNamespace),
+			jq.Match(`.metadata.labels."%s" == "true"`, labels.ConsoleDashboard),
+			jq.Match(`.metadata.labels."%s" == "1"`, labels.GrafanaDashboard),
+			jq.Match(`.metadata.labels.team == "ml"`),
+			jq.Match(`.data."Overview_Metrics.json" == "{\"title\": \"Overview\"}"`),
+		)),
+	))
+}
+
+func TestDashboardsWithPrefixAndNamespace(t *testing.T) {
+	g := NewWithT(t)
+
+	fsys := fstest.MapFS{
+		"overview.json": {Data: []byte(`{}`)},
+	}
+
+	rr, err := fakerequest.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = dashboards.NewAction(fsys, "*.json",
+		dashboards.WithPrefix("odh"),
+		dashboards.WithNamespace("grafana"),
+	)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		ContainElement(And(
+			jq.Match(`.metadata.name == "odh-overview"`),
+			jq.Match(`.metadata.namespace == "grafana"`),
+		)),
+	))
+}
+
+func TestDashboardsInvalidJSON(t *testing.T) {
+	g := NewWithT(t)
+
+	fsys := fstest.MapFS{
+		"broken.json": {Data: []byte(`{"title": `)},
+	}
+
+	rr, err := fakerequest.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = dashboards.NewAction(fsys, "*.json")(t.Context(), rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("broken.json is not a valid JSON document")))
+	g.Expect(rr.Resources).Should(BeEmpty())
+}
@@ -0,0 +1,154 @@
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	DefaultRequeueAfter = 30 * time.Second
+)
+
+// Dependency identifies a resource that must be Ready before the resources of
+// a component are deployed, i.e. another component or a service such as a
+// shared database.
+type Dependency struct {
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+}
+
+func (d Dependency) String() string {
+	if d.Namespace == "" {
+		return fmt.Sprintf("%s %s", d.GVK.Kind, d.Name)
+	}
+
+	return fmt.Sprintf("%s %s/%s", d.GVK.Kind, d.Namespace, d.Name)
+}
+
+// Action checks that the declared dependencies exist and report a Ready
+// condition set to True, and reflects the outcome in the DependenciesReady
+// condition. While any of them is not ready, the condition is set to False with
+// the Blocked reason, the remaining actions are skipped and the request is
+// requeued. It should be placed before the render and deploy actions, and the
+// DependenciesReady condition registered with the reconciler WithConditions.
+type Action struct {
+	dependencies []Dependency
+	requeueAfter time.Duration
+}
+
+type ActionOpts func(*Action)
+
+// WithDependency declares a dependency on the given resource, the namespace
+// is ignored for cluster scoped resources.
+func WithDependency(gvk schema.GroupVersionKind, namespace string, name string) ActionOpts {
+	return func(action *Action) {
+		action.dependencies = append(action.dependencies, Dependency{GVK: gvk, Namespace: namespace, Name: name})
+	}
+}
+
+// WithRequeueAfter sets the delay after which the request is requeued while
+// waiting for dependencies.
+func WithRequeueAfter(value time.Duration) ActionOpts {
+	return func(action *Action) {
+		action.requeueAfter = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	blocked := make([]string, 0)
+
+	for _, d := range a.dependencies {
+		reason, err := a.check(ctx, rr.Client, d)
+		if err != nil {
+			return fmt.Errorf("unable to check dependency %s: %w", d, err)
+		}
+
+		if reason != "" {
+			blocked = append(blocked, d.String()+" "+reason)
+		}
+	}
+
+	if len(blocked) > 0 {
+		msg := "waiting for dependencies: " + strings.Join(blocked, ", ")
+
+		rr.Conditions.MarkFalse(
+			status.ConditionDependenciesReady,
+			conditions.WithObservedGeneration(rr.Instance.GetGeneration()),
+			conditions.WithReason(status.ConditionDependenciesBlockedReason),
+			conditions.WithMessage("%s", msg),
+		)
+
+		return odherrors.NewWaitError(a.requeueAfter, "%s", msg)
+	}
+
+	rr.Conditions.MarkTrue(
+		status.ConditionDependenciesReady,
+		conditions.WithObservedGeneration(rr.Instance.GetGeneration()),
+	)
+
+	return nil
+}
+
+// check returns why the dependency is not ready, or an empty string if it is.
+func (a *Action) check(ctx context.Context, cli client.Client, d Dependency) (string, error) {
+	obj := resources.GvkToUnstructured(d.GVK)
+
+	err := cli.Get(ctx, client.ObjectKey{Namespace: d.Namespace, Name: d.Name}, obj)
+	switch {
+	case k8serr.IsNotFound(err):
+		return "not found", nil
+	case err != nil:
+		return "", err
+	}
+
+	conds, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range conds {
+		cm, ok := c.(map[string]any)
+		if !ok || cm["type"] != status.ConditionTypeReady {
+			continue
+		}
+
+		if cm["status"] == "True" {
+			return "", nil
+		}
+
+		if msg, ok := cm["message"].(string); ok && msg != "" {
+			return "not ready: " + msg, nil
+		}
+
+		return "not ready", nil
+	}
+
+	return "not ready", nil
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		requeueAfter: DefaultRequeueAfter,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
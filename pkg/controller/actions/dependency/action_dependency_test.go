@@ -0,0 +1,116 @@
+package dependency_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/dependency"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers"
+
+	. "github.com/onsi/gomega"
+)
+
+func newDependency(ready metav1.ConditionStatus) *componentApi.ModelRegistry {
+	return &componentApi.ModelRegistry{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: componentApi.GroupVersion.String(),
+			Kind:       componentApi.ModelRegistryKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: componentApi.ModelRegistryInstanceName,
+		},
+		Status: componentApi.ModelRegistryStatus{
+			Status: common.Status{
+				Conditions: []common.Condition{{
+					Type:    status.ConditionTypeReady,
+					Status:  ready,
+					Message: "deployments not ready",
+				}},
+			},
+		},
+	}
+}
+
+func TestDependencyAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		blocked bool
+		message string
+	}{
+		{
+			name:    "ready",
+			objs:    []client.Object{newDependency(metav1.ConditionTrue)},
+			blocked: false,
+		},
+		{
+			name:    "not ready",
+			objs:    []client.Object{newDependency(metav1.ConditionFalse)},
+			blocked: true,
+			message: "waiting for dependencies: ModelRegistry default-modelregistry not ready: deployments not ready",
+		},
+		{
+			name:    "not found",
+			blocked: true,
+			message: "waiting for dependencies: ModelRegistry default-modelregistry not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cl, err := fakeclient.New(fakeclient.WithObjects(tt.objs...))
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr := types.ReconciliationRequest{
+				Client:   cl,
+				Instance: &componentApi.Dashboard{},
+			}
+			rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady, status.ConditionDependenciesReady)
+
+			err = dependency.NewAction(
+				dependency.WithDependency(gvk.ModelRegistry, "", componentApi.ModelRegistryInstanceName),
+				dependency.WithRequeueAfter(time.Second),
+			)(t.Context(), &rr)
+
+			if !tt.blocked {
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(rr.Instance).Should(WithTransform(
+					matchers.ExtractStatusCondition(status.ConditionDependenciesReady),
+					gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+						"Status": Equal(metav1.ConditionTrue),
+					}),
+				))
+
+				return
+			}
+
+			we := odherrors.WaitError{}
+			g.Expect(errors.As(err, &we)).Should(BeTrue())
+			g.Expect(we.RequeueAfter()).Should(Equal(time.Second))
+
+			g.Expect(rr.Instance).Should(WithTransform(
+				matchers.ExtractStatusCondition(status.ConditionDependenciesReady),
+				gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal(status.ConditionDependenciesBlockedReason),
+					"Message": Equal(tt.message),
+				}),
+			))
+		})
+	}
+}
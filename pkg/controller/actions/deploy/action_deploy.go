@@ -4,18 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
 	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
@@ -29,6 +35,18 @@ const (
 	ModeSSA   Mode = "ssa"
 )
 
+const (
+	// EventReasonDeployError is the reason of the warning event recorded on the
+	// reconciled instance when one of its resources fails to deploy.
+	EventReasonDeployError = "DeployError"
+	// EventReasonDriftCorrected is the reason of the event recorded when a resource
+	// modified outside the operator is brought back to its desired state.
+	EventReasonDriftCorrected = "DriftCorrected"
+	// EventReasonUpgraded is the reason of the event recorded when the resources
+	// deployed by a previous platform version are upgraded.
+	EventReasonUpgraded = "Upgraded"
+)
+
 // Action deploys the resources that are included in the ReconciliationRequest using
 // the same create or patch machinery implemented as part of deploy.DeployManifestsFromPath.
 type Action struct {
@@ -37,6 +55,22 @@ type Action struct {
 	labels      map[string]string
 	annotations map[string]string
 	cache       *Cache
+	backoff     *wait.Backoff
+	tracking    bool
+	trackingApp string
+	validate    bool
+	nonCritical map[schema.GroupVersionKind]struct{}
+	limiter     flowcontrol.RateLimiter
+
+	audit          bool
+	auditNamespace string
+
+	force            bool
+	fieldOwnerFormat string
+	instanceUIDLabel bool
+
+	scopeMode       ScopeMode
+	scopeNamespaces []string
 }
 
 type ActionOpts func(*Action)
@@ -47,6 +81,28 @@ func WithFieldOwner(value string) ActionOpts {
 	}
 }
 
+// WithFieldOwnerFormat sets the format of the field manager resources are
+// applied with, unless set with WithFieldOwner. Resources previously applied
+// with the LegacyFieldOwnerFormat manager are migrated: once applied with the
+// new manager, the entries of the legacy one are dropped from their managed
+// fields. It can be set to LegacyFieldOwnerFormat to keep the legacy manager.
+func WithFieldOwnerFormat(format string) ActionOpts {
+	return func(action *Action) {
+		action.fieldOwnerFormat = format
+	}
+}
+
+// WithForceOwnership sets whether resources are applied taking the ownership of
+// the fields managed by others, the default. When disabled, the resources whose
+// fields are managed by others, i.e. a GitOps tool or a human, fail to deploy,
+// and the conflicting fields and managers are reported in the FieldsOwned
+// condition of the instance.
+func WithForceOwnership(enabled bool) ActionOpts {
+	return func(action *Action) {
+		action.force = enabled
+	}
+}
+
 func WithMode(value Mode) ActionOpts {
 	return func(action *Action) {
 		action.deployMode = value
@@ -103,7 +159,88 @@ func WithCache(opts ...CacheOpt) ActionOpts {
 	}
 }
 
+// WithTracking stamps the app.kubernetes.io/instance label and the ArgoCD
+// tracking-id annotation on the deployed resources, so that clusters where
+// ArgoCD is also used can attribute the resources to the operator rather than
+// pruning them. The application name defaults to the name of the instance.
+func WithTracking(appName string) ActionOpts {
+	return func(action *Action) {
+		action.tracking = true
+		action.trackingApp = appName
+	}
+}
+
+// WithInstanceUIDLabel stamps the UID of the instance on the deployed resources
+// as the platform.opendatahub.io/instance.uid label. Owner references can't
+// always be set (i.e. a namespaced owner can't own a cluster scoped resource),
+// the label lets the orphans action find the resources left behind once the
+// instance is gone.
+func WithInstanceUIDLabel() ActionOpts {
+	return func(action *Action) {
+		action.instanceUIDLabel = true
+	}
+}
+
+// WithApplyBackoff makes the action retry the deployment of resources failing
+// with a transient error (see IsTransientError) according to the given backoff.
+// Once the backoff is exhausted, a RetriesExhaustedError is returned.
+func WithApplyBackoff(value wait.Backoff) ActionOpts {
+	return func(action *Action) {
+		action.backoff = &value
+	}
+}
+
+// WithValidation makes the action validate all the resources against the cluster
+// schemas, by mean of a server side dry-run apply with strict field validation,
+// before any of them is deployed. Rejected resources are reported together as a
+// ValidationError and nothing is deployed.
+func WithValidation() ActionOpts {
+	return func(action *Action) {
+		action.validate = true
+	}
+}
+
+// WithNonCritical marks the resources of the given kinds, i.e. ServiceMonitors
+// or PodDisruptionBudgets, as not critical for the component. Failing to deploy
+// them does not fail the reconciliation, they are flagged as degraded in the
+// deployed resources of the instance status instead.
+func WithNonCritical(kinds ...schema.GroupVersionKind) ActionOpts {
+	return func(action *Action) {
+		for _, k := range kinds {
+			action.nonCritical[k] = struct{}{}
+		}
+	}
+}
+
+// WithRateLimit limits the rate at which the resources are sent to the API server
+// to qps per second, with bursts of at most burst resources, so that deploying a
+// large set of resources doesn't starve the other controllers sharing the same
+// client. Resources that are skipped because they are up to date are not
+// accounted for.
+func WithRateLimit(qps float32, burst int) ActionOpts {
+	return func(action *Action) {
+		action.limiter = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+	}
+}
+
+// WithAuditTrail makes the action keep track of each revision of the resources
+// it applies: what triggered it, the hash of the rendered inputs and the
+// resources created or updated. Revisions are recorded as events and appended
+// to a ConfigMap named after the component in the given namespace, or in the
+// applications namespace if empty, that keeps the last DefaultAuditEntries.
+func WithAuditTrail(namespace string) ActionOpts {
+	return func(action *Action) {
+		action.audit = true
+		action.auditNamespace = namespace
+	}
+}
+
 func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) error {
+	// the resources are only rendered, leave them to the caller
+	if odhTypes.IsRenderOnly(ctx) {
+		return odherrors.NewStopError("render only, %d resources not deployed", len(rr.Resources))
+	}
+
 	// cleanup old entries if needed
 	if a.cache != nil {
 		a.cache.Sync()
@@ -114,11 +251,39 @@ func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) er
 		return err
 	}
 
+	if err := a.enforceScope(ctx, rr); err != nil {
+		return err
+	}
+
 	controllerName := strings.ToLower(kind)
 	igvk := rr.Instance.GetObjectKind().GroupVersionKind()
+	upgradedFrom := ""
+
+	if a.validate {
+		if err := a.validateResources(ctx, rr, a.fieldOwnerFor(kind)); err != nil {
+			return err
+		}
+	}
+
+	// all the resources are deployed even if some fail, so that every failure
+	// is reported at once
+	failures := make([]error, 0)
+	degraded := make(map[string]string)
+	created := make([]string, 0)
+	updated := make([]string, 0)
+	conflicts := make([]FieldConflict, 0)
+	pendingJobs := make([]string, 0)
+	skipped := make(map[string]struct{})
 
 	for i := range rr.Resources {
 		res := rr.Resources[i]
+
+		// hooks are run by the hooks action
+		if resources.GetAnnotation(&res, annotations.Hook) != "" {
+			skipped[resources.FormatObjectReference(&res)] = struct{}{}
+			continue
+		}
+
 		current := resources.GvkToUnstructured(res.GroupVersionKind())
 
 		lookupErr := rr.Client.Get(ctx, client.ObjectKeyFromObject(&res), current)
@@ -128,48 +293,188 @@ func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) er
 			// that there's no previous known state of the resource
 			current = nil
 		case lookupErr != nil:
-			return fmt.Errorf("failed to lookup object %s/%s: %w", res.GetNamespace(), res.GetName(), lookupErr)
+			failures = append(failures, fmt.Errorf("%s: failed to lookup object: %w", resources.FormatObjectReference(&res), lookupErr))
+			continue
 		default:
 			// Remove the previous owner reference if set, This is required during the
 			// transition from the old to the new operator.
 			if err := resources.RemoveOwnerReferences(ctx, rr.Client, current, ownedTypeIsNot(&igvk)); err != nil {
-				return err
+				failures = append(failures, fmt.Errorf("%s: %w", resources.FormatObjectReference(&res), err))
+				continue
 			}
 
 			// the user has explicitly marked the current object as not owned by the operator
 			if resources.GetAnnotation(current, annotations.ManagedByODHOperator) == "false" {
 				// de-own the object so the resource is not removed upon cleanup
 				if err := resources.RemoveOwnerReferences(ctx, rr.Client, current, ownedTypeIs(&igvk)); err != nil {
-					return err
+					failures = append(failures, fmt.Errorf("%s: %w", resources.FormatObjectReference(&res), err))
 				}
 
 				//  skip any further processing
+				skipped[resources.FormatObjectReference(&res)] = struct{}{}
 				continue
 			}
 		}
 
+		if res.GroupVersionKind() == gvk.Job {
+			action, err := a.jobRerun(ctx, rr, kind, &res, current)
+			if err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", resources.FormatObjectReference(&res), err))
+				continue
+			}
+
+			switch action {
+			case jobSkip:
+				// not deployed by this reconciliation, i.e. already run or
+				// removed by ttlSecondsAfterFinished
+				skipped[resources.FormatObjectReference(&res)] = struct{}{}
+				continue
+			case jobPending:
+				pendingJobs = append(pendingJobs, resources.FormatObjectReference(&res))
+				continue
+			case jobDeploy:
+				// deployed as any other resource
+			}
+		}
+
 		var ok bool
 		var err error
 
-		switch rr.Resources[i].GroupVersionKind() {
-		case gvk.CustomResourceDefinition:
-			ok, err = a.deployCRD(ctx, rr, res, current)
-		default:
-			ok, err = a.deploy(ctx, rr, res, current)
+		// current is updated in place when deployed, keep track of the
+		// version it has been previously deployed with
+		currentVersion := ""
+		if current != nil {
+			currentVersion = resources.GetAnnotation(current, annotations.PlatformVersion)
+		}
+
+		start := time.Now()
+		isNew := current == nil
+
+		ok, err = a.deployWithRetry(ctx, rr, res, current)
+
+		if ok || err != nil {
+			DeployDurationSeconds.WithLabelValues(controllerName).Observe(time.Since(start).Seconds())
 		}
 
 		if err != nil {
-			return fmt.Errorf("failure deploying resource %s: %w", res, err)
+			DeployErrorsTotal.WithLabelValues(controllerName).Inc()
+			rr.RecordEvent(corev1.EventTypeWarning, EventReasonDeployError, "Failed to deploy %s %s: %v",
+				res.GetKind(), client.ObjectKeyFromObject(&res), err)
+
+			if _, nonCritical := a.nonCritical[res.GroupVersionKind()]; nonCritical {
+				logf.FromContext(ctx).Info("non critical resource failed to deploy",
+					"gvk", res.GroupVersionKind(),
+					"name", client.ObjectKeyFromObject(&res),
+					"error", err.Error(),
+				)
+
+				degraded[resources.FormatObjectReference(&res)] = err.Error()
+				continue
+			}
+
+			conflicts = append(conflicts, FieldConflicts(resources.FormatObjectReference(&res), err)...)
+			failures = append(failures, fmt.Errorf("%s: %w", resources.FormatObjectReference(&res), err))
+			continue
 		}
 
 		if ok {
 			DeployedResourcesTotal.WithLabelValues(controllerName).Inc()
+
+			if isNew && res.GroupVersionKind() == gvk.Job {
+				if err := a.recordJob(ctx, rr, kind, &res); err != nil {
+					failures = append(failures, fmt.Errorf("%s: %w", resources.FormatObjectReference(&res), err))
+				}
+			}
+
+			if isNew {
+				created = append(created, resources.FormatObjectReference(&res))
+			} else {
+				updated = append(updated, resources.FormatObjectReference(&res))
+			}
+
+			if currentVersion != "" && currentVersion != rr.Release.Version.String() {
+				upgradedFrom = currentVersion
+			}
 		}
 	}
 
+	if !a.force {
+		setConflictsCondition(rr, conflicts)
+	}
+
+	if len(failures) > 0 {
+		return odherrors.NewDeployError(failures...)
+	}
+
+	setDeployedResources(rr, degraded, skipped)
+
+	if a.audit && len(created)+len(updated) > 0 {
+		// the resources are deployed at this point, failing to record them
+		// would not make the next reconciliation record them either
+		if err := a.recordAudit(ctx, rr, kind, created, updated); err != nil {
+			logf.FromContext(ctx).Error(err, "unable to record audit entry")
+		}
+	}
+
+	if upgradedFrom != "" {
+		rr.RecordEvent(corev1.EventTypeNormal, EventReasonUpgraded, "Upgraded resources from version %s to %s",
+			upgradedFrom, rr.Release.Version.String())
+	}
+
+	if len(pendingJobs) > 0 {
+		return odherrors.NewWaitError(DefaultJobRequeueAfter, "waiting for jobs to be re-run: %s", strings.Join(pendingJobs, ", "))
+	}
+
 	return nil
 }
 
+func (a *Action) deployWithRetry(
+	ctx context.Context,
+	rr *odhTypes.ReconciliationRequest,
+	obj unstructured.Unstructured,
+	current *unstructured.Unstructured,
+) (bool, error) {
+	deployFn := func() (bool, error) {
+		switch obj.GroupVersionKind() {
+		case gvk.CustomResourceDefinition:
+			return a.deployCRD(ctx, rr, obj, current)
+		default:
+			return a.deploy(ctx, rr, obj, current)
+		}
+	}
+
+	if a.backoff == nil {
+		return deployFn()
+	}
+
+	ok := false
+	attempts := 0
+
+	err := retry.OnError(*a.backoff, IsTransientError, func() error {
+		var err error
+
+		attempts++
+		ok, err = deployFn()
+
+		if err != nil && IsTransientError(err) {
+			logf.FromContext(ctx).V(3).Info("transient deploy failure",
+				"gvk", obj.GroupVersionKind(),
+				"name", client.ObjectKeyFromObject(&obj),
+				"attempt", attempts,
+				"error", err.Error(),
+			)
+		}
+
+		return err
+	})
+
+	if err != nil && IsTransientError(err) {
+		return false, odherrors.NewRetriesExhaustedError(attempts, err)
+	}
+
+	return ok, err
+}
+
 // ShouldSkip determines whether resource deployment should be skipped based on cache state.
 // Returns true if the resource is cached and deployment should be skipped, false if deployment should proceed.
 // Delegates to cache for deletion timestamp handling and cache cleanup.
@@ -208,7 +513,7 @@ func (a *Action) deployCRD(
 ) (bool, error) {
 	resources.SetLabels(&obj, a.labels)
 	resources.SetAnnotations(&obj, a.annotations)
-	resources.SetLabel(&obj, labels.PlatformPartOf, labels.Platform)
+	SetPlatformMetadata(rr, "", &obj)
 
 	shouldSkip, err := a.ShouldSkip(current, &obj)
 	if err != nil {
@@ -218,6 +523,10 @@ func (a *Action) deployCRD(
 		return false, nil
 	}
 
+	if err := a.throttle(ctx); err != nil {
+		return false, err
+	}
+
 	// backup copy for caching
 	origObj := obj.DeepCopy()
 
@@ -259,26 +568,26 @@ func (a *Action) deploy(
 	obj unstructured.Unstructured,
 	current *unstructured.Unstructured,
 ) (bool, error) {
-	fo := a.fieldOwner
-	if fo == "" {
-		kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
-		if err != nil {
-			return false, err
-		}
-
-		fo = strings.ToLower(kind)
+	kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
+	if err != nil {
+		return false, err
 	}
 
 	resources.SetLabels(&obj, a.labels)
 	resources.SetAnnotations(&obj, a.annotations)
-	resources.SetAnnotation(&obj, annotations.InstanceGeneration, strconv.FormatInt(rr.Instance.GetGeneration(), 10))
-	resources.SetAnnotation(&obj, annotations.InstanceName, rr.Instance.GetName())
-	resources.SetAnnotation(&obj, annotations.InstanceUID, string(rr.Instance.GetUID()))
-	resources.SetAnnotation(&obj, annotations.PlatformType, string(rr.Release.Name))
-	resources.SetAnnotation(&obj, annotations.PlatformVersion, rr.Release.Version.String())
+	SetPlatformMetadata(rr, kind, &obj)
+
+	if a.tracking {
+		app := a.trackingApp
+		if app == "" {
+			app = rr.Instance.GetName()
+		}
 
-	if resources.GetLabel(&obj, labels.PlatformPartOf) == "" && fo != "" {
-		resources.SetLabel(&obj, labels.PlatformPartOf, fo)
+		setTracking(&obj, app)
+	}
+
+	if uid := rr.Instance.GetUID(); a.instanceUIDLabel && uid != "" {
+		resources.SetLabel(&obj, labels.PlatformInstanceUID, string(uid))
 	}
 
 	shouldSkip, err := a.ShouldSkip(current, &obj)
@@ -289,9 +598,22 @@ func (a *Action) deploy(
 		return false, nil
 	}
 
+	if err := a.throttle(ctx); err != nil {
+		return false, err
+	}
+
 	// backup copy for caching
 	origObj := obj.DeepCopy()
 
+	// a change to an object already deployed for the same revision means that
+	// it has been modified out of band, compute it before current gets updated
+	// in place
+	deployedRevision := isDeployedRevision(rr, current)
+	currentResourceVersion := ""
+	if current != nil {
+		currentResourceVersion = current.GetResourceVersion()
+	}
+
 	var deployedObj *unstructured.Unstructured
 
 	switch {
@@ -316,8 +638,11 @@ func (a *Action) deploy(
 		}
 
 		ops := []client.PatchOption{
-			client.ForceOwnership,
-			client.FieldOwner(fo),
+			client.FieldOwner(a.fieldOwnerFor(kind)),
+		}
+
+		if a.force {
+			ops = append(ops, client.ForceOwnership)
 		}
 
 		switch a.deployMode {
@@ -332,6 +657,17 @@ func (a *Action) deploy(
 		if err != nil {
 			return false, err
 		}
+
+		if deployedObj != nil {
+			if err := a.migrateFieldOwner(ctx, rr.Client, deployedObj, kind); err != nil {
+				return false, err
+			}
+		}
+
+		if deployedRevision && deployedObj != nil && deployedObj.GetResourceVersion() != currentResourceVersion {
+			rr.RecordEvent(corev1.EventTypeNormal, EventReasonDriftCorrected, "Reverted out of band changes to %s %s",
+				obj.GetKind(), client.ObjectKeyFromObject(&obj))
+		}
 	}
 
 	if a.cache != nil {
@@ -344,6 +680,20 @@ func (a *Action) deploy(
 	return true, nil
 }
 
+// throttle waits for the rate limiter, if any, to allow a request to the API
+// server.
+func (a *Action) throttle(ctx context.Context) error {
+	if a.limiter == nil {
+		return nil
+	}
+
+	if err := a.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter wait failed: %w", err)
+	}
+
+	return nil
+}
+
 func (a *Action) create(
 	ctx context.Context,
 	cli client.Client,
@@ -484,7 +834,10 @@ func (a *Action) apply(
 
 func NewAction(opts ...ActionOpts) actions.Fn {
 	action := Action{
-		deployMode: ModeSSA,
+		deployMode:       ModeSSA,
+		fieldOwnerFormat: StandardFieldOwnerFormat,
+		nonCritical:      map[schema.GroupVersionKind]struct{}{},
+		force:            true,
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,150 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+const (
+	// EventReasonApplied is the reason of the event recorded when a new revision
+	// of the resources is applied and the audit trail is enabled.
+	EventReasonApplied = "Applied"
+
+	// AuditKey is the key of the audit ConfigMap holding the audit entries, one
+	// JSON document per line, oldest first.
+	AuditKey = "audit.log"
+
+	// DefaultAuditEntries is the number of entries kept in the audit ConfigMap.
+	DefaultAuditEntries = 100
+)
+
+type AuditTrigger string
+
+const (
+	// AuditTriggerGeneration is the trigger of revisions applied because the
+	// spec of the component, or of the platform, has changed.
+	AuditTriggerGeneration AuditTrigger = "generation"
+	// AuditTriggerUpgrade is the trigger of revisions applied because the
+	// platform has been upgraded.
+	AuditTriggerUpgrade AuditTrigger = "upgrade"
+	// AuditTriggerDrift is the trigger of revisions applied to revert changes
+	// made to the resources outside the operator.
+	AuditTriggerDrift AuditTrigger = "drift"
+)
+
+// AuditEntry describes a revision of the resources of a component applied by
+// the deploy action.
+type AuditEntry struct {
+	Time       time.Time    `json:"time"`
+	Trigger    AuditTrigger `json:"trigger"`
+	Generation int64        `json:"generation"`
+	Version    string       `json:"version"`
+	// Hash is the hash of the inputs the resources have been rendered from.
+	Hash    string   `json:"hash,omitempty"`
+	Created []string `json:"created,omitempty"`
+	Updated []string `json:"updated,omitempty"`
+}
+
+// auditConfigMapName returns the name of the audit ConfigMap of the component
+// of the given kind.
+func auditConfigMapName(kind string, rr *odhTypes.ReconciliationRequest) string {
+	return strings.ToLower(kind) + "-" + rr.Instance.GetName() + "-audit"
+}
+
+// recordAudit appends an entry describing the resources created and updated by
+// the current reconciliation to the audit ConfigMap of the component, dropping
+// the oldest entries beyond DefaultAuditEntries, and records an event.
+func (a *Action) recordAudit(ctx context.Context, rr *odhTypes.ReconciliationRequest, kind string, created []string, updated []string) error {
+	entry := AuditEntry{
+		Time:       time.Now().UTC(),
+		Generation: rr.Instance.GetGeneration(),
+		Version:    rr.Release.Version.String(),
+		Created:    created,
+		Updated:    updated,
+	}
+
+	if rr.DSCI != nil {
+		hash, err := odhTypes.HashStr(rr)
+		if err != nil {
+			return err
+		}
+
+		entry.Hash = hash
+	}
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      auditConfigMapName(kind, rr),
+			Namespace: a.auditNamespace,
+		},
+	}
+
+	if cm.Namespace == "" && rr.DSCI != nil {
+		cm.Namespace = rr.DSCI.Spec.ApplicationsNamespace
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, rr.Client, &cm, func() error {
+		lines := make([]string, 0)
+		if log := strings.TrimSpace(cm.Data[AuditKey]); log != "" {
+			lines = strings.Split(log, "\n")
+		}
+
+		entry.Trigger = auditTrigger(lines, &entry)
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, string(data))
+		if len(lines) > DefaultAuditEntries {
+			lines = lines[len(lines)-DefaultAuditEntries:]
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+
+		cm.Data[AuditKey] = strings.Join(lines, "\n") + "\n"
+
+		return controllerutil.SetOwnerReference(rr.Instance, &cm, rr.Client.Scheme())
+	})
+	if err != nil {
+		return err
+	}
+
+	rr.RecordEvent(corev1.EventTypeNormal, EventReasonApplied, "Applied revision %d (%s): %d created, %d updated",
+		entry.Generation, entry.Trigger, len(created), len(updated))
+
+	return nil
+}
+
+// auditTrigger infers what triggered the given entry by comparing it with the
+// last of the given audit lines.
+func auditTrigger(lines []string, entry *AuditEntry) AuditTrigger {
+	if len(lines) == 0 {
+		return AuditTriggerGeneration
+	}
+
+	last := AuditEntry{}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &last); err != nil {
+		return AuditTriggerGeneration
+	}
+
+	switch {
+	case last.Version != entry.Version:
+		return AuditTriggerUpgrade
+	case last.Generation != entry.Generation || last.Hash != entry.Hash:
+		return AuditTriggerGeneration
+	default:
+		return AuditTriggerDrift
+	}
+}
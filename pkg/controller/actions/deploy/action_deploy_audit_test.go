@@ -0,0 +1,88 @@
+package deploy_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDeployAuditTrail(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	cl, err := fakeclient.New(applyAsMergePatch())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	recorder := record.NewFakeRecorder(10)
+	action := deploy.NewAction(
+		deploy.WithMode(deploy.ModePatch),
+		deploy.WithAuditTrail(ns),
+	)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(recorder))),
+		fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(action(ctx, rr)).Should(Succeed())
+
+	// modify the resource out of band, so that it is reverted
+	cm := corev1.ConfigMap{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &cm)).Should(Succeed())
+	cm.Data["key"] = "changed"
+	g.Expect(cl.Update(ctx, &cm)).Should(Succeed())
+
+	rr, err = fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(recorder))),
+		fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(action(ctx, rr)).Should(Succeed())
+
+	audit := corev1.ConfigMap{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: "dashboard-default-dashboard-audit"}, &audit)).Should(Succeed())
+
+	lines := strings.Split(strings.TrimSpace(audit.Data[deploy.AuditKey]), "\n")
+	g.Expect(lines).Should(HaveLen(2))
+
+	entries := make([]deploy.AuditEntry, len(lines))
+	for i := range lines {
+		g.Expect(json.Unmarshal([]byte(lines[i]), &entries[i])).Should(Succeed())
+	}
+
+	g.Expect(entries[0]).Should(And(
+		HaveField("Trigger", deploy.AuditTriggerGeneration),
+		HaveField("Generation", int64(1)),
+		HaveField("Version", "1.2.3"),
+		HaveField("Created", ConsistOf(ContainSubstring(name))),
+		HaveField("Updated", BeEmpty()),
+	))
+	g.Expect(entries[1]).Should(And(
+		HaveField("Trigger", deploy.AuditTriggerDrift),
+		HaveField("Created", BeEmpty()),
+		HaveField("Updated", ConsistOf(ContainSubstring(name))),
+	))
+
+	g.Expect(recorder.Events).Should(Receive(ContainSubstring(deploy.EventReasonApplied)))
+}
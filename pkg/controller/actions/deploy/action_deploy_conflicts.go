@@ -0,0 +1,99 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+// conflictManager extracts the competing manager from the message of a field
+// manager conflict cause, i.e. conflict with "kubectl-edit" using apps/v1.
+var conflictManager = regexp.MustCompile(`conflict with "([^"]+)"`)
+
+// FieldConflict describes a field of a resource which could not be applied
+// because it is owned by another field manager.
+type FieldConflict struct {
+	Resource string
+	Field    string
+	Manager  string
+}
+
+func (c FieldConflict) String() string {
+	return fmt.Sprintf("%s %s (managed by %s)", c.Resource, c.Field, c.Manager)
+}
+
+// IsFieldManagerConflict returns true if the given error is an apply conflict
+// with other field managers, which retrying does not solve.
+func IsFieldManagerConflict(err error) bool {
+	return len(FieldConflicts("", err)) > 0
+}
+
+// FieldConflicts returns the fields of the given resource, and their competing
+// managers, reported by the given apply conflict error.
+func FieldConflicts(resource string, err error) []FieldConflict {
+	var se *k8serr.StatusError
+	if !errors.As(err, &se) || !k8serr.IsConflict(se) || se.ErrStatus.Details == nil {
+		return nil
+	}
+
+	result := make([]FieldConflict, 0)
+
+	for _, c := range se.ErrStatus.Details.Causes {
+		if c.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		manager := "unknown"
+		if m := conflictManager.FindStringSubmatch(c.Message); m != nil {
+			manager = m[1]
+		}
+
+		result = append(result, FieldConflict{
+			Resource: resource,
+			Field:    c.Field,
+			Manager:  manager,
+		})
+	}
+
+	return result
+}
+
+// setConflictsCondition reports the given field conflicts, if any, with the
+// FieldsOwned condition of the instance.
+func setConflictsCondition(rr *odhTypes.ReconciliationRequest, conflicts []FieldConflict) {
+	if rr.Conditions == nil {
+		return
+	}
+
+	if len(conflicts) == 0 {
+		rr.Conditions.MarkTrue(
+			status.ConditionFieldsOwned,
+			conditions.WithObservedGeneration(rr.Instance.GetGeneration()),
+		)
+
+		return
+	}
+
+	fields := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		fields = append(fields, c.String())
+	}
+
+	slices.Sort(fields)
+
+	rr.Conditions.MarkFalse(
+		status.ConditionFieldsOwned,
+		conditions.WithObservedGeneration(rr.Instance.GetGeneration()),
+		conditions.WithReason(status.ConditionFieldManagerConflictReason),
+		conditions.WithMessage("Fields owned by other managers: %s", strings.Join(fields, ", ")),
+	)
+}
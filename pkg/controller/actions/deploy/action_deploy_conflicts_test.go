@@ -0,0 +1,122 @@
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/xid"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+// conflictingApply rejects non forced apply patches as the API server does when
+// fields are owned by other managers.
+func conflictingApply() fakeclient.ClientOpts {
+	return fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			po := client.PatchOptions{}
+			po.ApplyOptions(opts)
+
+			if patch.Type() == apimachinery.ApplyPatchType && (po.Force == nil || !*po.Force) {
+				return k8serr.NewApplyConflict([]metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "argocd-controller" using v1`,
+					Field:   ".data.key",
+				}}, "Apply failed with 1 conflict")
+			}
+
+			data, err := patch.Data(obj)
+			if err != nil {
+				return err
+			}
+
+			return cli.Patch(ctx, obj, client.RawPatch(apimachinery.MergePatchType, data))
+		},
+	})
+}
+
+func TestDeployFieldConflicts(t *testing.T) {
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	tests := []struct {
+		name    string
+		force   bool
+		matcher func(g *WithT, err error, cond *metav1.Condition)
+	}{
+		{
+			name:  "force",
+			force: true,
+			matcher: func(g *WithT, err error, _ *metav1.Condition) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			},
+		},
+		{
+			name:  "no force",
+			force: false,
+			matcher: func(g *WithT, err error, cond *metav1.Condition) {
+				g.Expect(errors.As(err, &odherrors.DeployError{})).Should(BeTrue())
+				g.Expect(deploy.IsTransientError(err)).Should(BeFalse())
+
+				g.Expect(cond).ShouldNot(BeNil())
+				g.Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+				g.Expect(cond.Reason).Should(Equal(status.ConditionFieldManagerConflictReason))
+				g.Expect(cond.Message).Should(And(
+					ContainSubstring(".data.key"),
+					ContainSubstring("managed by argocd-controller"),
+				))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			cl, err := fakeclient.New(
+				fakeclient.WithObjects(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+				conflictingApply(),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(newEventsConfigMap(name, ns, "v2", "1", "1.2.3")),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+			err = deploy.NewAction(
+				deploy.WithMode(deploy.ModePatch),
+				deploy.WithForceOwnership(tt.force),
+			)(ctx, rr)
+
+			var cond *metav1.Condition
+			if c := rr.Conditions.GetCondition(status.ConditionFieldsOwned); c != nil {
+				cond = &metav1.Condition{Status: c.Status, Reason: c.Reason, Message: c.Message}
+			}
+
+			tt.matcher(g, err, cond)
+		})
+	}
+}
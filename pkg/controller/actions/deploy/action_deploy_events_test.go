@@ -0,0 +1,201 @@
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+// applyAsMergePatch turns apply patches into merge patches as the fake client does
+// not support server side apply.
+func applyAsMergePatch() fakeclient.ClientOpts {
+	return fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+			if patch.Type() != apimachinery.ApplyPatchType {
+				return cli.Patch(ctx, obj, patch)
+			}
+
+			data, err := patch.Data(obj)
+			if err != nil {
+				return err
+			}
+
+			return cli.Patch(ctx, obj, client.RawPatch(apimachinery.MergePatchType, data))
+		},
+	})
+}
+
+func newEventsInstance() *componentApi.Dashboard {
+	return &componentApi.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       componentApi.DashboardInstanceName,
+			Generation: 1,
+		},
+	}
+}
+
+func newEventsRelease() common.Release {
+	return common.Release{
+		Name: cluster.OpenDataHub,
+		Version: version.OperatorVersion{Version: semver.Version{
+			Major: 1, Minor: 2, Patch: 3,
+		}},
+	}
+}
+
+func newEventsConfigMap(name string, ns string, value string, generation string, ver string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Annotations: map[string]string{
+				annotations.InstanceGeneration: generation,
+				annotations.PlatformVersion:    ver,
+			},
+		},
+		Data: map[string]string{
+			"key": value,
+		},
+	}
+}
+
+func TestDeployEventsUpgrade(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	cl, err := fakeclient.New(
+		fakeclient.WithObjects(newEventsConfigMap(name, ns, "v1", "1", "1.0.0")),
+		applyAsMergePatch(),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	recorder := record.NewFakeRecorder(10)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(recorder))),
+		fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = deploy.NewAction(deploy.WithMode(deploy.ModePatch))(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(recorder.Events).Should(Receive(And(
+		ContainSubstring(deploy.EventReasonUpgraded),
+		ContainSubstring("from version 1.0.0 to 1.2.3"),
+	)))
+	g.Expect(recorder.Events).ShouldNot(Receive())
+}
+
+func TestDeployEventsDriftCorrected(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	cl, err := fakeclient.New(
+		fakeclient.WithObjects(newEventsConfigMap(name, ns, "changed", "1", "1.2.3")),
+		applyAsMergePatch(),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	recorder := record.NewFakeRecorder(10)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(recorder))),
+		fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = deploy.NewAction(deploy.WithMode(deploy.ModePatch))(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(recorder.Events).Should(Receive(And(
+		ContainSubstring(deploy.EventReasonDriftCorrected),
+		ContainSubstring(name),
+	)))
+	g.Expect(recorder.Events).ShouldNot(Receive())
+
+	cm := corev1.ConfigMap{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &cm)).Should(Succeed())
+	g.Expect(cm.Data).Should(HaveKeyWithValue("key", "v1"))
+}
+
+func TestDeployEventsError(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	cl, err := fakeclient.New(
+		fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.CreateOption) error {
+				return errors.New("boom")
+			},
+		}),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	recorder := record.NewFakeRecorder(10)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(recorder))),
+		fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	deploy.DeployErrorsTotal.Reset()
+	deploy.DeployDurationSeconds.Reset()
+
+	err = deploy.NewAction(deploy.WithMode(deploy.ModePatch))(ctx, rr)
+	g.Expect(err).Should(HaveOccurred())
+
+	g.Expect(testutil.ToFloat64(deploy.DeployErrorsTotal)).Should(Equal(float64(1)))
+	g.Expect(testutil.CollectAndCount(deploy.DeployDurationSeconds)).Should(Equal(1))
+
+	g.Expect(recorder.Events).Should(Receive(And(
+		HavePrefix(corev1.EventTypeWarning),
+		ContainSubstring(deploy.EventReasonDeployError),
+		ContainSubstring("boom"),
+	)))
+}
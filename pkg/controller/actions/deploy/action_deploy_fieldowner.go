@@ -0,0 +1,109 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// FieldOwnerComponent is replaced, in field owner formats, by the lowercase
+	// kind of the component whose resources are deployed.
+	FieldOwnerComponent = "{component}"
+
+	// StandardFieldOwnerFormat names the field manager after the operator and
+	// the component, i.e. odh-operator/dashboard. It is the default.
+	StandardFieldOwnerFormat = "odh-operator/" + FieldOwnerComponent
+
+	// LegacyFieldOwnerFormat names the field manager after the component only,
+	// as previous versions of the operator did.
+	LegacyFieldOwnerFormat = FieldOwnerComponent
+)
+
+// FieldOwner returns the field manager the resources of components of the given
+// kind are applied with according to the given format.
+func FieldOwner(format string, kind string) string {
+	return strings.ReplaceAll(format, FieldOwnerComponent, strings.ToLower(kind))
+}
+
+// fieldOwnerFor returns the field manager the resources of components of the
+// given kind are applied with by this action.
+func (a *Action) fieldOwnerFor(kind string) string {
+	if a.fieldOwner != "" {
+		return a.fieldOwner
+	}
+
+	return FieldOwner(a.fieldOwnerFormat, kind)
+}
+
+// migrateFieldOwner drops the entries of the legacy field manager from the
+// managed fields of an object that has just been applied with a different
+// manager. Without it, the legacy manager would keep co-owning every field it
+// applied, and the fields removed from the manifests would never be pruned.
+//
+// A fixed field owner set with WithFieldOwner is meant to match a previous
+// manager, so no migration happens in that case.
+func (a *Action) migrateFieldOwner(
+	ctx context.Context,
+	cli client.Client,
+	obj *unstructured.Unstructured,
+	kind string,
+) error {
+	if a.fieldOwner != "" {
+		return nil
+	}
+
+	legacy := FieldOwner(LegacyFieldOwnerFormat, kind)
+	if legacy == a.fieldOwnerFor(kind) {
+		return nil
+	}
+
+	mf := obj.GetManagedFields()
+	patch := make([]map[string]any, 0)
+
+	// remove from the last entry so that the indexes of the entries still to
+	// be removed are not shifted, the test operations make the patch fail if
+	// the managed fields have been changed in between
+	for i := len(mf) - 1; i >= 0; i-- {
+		if mf[i].Manager != legacy || mf[i].Operation != metav1.ManagedFieldsOperationApply {
+			continue
+		}
+
+		path := fmt.Sprintf("/metadata/managedFields/%d", i)
+
+		patch = append(patch,
+			map[string]any{"op": "test", "path": path + "/manager", "value": legacy},
+			map[string]any{"op": "remove", "path": path},
+		)
+	}
+
+	if len(patch) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	logf.FromContext(ctx).V(3).Info("migrate field owner",
+		"gvk", obj.GroupVersionKind(),
+		"name", client.ObjectKeyFromObject(obj),
+		"from", legacy,
+		"to", a.fieldOwnerFor(kind),
+	)
+
+	err = cli.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, data))
+	if err != nil {
+		return fmt.Errorf("failed to migrate field owner of %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+	}
+
+	return nil
+}
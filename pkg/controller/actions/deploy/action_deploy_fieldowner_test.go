@@ -0,0 +1,191 @@
+package deploy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFieldOwner(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(deploy.FieldOwner(deploy.StandardFieldOwnerFormat, "Dashboard")).Should(Equal("odh-operator/dashboard"))
+	g.Expect(deploy.FieldOwner(deploy.LegacyFieldOwnerFormat, "Dashboard")).Should(Equal("dashboard"))
+
+	// a fixed manager, i.e. the one of a previous controller
+	g.Expect(deploy.FieldOwner("dashboard-controller", "Dashboard")).Should(Equal("dashboard-controller"))
+}
+
+func TestDeployFieldOwnerFormat(t *testing.T) {
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	tests := []struct {
+		name    string
+		opts    []deploy.ActionOpts
+		manager string
+	}{
+		{
+			name:    "default",
+			manager: "odh-operator/dashboard",
+		},
+		{
+			name:    "legacy",
+			opts:    []deploy.ActionOpts{deploy.WithFieldOwnerFormat(deploy.LegacyFieldOwnerFormat)},
+			manager: "dashboard",
+		},
+		{
+			name: "field owner",
+			opts: []deploy.ActionOpts{
+				deploy.WithFieldOwnerFormat(deploy.LegacyFieldOwnerFormat),
+				deploy.WithFieldOwner("dashboard-controller"),
+			},
+			manager: "dashboard-controller",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			managers := make([]string, 0)
+
+			cl, err := fakeclient.New(
+				fakeclient.WithObjects(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+				fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						po := client.PatchOptions{}
+						po.ApplyOptions(opts)
+						managers = append(managers, po.FieldManager)
+
+						data, err := patch.Data(obj)
+						if err != nil {
+							return err
+						}
+
+						return cli.Patch(ctx, obj, client.RawPatch(apimachinery.MergePatchType, data))
+					},
+				}),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(newEventsConfigMap(name, ns, "v2", "1", "1.2.3")),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			err = deploy.NewAction(tt.opts...)(ctx, rr)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(managers).Should(ConsistOf(tt.manager))
+
+			cm := corev1.ConfigMap{}
+			g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &cm)).Should(Succeed())
+
+			// the part-of label doesn't depend on the field manager, it is
+			// used by gc and the status actions to select the resources
+			g.Expect(cm.Labels).Should(HaveKeyWithValue(labels.PlatformPartOf, "dashboard"))
+		})
+	}
+}
+
+func TestDeployFieldOwnerMigration(t *testing.T) {
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	tests := []struct {
+		name     string
+		opts     []deploy.ActionOpts
+		managers []string
+	}{
+		{
+			name:     "default",
+			managers: []string{"kubectl-edit"},
+		},
+		{
+			name:     "legacy",
+			opts:     []deploy.ActionOpts{deploy.WithFieldOwnerFormat(deploy.LegacyFieldOwnerFormat)},
+			managers: []string{"dashboard", "kubectl-edit"},
+		},
+		{
+			name:     "field owner",
+			opts:     []deploy.ActionOpts{deploy.WithFieldOwner("dashboard-controller")},
+			managers: []string{"dashboard", "kubectl-edit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			// an object applied by a previous version of the operator, and
+			// edited by hand afterward
+			live := newEventsConfigMap(name, ns, "v1", "1", "1.2.3")
+			live.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:    "dashboard",
+					Operation:  metav1.ManagedFieldsOperationApply,
+					APIVersion: "v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{},"f:removed":{}}}`)},
+				},
+				{
+					Manager:    "kubectl-edit",
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:team":{}}}}`)},
+				},
+			}
+
+			cl, err := fakeclient.New(
+				fakeclient.WithObjects(live),
+				applyAsMergePatch(),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(newEventsConfigMap(name, ns, "v2", "1", "1.2.3")),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			err = deploy.NewAction(tt.opts...)(ctx, rr)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			cm := corev1.ConfigMap{}
+			g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &cm)).Should(Succeed())
+			g.Expect(cm.Data).Should(HaveKeyWithValue("key", "v2"))
+
+			managers := make([]string, 0, len(cm.ManagedFields))
+			for _, e := range cm.ManagedFields {
+				managers = append(managers, e.Manager)
+			}
+
+			g.Expect(managers).Should(ConsistOf(tt.managers))
+		})
+	}
+}
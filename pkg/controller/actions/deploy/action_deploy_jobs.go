@@ -0,0 +1,272 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// JobRerunPolicy defines, through the annotations.JobRerun annotation, how a
+// rendered Job is run again. Jobs without the annotation are applied as any
+// other resource, so a change of their immutable pod template fails and a
+// removed Job is run again.
+type JobRerunPolicy string
+
+const (
+	// JobRerunOnChange re-runs the Job when its rendered spec changes: once the
+	// previous run is finished, the Job is deleted and created again.
+	JobRerunOnChange JobRerunPolicy = "on-change"
+	// JobRerunNever leaves an existing Job untouched whatever its rendered spec,
+	// the Job is only created when missing.
+	JobRerunNever JobRerunPolicy = "never"
+	// JobRunOnce runs the Job once for the lifetime of the component: it is not
+	// created again once removed, i.e. by ttlSecondsAfterFinished.
+	JobRunOnce JobRerunPolicy = "once"
+
+	// DefaultJobRequeueAfter is the delay after which the request is requeued
+	// while waiting for Jobs to be re-run.
+	DefaultJobRequeueAfter = 10 * time.Second
+)
+
+type jobAction int
+
+const (
+	jobDeploy jobAction = iota
+	jobSkip
+	jobPending
+)
+
+// jobsConfigMapName returns the name of the ConfigMap recording the Jobs of the
+// component of the given kind run with the JobRunOnce policy.
+func jobsConfigMapName(kind string, rr *odhTypes.ReconciliationRequest) string {
+	return strings.ToLower(kind) + "-" + rr.Instance.GetName() + "-jobs"
+}
+
+// jobRerun applies the re-run policy of the given rendered Job, whose live
+// counterpart is current, and returns whether it must be deployed, skipped or
+// waited for.
+func (a *Action) jobRerun(
+	ctx context.Context,
+	rr *odhTypes.ReconciliationRequest,
+	kind string,
+	obj *unstructured.Unstructured,
+	current *unstructured.Unstructured,
+) (jobAction, error) {
+	policy := JobRerunPolicy(resources.GetAnnotation(obj, annotations.JobRerun))
+
+	switch policy {
+	case "":
+		return jobDeploy, nil
+	case JobRerunNever:
+		if current != nil {
+			return jobSkip, a.refreshJob(ctx, rr, current)
+		}
+
+		return jobDeploy, nil
+	case JobRunOnce:
+		return a.jobRunOnce(ctx, rr, kind, obj, current)
+	case JobRerunOnChange:
+		return a.jobRerunOnChange(ctx, rr, obj, current)
+	default:
+		return jobSkip, fmt.Errorf("unsupported job re-run policy %q", policy)
+	}
+}
+
+func (a *Action) jobRerunOnChange(
+	ctx context.Context,
+	rr *odhTypes.ReconciliationRequest,
+	obj *unstructured.Unstructured,
+	current *unstructured.Unstructured,
+) (jobAction, error) {
+	spec, err := json.Marshal(obj.Object["spec"])
+	if err != nil {
+		return jobSkip, err
+	}
+
+	sum := sha256.Sum256(spec)
+	hash := resources.EncodeToString(sum[:])
+
+	resources.SetAnnotation(obj, annotations.JobSpecHash, hash)
+
+	if current == nil || resources.GetAnnotation(current, annotations.JobSpecHash) == hash {
+		return jobDeploy, nil
+	}
+
+	if !current.GetDeletionTimestamp().IsZero() {
+		return jobPending, nil
+	}
+
+	job := batchv1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(current.Object, &job); err != nil {
+		return jobSkip, err
+	}
+
+	// a running Job is not interrupted, it is re-run once finished
+	if !jobFinished(&job) {
+		return jobPending, a.refreshJob(ctx, rr, current)
+	}
+
+	logf.FromContext(ctx).V(3).Info("deleting job to re-run it", "name", client.ObjectKeyFromObject(obj))
+
+	err = rr.Client.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !k8serr.IsNotFound(err) {
+		return jobSkip, fmt.Errorf("unable to delete job: %w", err)
+	}
+
+	return jobPending, nil
+}
+
+func (a *Action) jobRunOnce(
+	ctx context.Context,
+	rr *odhTypes.ReconciliationRequest,
+	kind string,
+	obj *unstructured.Unstructured,
+	current *unstructured.Unstructured,
+) (jobAction, error) {
+	if current != nil {
+		if err := a.refreshJob(ctx, rr, current); err != nil {
+			return jobSkip, err
+		}
+
+		return jobSkip, a.recordJob(ctx, rr, kind, obj)
+	}
+
+	recorded, err := jobRecorded(ctx, rr, kind, obj)
+	switch {
+	case err != nil:
+		return jobSkip, err
+	case recorded:
+		return jobSkip, nil
+	default:
+		return jobDeploy, nil
+	}
+}
+
+// JobRecorded returns whether the given rendered Job has the JobRunOnce policy
+// and has already been run for the instance of the request. Such a Job is not
+// created again once removed, i.e. by ttlSecondsAfterFinished, so it has to be
+// considered as done when it is missing.
+func JobRecorded(ctx context.Context, rr *odhTypes.ReconciliationRequest, obj *unstructured.Unstructured) (bool, error) {
+	if JobRerunPolicy(resources.GetAnnotation(obj, annotations.JobRerun)) != JobRunOnce {
+		return false, nil
+	}
+
+	kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
+	if err != nil {
+		return false, err
+	}
+
+	return jobRecorded(ctx, rr, kind, obj)
+}
+
+func jobRecorded(ctx context.Context, rr *odhTypes.ReconciliationRequest, kind string, obj *unstructured.Unstructured) (bool, error) {
+	cm := corev1.ConfigMap{}
+
+	err := rr.Client.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: jobsConfigMapName(kind, rr)}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("unable to get job records: %w", err)
+	default:
+		return cm.Data[obj.GetName()] != "", nil
+	}
+}
+
+// recordJob records the given Job as run, if it has the JobRunOnce policy and
+// it is not recorded yet.
+func (a *Action) recordJob(ctx context.Context, rr *odhTypes.ReconciliationRequest, kind string, obj *unstructured.Unstructured) error {
+	if JobRerunPolicy(resources.GetAnnotation(obj, annotations.JobRerun)) != JobRunOnce {
+		return nil
+	}
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobsConfigMapName(kind, rr),
+			Namespace: obj.GetNamespace(),
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, rr.Client, &cm, func() error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+
+		if cm.Data[obj.GetName()] == "" {
+			cm.Data[obj.GetName()] = time.Now().UTC().Format(time.RFC3339)
+		}
+
+		return controllerutil.SetOwnerReference(rr.Instance, &cm, rr.Client.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("unable to record job: %w", err)
+	}
+
+	return nil
+}
+
+// refreshJob updates the annotations tracking the instance and the platform a
+// Job not re-applied because of its policy has been deployed for, as gc would
+// otherwise consider the Job as left behind by a previous generation or release.
+func (a *Action) refreshJob(ctx context.Context, rr *odhTypes.ReconciliationRequest, current *unstructured.Unstructured) error {
+	values := map[string]string{
+		annotations.InstanceGeneration: strconv.FormatInt(rr.Instance.GetGeneration(), 10),
+		annotations.InstanceName:       rr.Instance.GetName(),
+		annotations.InstanceUID:        string(rr.Instance.GetUID()),
+		annotations.PlatformType:       string(rr.Release.Name),
+		annotations.PlatformVersion:    rr.Release.Version.String(),
+	}
+
+	changed := false
+	for k, v := range values {
+		if resources.GetAnnotation(current, k) != v {
+			changed = true
+			break
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	patch := client.MergeFrom(current.DeepCopy())
+	resources.SetAnnotations(current, values)
+
+	if err := rr.Client.Patch(ctx, current, patch); err != nil {
+		return fmt.Errorf("unable to refresh job annotations: %w", err)
+	}
+
+	return nil
+}
+
+func jobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		if c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,252 @@
+package deploy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/xid"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+func newJob(name string, ns string, policy deploy.JobRerunPolicy, image string) *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Annotations: map[string]string{
+				annotations.JobRerun: string(policy),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{{Name: "job", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func withJobStatus(job *batchv1.Job, finished bool) *batchv1.Job {
+	if finished {
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:   batchv1.JobComplete,
+			Status: corev1.ConditionTrue,
+		}}
+	}
+
+	// a different hash than the one of the rendered spec
+	job.Annotations[annotations.JobSpecHash] = "previous"
+
+	return job
+}
+
+func TestDeployJobRerun(t *testing.T) {
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	tests := []struct {
+		name     string
+		policy   deploy.JobRerunPolicy
+		existing []client.Object
+		deployed bool
+		matcher  func(g *WithT, cl client.Client, err error)
+	}{
+		{
+			name:     "never",
+			policy:   deploy.JobRerunNever,
+			existing: []client.Object{withJobStatus(newJob(name, ns, deploy.JobRerunNever, "v1"), true)},
+			matcher: func(g *WithT, cl client.Client, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+
+				job := batchv1.Job{}
+				g.Expect(cl.Get(t.Context(), client.ObjectKey{Namespace: ns, Name: name}, &job)).Should(Succeed())
+				g.Expect(job.Spec.Template.Spec.Containers[0].Image).Should(Equal("v1"))
+			},
+		},
+		{
+			name:     "once",
+			policy:   deploy.JobRunOnce,
+			deployed: true,
+			matcher: func(g *WithT, cl client.Client, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+
+				job := batchv1.Job{}
+				g.Expect(cl.Get(t.Context(), client.ObjectKey{Namespace: ns, Name: name}, &job)).Should(Succeed())
+
+				cm := corev1.ConfigMap{}
+				g.Expect(cl.Get(t.Context(), client.ObjectKey{Namespace: ns, Name: "dashboard-default-dashboard-jobs"}, &cm)).Should(Succeed())
+				g.Expect(cm.Data).Should(HaveKey(name))
+			},
+		},
+		{
+			name:   "once already run",
+			policy: deploy.JobRunOnce,
+			existing: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "dashboard-default-dashboard-jobs"},
+				Data:       map[string]string{name: "2025-01-01T00:00:00Z"},
+			}},
+			matcher: func(g *WithT, cl client.Client, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+
+				err = cl.Get(t.Context(), client.ObjectKey{Namespace: ns, Name: name}, &batchv1.Job{})
+				g.Expect(k8serr.IsNotFound(err)).Should(BeTrue())
+			},
+		},
+		{
+			name:     "on-change finished",
+			policy:   deploy.JobRerunOnChange,
+			existing: []client.Object{withJobStatus(newJob(name, ns, deploy.JobRerunOnChange, "v1"), true)},
+			matcher: func(g *WithT, cl client.Client, err error) {
+				g.Expect(errors.As(err, &odherrors.WaitError{})).Should(BeTrue())
+
+				err = cl.Get(t.Context(), client.ObjectKey{Namespace: ns, Name: name}, &batchv1.Job{})
+				g.Expect(k8serr.IsNotFound(err)).Should(BeTrue())
+			},
+		},
+		{
+			name:     "on-change running",
+			policy:   deploy.JobRerunOnChange,
+			existing: []client.Object{withJobStatus(newJob(name, ns, deploy.JobRerunOnChange, "v1"), false)},
+			matcher: func(g *WithT, cl client.Client, err error) {
+				g.Expect(errors.As(err, &odherrors.WaitError{})).Should(BeTrue())
+
+				job := batchv1.Job{}
+				g.Expect(cl.Get(t.Context(), client.ObjectKey{Namespace: ns, Name: name}, &job)).Should(Succeed())
+				g.Expect(job.Spec.Template.Spec.Containers[0].Image).Should(Equal("v1"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			cl, err := fakeclient.New(
+				fakeclient.WithObjects(tt.existing...),
+				applyAsMergePatch(),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(newJob(name, ns, tt.policy, "v2")),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			err = deploy.NewAction(
+				deploy.WithMode(deploy.ModePatch),
+			)(ctx, rr)
+
+			tt.matcher(g, cl, err)
+
+			if err == nil {
+				// Jobs left untouched by their policy are not reported as deployed
+				names := make([]string, 0)
+				for _, r := range rr.Instance.GetStatus().DeployedResources {
+					names = append(names, r.Name)
+				}
+
+				if tt.deployed {
+					g.Expect(names).Should(ContainElement(name))
+				} else {
+					g.Expect(names).ShouldNot(ContainElement(name))
+				}
+			}
+		})
+	}
+}
+
+func TestDeployJobRerunGc(t *testing.T) {
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	tests := []struct {
+		name     string
+		policy   deploy.JobRerunPolicy
+		finished bool
+	}{
+		{name: "never", policy: deploy.JobRerunNever, finished: true},
+		{name: "once", policy: deploy.JobRunOnce, finished: true},
+		{name: "on-change running", policy: deploy.JobRerunOnChange, finished: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			// deployed for a previous generation of the instance and a
+			// previous release
+			existing := withJobStatus(newJob(name, ns, tt.policy, "v1"), tt.finished)
+			existing.Annotations[annotations.InstanceGeneration] = "1"
+			existing.Annotations[annotations.InstanceUID] = "uid"
+			existing.Annotations[annotations.PlatformType] = "OpenDataHub"
+			existing.Annotations[annotations.PlatformVersion] = "1.2.2"
+
+			cl, err := fakeclient.New(
+				fakeclient.WithObjects(existing),
+				applyAsMergePatch(),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(newJob(name, ns, tt.policy, "v2")),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr.Instance.SetUID(apimachinery.UID("uid"))
+			rr.Instance.SetGeneration(2)
+
+			err = deploy.NewAction(
+				deploy.WithMode(deploy.ModePatch),
+			)(ctx, rr)
+			if tt.finished {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				g.Expect(errors.As(err, &odherrors.WaitError{})).Should(BeTrue())
+			}
+
+			job := batchv1.Job{}
+			g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &job)).Should(Succeed())
+			g.Expect(job.Spec.Template.Spec.Containers[0].Image).Should(Equal("v1"))
+
+			u, err := resources.ToUnstructured(&job)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			deletable, err := gc.DefaultObjectPredicate(rr, *u)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(deletable).Should(BeFalse())
+		})
+	}
+}
@@ -18,6 +18,33 @@ var (
 			"controller",
 		},
 	)
+
+	// DeployDurationSeconds is a prometheus histogram metrics which holds the time
+	// spent applying a single resource to the cluster per controller. It has one label.
+	// controller label refers to the controller name.
+	DeployDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "action_deploy_duration_seconds",
+			Help:    "Time spent deploying a resource",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{
+			"controller",
+		},
+	)
+
+	// DeployErrorsTotal is a prometheus counter metrics which holds the total
+	// number of resources that failed to deploy per controller. It has one label.
+	// controller label refers to the controller name.
+	DeployErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "action_deploy_errors_total",
+			Help: "Number of resources that failed to deploy",
+		},
+		[]string{
+			"controller",
+		},
+	)
 )
 
 // init register metrics to the global registry from controller-runtime/pkg/metrics.
@@ -26,4 +53,6 @@ var (
 //nolint:gochecknoinits
 func init() {
 	metrics.Registry.MustRegister(DeployedResourcesTotal)
+	metrics.Registry.MustRegister(DeployDurationSeconds)
+	metrics.Registry.MustRegister(DeployErrorsTotal)
 }
@@ -0,0 +1,349 @@
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega/types"
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestIsTransientError(t *testing.T) {
+	g := NewWithT(t)
+
+	gr := schema.GroupResource{Resource: "configmaps"}
+
+	g.Expect(deploy.IsTransientError(nil)).Should(BeFalse())
+	g.Expect(deploy.IsTransientError(errors.New("boom"))).Should(BeFalse())
+	g.Expect(deploy.IsTransientError(k8serr.NewForbidden(gr, "foo", errors.New("boom")))).Should(BeFalse())
+	g.Expect(deploy.IsTransientError(k8serr.NewConflict(gr, "foo", errors.New("boom")))).Should(BeTrue())
+	g.Expect(deploy.IsTransientError(k8serr.NewServiceUnavailable("boom"))).Should(BeTrue())
+	g.Expect(deploy.IsTransientError(k8serr.NewInternalError(errors.New("failed calling webhook")))).Should(BeTrue())
+	g.Expect(deploy.IsTransientError(k8serr.NewTimeoutError("boom", 1))).Should(BeTrue())
+}
+
+func TestDeployApplyBackoff(t *testing.T) {
+	backoff := wait.Backoff{
+		Steps:    3,
+		Duration: time.Millisecond,
+		Factor:   2,
+	}
+
+	tests := []struct {
+		name     string
+		failures int
+		err      error
+		calls    int
+		matcher  func(error) bool
+	}{
+		{
+			name:     "succeeds after transient failures",
+			failures: 2,
+			err:      k8serr.NewServiceUnavailable("webhook unavailable"),
+			calls:    3,
+		},
+		{
+			name:     "gives up once the backoff is exhausted",
+			failures: 5,
+			err:      k8serr.NewServiceUnavailable("webhook unavailable"),
+			calls:    3,
+			matcher: func(err error) bool {
+				ree := odherrors.RetriesExhaustedError{}
+				return errors.As(err, &ree) && ree.Attempts() == 3 && k8serr.IsServiceUnavailable(err)
+			},
+		},
+		{
+			name:     "does not retry permanent failures",
+			failures: 5,
+			err:      k8serr.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "foo", errors.New("denied")),
+			calls:    1,
+			matcher: func(err error) bool {
+				ree := odherrors.RetriesExhaustedError{}
+				return !errors.As(err, &ree) && k8serr.IsForbidden(err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := t.Context()
+			ns := xid.New().String()
+			name := xid.New().String()
+			calls := 0
+
+			cl, err := fakeclient.New(
+				fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						calls++
+						if calls <= tt.failures {
+							return tt.err
+						}
+
+						return cli.Create(ctx, obj, opts...)
+					},
+				}),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			err = deploy.NewAction(
+				deploy.WithMode(deploy.ModePatch),
+				deploy.WithApplyBackoff(backoff),
+			)(ctx, rr)
+
+			g.Expect(calls).Should(Equal(tt.calls))
+
+			if tt.matcher != nil {
+				g.Expect(err).Should(MatchError(tt.matcher, "matches expected error"))
+				return
+			}
+
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &corev1.ConfigMap{})).Should(Succeed())
+		})
+	}
+}
+
+func TestDeployAggregatesErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+	names := []string{xid.New().String(), xid.New().String(), xid.New().String()}
+
+	cl, err := fakeclient.New(
+		fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if obj.GetName() != names[1] {
+					return k8serr.NewForbidden(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), errors.New("denied"))
+				}
+
+				return cli.Create(ctx, obj, opts...)
+			},
+		}),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+		fakerequest.WithResources(newEventsConfigMap(names[0], ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	for _, name := range names[1:] {
+		g.Expect(rr.AddResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3"))).Should(Succeed())
+	}
+
+	err = deploy.NewAction(deploy.WithMode(deploy.ModePatch))(ctx, rr)
+
+	de := odherrors.DeployError{}
+	g.Expect(errors.As(err, &de)).Should(BeTrue())
+	g.Expect(de.Failures()).Should(HaveLen(2))
+	g.Expect(err).Should(MatchError(And(
+		ContainSubstring("2 resources failed to deploy"),
+		ContainSubstring(names[0]),
+		ContainSubstring(names[2]),
+	)))
+	g.Expect(k8serr.IsForbidden(err)).Should(BeTrue())
+
+	// the resource between the failing ones is deployed anyway
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: names[1]}, &corev1.ConfigMap{})).Should(Succeed())
+}
+
+func TestDeployNonCritical(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	cl, err := fakeclient.New(
+		fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				return k8serr.NewForbidden(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), errors.New("denied"))
+			},
+		}),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+		fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = deploy.NewAction(
+		deploy.WithMode(deploy.ModePatch),
+		deploy.WithNonCritical(gvk.ConfigMap),
+	)(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Instance.GetStatus().DeployedResources).Should(ConsistOf(And(
+		HaveField("Kind", gvk.ConfigMap.Kind),
+		HaveField("Name", name),
+		HaveField("Degraded", true),
+		HaveField("Message", ContainSubstring("denied")),
+	)))
+}
+
+func TestDeployDeployedResourcesSkipped(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+	name := xid.New().String()
+
+	unmanaged := newEventsConfigMap(xid.New().String(), ns, "v1", "1", "1.2.3")
+	unmanaged.Annotations[annotations.ManagedByODHOperator] = "false"
+
+	hook := newEventsConfigMap(xid.New().String(), ns, "v1", "1", "1.2.3")
+	hook.Annotations[annotations.Hook] = "pre-delete"
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(unmanaged))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+		fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.AddResources(
+		newEventsConfigMap(unmanaged.Name, ns, "v1", "1", "1.2.3"),
+		hook,
+	)).Should(Succeed())
+
+	err = deploy.NewAction(
+		deploy.WithMode(deploy.ModePatch),
+	)(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Instance.GetStatus().DeployedResources).Should(ConsistOf(
+		HaveField("Name", name),
+	))
+}
+
+func TestDeployInstanceUIDLabel(t *testing.T) {
+	ns := xid.New().String()
+
+	tests := []struct {
+		name    string
+		opts    []deploy.ActionOpts
+		matcher types.GomegaMatcher
+	}{
+		{
+			name:    "default",
+			matcher: Not(HaveKey(labels.PlatformInstanceUID)),
+		},
+		{
+			name:    "enabled",
+			opts:    []deploy.ActionOpts{deploy.WithInstanceUIDLabel()},
+			matcher: HaveKeyWithValue(labels.PlatformInstanceUID, "uid"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+			name := xid.New().String()
+
+			cl, err := fakeclient.New()
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(newEventsConfigMap(name, ns, "v1", "1", "1.2.3")),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr.Instance.SetUID("uid")
+
+			opts := append([]deploy.ActionOpts{deploy.WithMode(deploy.ModePatch)}, tt.opts...)
+
+			err = deploy.NewAction(opts...)(ctx, rr)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			cm := corev1.ConfigMap{}
+			g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &cm)).Should(Succeed())
+			g.Expect(cm.Labels).Should(tt.matcher)
+		})
+	}
+}
+
+func TestDeployRateLimit(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newEventsInstance()),
+		fakerequest.WithRelease(newEventsRelease()),
+		fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+		fakerequest.WithResources(newEventsConfigMap(xid.New().String(), ns, "v1", "1", "1.2.3")),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	for range 2 {
+		g.Expect(rr.AddResources(newEventsConfigMap(xid.New().String(), ns, "v1", "1", "1.2.3"))).Should(Succeed())
+	}
+
+	start := time.Now()
+
+	err = deploy.NewAction(
+		deploy.WithMode(deploy.ModePatch),
+		deploy.WithRateLimit(10, 1),
+	)(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// the first resource is sent right away, the other two wait for 100ms each
+	g.Expect(time.Since(start)).Should(BeNumerically(">=", 150*time.Millisecond))
+	g.Expect(rr.Instance.GetStatus().DeployedResources).Should(HaveLen(3))
+}
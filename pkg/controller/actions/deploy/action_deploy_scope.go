@@ -0,0 +1,87 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// ScopeMode defines how cluster-scoped resources are handled when the operator
+// runs in namespace-scoped mode.
+type ScopeMode string
+
+const (
+	// ScopeReject fails the deployment of components rendering cluster-scoped
+	// resources.
+	ScopeReject ScopeMode = "reject"
+	// ScopeStrip drops the cluster-scoped resources from the rendered ones.
+	ScopeStrip ScopeMode = "strip"
+)
+
+// WithNamespaceScope turns on the namespace-scoped mode, in which the action
+// only deploys namespaced resources in the given namespaces, so that the
+// operator can run with namespace-scoped RBAC. Resources in other namespaces are
+// always rejected, cluster-scoped ones are handled according to the given mode.
+//
+// The mode is turned off, the default, if no namespace is given.
+func WithNamespaceScope(mode ScopeMode, namespaces ...string) ActionOpts {
+	return func(action *Action) {
+		action.scopeMode = mode
+		action.scopeNamespaces = slices.Clone(namespaces)
+	}
+}
+
+// WithSettings configures the action according to the operator settings the
+// controller is built with, that is the namespace scope.
+func WithSettings(s odhTypes.Settings) ActionOpts {
+	return WithNamespaceScope(ScopeMode(s.ClusterScoped), s.WatchNamespaces...)
+}
+
+// enforceScope checks the rendered resources against the namespace scope set by
+// WithNamespaceScope, if any.
+func (a *Action) enforceScope(ctx context.Context, rr *odhTypes.ReconciliationRequest) error {
+	if len(a.scopeNamespaces) == 0 {
+		return nil
+	}
+
+	failures := make([]error, 0)
+	kept := make([]unstructured.Unstructured, 0, len(rr.Resources))
+
+	for i := range rr.Resources {
+		res := &rr.Resources[i]
+
+		namespaced, err := rr.Client.IsObjectNamespaced(res)
+		if err != nil {
+			return fmt.Errorf("unable to determine the scope of %s: %w", resources.FormatObjectReference(res), err)
+		}
+
+		switch {
+		case !namespaced && a.scopeMode == ScopeStrip:
+			logf.FromContext(ctx).Info("dropping cluster-scoped resource", "resource", resources.FormatObjectReference(res))
+			continue
+		case !namespaced:
+			failures = append(failures, fmt.Errorf("%s: cluster-scoped resources are not allowed in namespace-scoped mode",
+				resources.FormatObjectReference(res)))
+		case !slices.Contains(a.scopeNamespaces, res.GetNamespace()):
+			failures = append(failures, fmt.Errorf("%s: namespace %q is not one of the watched namespaces %v",
+				resources.FormatObjectReference(res), res.GetNamespace(), a.scopeNamespaces))
+		}
+
+		kept = append(kept, *res)
+	}
+
+	if len(failures) > 0 {
+		return odherrors.NewValidationError(failures...)
+	}
+
+	rr.Resources = kept
+
+	return nil
+}
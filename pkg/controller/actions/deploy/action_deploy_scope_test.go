@@ -0,0 +1,105 @@
+package deploy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDeployNamespaceScope(t *testing.T) {
+	ns := xid.New().String()
+
+	tests := []struct {
+		name      string
+		mode      deploy.ScopeMode
+		namespace string
+		matcher   func(g *WithT, err error)
+		deployed  bool
+	}{
+		{
+			name:      "reject",
+			mode:      deploy.ScopeReject,
+			namespace: ns,
+			matcher: func(g *WithT, err error) {
+				g.Expect(errors.As(err, &odherrors.ValidationError{})).Should(BeTrue())
+				g.Expect(err).Should(MatchError(ContainSubstring("cluster-scoped resources are not allowed")))
+			},
+		},
+		{
+			name:      "strip",
+			mode:      deploy.ScopeStrip,
+			namespace: ns,
+			matcher: func(g *WithT, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			},
+			deployed: true,
+		},
+		{
+			name:      "other namespace",
+			mode:      deploy.ScopeStrip,
+			namespace: xid.New().String(),
+			matcher: func(g *WithT, err error) {
+				g.Expect(err).Should(MatchError(ContainSubstring("is not one of the watched namespaces")))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			cl, err := fakeclient.New(applyAsMergePatch())
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			cm := newEventsConfigMap(xid.New().String(), tt.namespace, "v1", "1", "1.2.3")
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(cm),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			cr, err := resources.ToUnstructured(&rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: xid.New().String()},
+			})
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr.Resources = append(rr.Resources, *cr)
+
+			err = deploy.NewAction(
+				deploy.WithMode(deploy.ModePatch),
+				deploy.WithNamespaceScope(tt.mode, ns),
+			)(ctx, rr)
+			tt.matcher(g, err)
+
+			err = cl.Get(ctx, client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+			if tt.deployed {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				g.Expect(err).Should(HaveOccurred())
+			}
+
+			g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cr), &rbacv1.ClusterRole{})).ShouldNot(Succeed())
+		})
+	}
+}
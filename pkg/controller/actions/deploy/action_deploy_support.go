@@ -1,10 +1,21 @@
 package deploy
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 )
 
 func isLegacyOwnerRef(or metav1.OwnerReference) bool {
@@ -46,3 +57,102 @@ func ownedTypeIs(ownerType *schema.GroupVersionKind) func(or metav1.OwnerReferen
 		return ownerType.Kind == or.Kind && gv == or.APIVersion
 	}
 }
+
+// isDeployedRevision returns true if the current object has been last deployed for
+// the same instance generation and platform version of the given request.
+func isDeployedRevision(rr *odhTypes.ReconciliationRequest, current *unstructured.Unstructured) bool {
+	if current == nil {
+		return false
+	}
+
+	if resources.GetAnnotation(current, annotations.InstanceGeneration) != strconv.FormatInt(rr.Instance.GetGeneration(), 10) {
+		return false
+	}
+
+	return resources.GetAnnotation(current, annotations.PlatformVersion) == rr.Release.Version.String()
+}
+
+// SetPlatformMetadata sets the labels and annotations the resources rendered for
+// the instance of the given kind are deployed with, i.e. to track the generation
+// of the instance and the release they belong to. Custom resource definitions
+// are not bound to a component and are only marked as part of the platform.
+func SetPlatformMetadata(rr *odhTypes.ReconciliationRequest, kind string, obj *unstructured.Unstructured) {
+	if obj.GroupVersionKind() == gvk.CustomResourceDefinition {
+		resources.SetLabel(obj, labels.PlatformPartOf, labels.Platform)
+		return
+	}
+
+	resources.SetAnnotation(obj, annotations.InstanceGeneration, strconv.FormatInt(rr.Instance.GetGeneration(), 10))
+	resources.SetAnnotation(obj, annotations.InstanceName, rr.Instance.GetName())
+	resources.SetAnnotation(obj, annotations.InstanceUID, string(rr.Instance.GetUID()))
+	resources.SetAnnotation(obj, annotations.PlatformType, string(rr.Release.Name))
+	resources.SetAnnotation(obj, annotations.PlatformVersion, rr.Release.Version.String())
+
+	if resources.GetLabel(obj, labels.PlatformPartOf) == "" {
+		resources.SetLabel(obj, labels.PlatformPartOf, strings.ToLower(kind))
+	}
+}
+
+// setDeployedResources records the resources deployed as part of the current
+// reconciliation in the status of the instance, so it matches the set of resources
+// the GC action retains. The degraded map holds, by object reference, the reason
+// why non critical resources failed to deploy, the skipped one the resources not
+// deployed at all, i.e. hooks, resources marked as not managed by the operator or
+// Jobs left untouched because of their re-run policy.
+func setDeployedResources(rr *odhTypes.ReconciliationRequest, degraded map[string]string, skipped map[string]struct{}) {
+	deployed := make([]common.DeployedResource, 0, len(rr.Resources))
+
+	for i := range rr.Resources {
+		ref := resources.FormatObjectReference(&rr.Resources[i])
+		if _, ok := skipped[ref]; ok {
+			continue
+		}
+
+		rgvk := rr.Resources[i].GroupVersionKind()
+		msg, isDegraded := degraded[ref]
+
+		deployed = append(deployed, common.DeployedResource{
+			Group:     rgvk.Group,
+			Version:   rgvk.Version,
+			Kind:      rgvk.Kind,
+			Namespace: rr.Resources[i].GetNamespace(),
+			Name:      rr.Resources[i].GetName(),
+			Degraded:  isDegraded,
+			Message:   msg,
+		})
+	}
+
+	rr.Instance.GetStatus().DeployedResources = deployed
+}
+
+// IsTransientError returns true if the given error is likely to be resolved by
+// retrying the same request, i.e. an admission webhook being temporarily
+// unavailable, an optimistic locking conflict or an API server/etcd timeout.
+// Conflicts with other field managers are not.
+func IsTransientError(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case IsFieldManagerConflict(err):
+		return false
+	case k8serr.IsConflict(err),
+		k8serr.IsServerTimeout(err),
+		k8serr.IsTimeout(err),
+		k8serr.IsTooManyRequests(err),
+		k8serr.IsServiceUnavailable(err),
+		k8serr.IsInternalError(err):
+		return true
+	default:
+		return false
+	}
+}
+
+// setTracking sets the app.kubernetes.io/instance label and the ArgoCD tracking-id
+// annotation, which has the <app>:<group>/<kind>:<namespace>/<name> format.
+func setTracking(obj *unstructured.Unstructured, app string) {
+	objGVK := obj.GroupVersionKind()
+
+	resources.SetLabel(obj, labels.K8SCommon.Instance, app)
+	resources.SetAnnotation(obj, annotations.ArgoCDTrackingID, fmt.Sprintf("%s:%s/%s:%s/%s",
+		app, objGVK.Group, objGVK.Kind, obj.GetNamespace(), obj.GetName()))
+}
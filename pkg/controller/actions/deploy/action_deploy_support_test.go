@@ -6,8 +6,11 @@ import (
 
 	"github.com/onsi/gomega/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 
 	. "github.com/onsi/gomega"
 )
@@ -79,3 +82,27 @@ func TestIsLegacyOwnerRef(t *testing.T) {
 		})
 	}
 }
+
+func TestSetTracking(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	obj := unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk.Deployment)
+	obj.SetNamespace("ns")
+	obj.SetName("foo")
+
+	setTracking(&obj, "dashboard")
+
+	g.Expect(obj.GetLabels()).Should(HaveKeyWithValue(labels.K8SCommon.Instance, "dashboard"))
+	g.Expect(obj.GetAnnotations()).Should(HaveKeyWithValue(annotations.ArgoCDTrackingID, "dashboard:apps/Deployment:ns/foo"))
+
+	cm := unstructured.Unstructured{}
+	cm.SetGroupVersionKind(gvk.ConfigMap)
+	cm.SetName("bar")
+
+	setTracking(&cm, "dashboard")
+
+	g.Expect(cm.GetAnnotations()).Should(HaveKeyWithValue(annotations.ArgoCDTrackingID, "dashboard:/ConfigMap:/bar"))
+}
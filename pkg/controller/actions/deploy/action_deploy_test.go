@@ -103,6 +103,14 @@ func TestDeployAction(t *testing.T) {
 		jq.Match(`.metadata.annotations."%s" == "%s"`, annotations.PlatformVersion, "1.2.3"),
 		jq.Match(`.metadata.annotations."%s" == "%s"`, annotations.PlatformType, string(cluster.OpenDataHub)),
 	))
+
+	g.Expect(rr.Instance.GetStatus().DeployedResources).Should(ConsistOf(common.DeployedResource{
+		Group:     appsv1.SchemeGroupVersion.Group,
+		Version:   appsv1.SchemeGroupVersion.Version,
+		Kind:      "Deployment",
+		Namespace: ns,
+		Name:      obj1.GetName(),
+	}))
 }
 
 func TestDeployNotOwnedSkip(t *testing.T) {
@@ -0,0 +1,58 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// validateResources performs a server side dry-run apply of every resource to
+// be deployed, so that both the schema validation and the detection of unknown
+// fields happen before anything is applied. The failures are collected per
+// resource and returned as a single ValidationError.
+//
+// Resources that can't be validated yet, because they depend on another
+// resource of the same batch (i.e. a namespace or a CRD), are skipped and left
+// to the regular deploy.
+func (a *Action) validateResources(ctx context.Context, rr *odhTypes.ReconciliationRequest, fieldOwner string) error {
+	failures := make([]error, 0)
+
+	for i := range rr.Resources {
+		if resources.GetAnnotation(&rr.Resources[i], annotations.Hook) != "" {
+			continue
+		}
+
+		err := resources.Apply(ctx, rr.Client, rr.Resources[i].DeepCopy(),
+			client.DryRunAll,
+			client.FieldValidation(metav1.FieldValidationStrict),
+			client.ForceOwnership,
+			client.FieldOwner(fieldOwner),
+		)
+
+		switch {
+		case err == nil:
+			continue
+		case k8serr.IsInvalid(err), k8serr.IsBadRequest(err):
+			failures = append(failures, fmt.Errorf("%s: %w", resources.FormatObjectReference(&rr.Resources[i]), err))
+		case k8serr.IsNotFound(err), meta.IsNoMatchError(err):
+			continue
+		default:
+			return fmt.Errorf("failure validating resource %s: %w", resources.FormatObjectReference(&rr.Resources[i]), err)
+		}
+	}
+
+	if len(failures) > 0 {
+		return odherrors.NewValidationError(failures...)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDeployValidation(t *testing.T) {
+	invalid := k8serr.NewInvalid(
+		schema.GroupKind{Kind: "ConfigMap"},
+		"invalid",
+		field.ErrorList{field.Invalid(field.NewPath("data"), "foo", "unknown field")},
+	)
+
+	tests := []struct {
+		name    string
+		err     error
+		matcher func(g *WithT, err error)
+		created bool
+		dryRuns int
+	}{
+		{
+			name: "valid",
+			matcher: func(g *WithT, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			},
+			created: true,
+			dryRuns: 2,
+		},
+		{
+			name: "invalid",
+			err:  invalid,
+			matcher: func(g *WithT, err error) {
+				ve := odherrors.ValidationError{}
+				g.Expect(errors.As(err, &ve)).Should(BeTrue())
+				g.Expect(ve.Failures()).Should(HaveLen(2))
+				g.Expect(k8serr.IsInvalid(err)).Should(BeTrue())
+			},
+			dryRuns: 2,
+		},
+		{
+			name: "not yet validable",
+			err:  k8serr.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "foo"),
+			matcher: func(g *WithT, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			},
+			created: true,
+			dryRuns: 2,
+		},
+		{
+			name: "unexpected failure",
+			err:  k8serr.NewServiceUnavailable("unavailable"),
+			matcher: func(g *WithT, err error) {
+				g.Expect(errors.As(err, &odherrors.ValidationError{})).Should(BeFalse())
+				g.Expect(k8serr.IsServiceUnavailable(err)).Should(BeTrue())
+			},
+			dryRuns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := t.Context()
+			ns := xid.New().String()
+			names := []string{xid.New().String(), xid.New().String()}
+			dryRuns := 0
+
+			cl, err := fakeclient.New(
+				fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						po := client.PatchOptions{}
+						po.ApplyOptions(opts)
+
+						g.Expect(po.DryRun).Should(ConsistOf(metav1.DryRunAll))
+						g.Expect(po.FieldValidation).Should(Equal(metav1.FieldValidationStrict))
+
+						dryRuns++
+
+						return tt.err
+					},
+				}),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newEventsInstance()),
+				fakerequest.WithRelease(newEventsRelease()),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(record.NewFakeRecorder(10)))),
+				fakerequest.WithResources(newEventsConfigMap(names[0], ns, "v1", "1", "1.2.3")),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			u, err := resources.ToUnstructured(newEventsConfigMap(names[1], ns, "v1", "1", "1.2.3"))
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr.Resources = append(rr.Resources, *u)
+
+			err = deploy.NewAction(
+				deploy.WithMode(deploy.ModePatch),
+				deploy.WithValidation(),
+			)(ctx, rr)
+
+			tt.matcher(g, err)
+
+			for _, name := range names {
+				err = cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &corev1.ConfigMap{})
+				if tt.created {
+					g.Expect(err).ShouldNot(HaveOccurred())
+				} else {
+					g.Expect(k8serr.IsNotFound(err)).Should(BeTrue())
+				}
+			}
+
+			g.Expect(dryRuns).Should(Equal(tt.dryRuns))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package errors
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 // StopError is a marker error that thew ComponentController uses
@@ -22,3 +24,150 @@ func NewStopError(format string, args ...any) StopError {
 		fmt.Errorf(format, args...),
 	}
 }
+
+// RenderError is a marker error used to signal that the resources of a
+// component could not be rendered.
+type RenderError struct {
+	reason error
+}
+
+func (e RenderError) Error() string {
+	return e.reason.Error()
+}
+
+func (e RenderError) Unwrap() error {
+	return e.reason
+}
+
+func NewRenderError(reason error) RenderError {
+	return RenderError{reason}
+}
+
+// RetriesExhaustedError is a marker error used to signal that an operation
+// failing with a transient error has been retried until the configured
+// backoff got exhausted.
+type RetriesExhaustedError struct {
+	attempts int
+	reason   error
+}
+
+func (e RetriesExhaustedError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %s", e.attempts, e.reason.Error())
+}
+
+func (e RetriesExhaustedError) Unwrap() error {
+	return e.reason
+}
+
+func (e RetriesExhaustedError) Attempts() int {
+	return e.attempts
+}
+
+func NewRetriesExhaustedError(attempts int, reason error) RetriesExhaustedError {
+	return RetriesExhaustedError{attempts: attempts, reason: reason}
+}
+
+// WaitError is a marker error used by actions to signal that the reconciliation
+// can't proceed until some condition is met (i.e. a Job completes). The remaining
+// actions are skipped and the request is requeued after the given duration,
+// without the reconciliation being reported as failed.
+type WaitError struct {
+	reason       error
+	requeueAfter time.Duration
+}
+
+func (e WaitError) Error() string {
+	return e.reason.Error()
+}
+
+func (e WaitError) Unwrap() error {
+	return e.reason
+}
+
+func (e WaitError) RequeueAfter() time.Duration {
+	return e.requeueAfter
+}
+
+func NewWaitError(requeueAfter time.Duration, format string, args ...any) WaitError {
+	return WaitError{
+		reason:       fmt.Errorf(format, args...),
+		requeueAfter: requeueAfter,
+	}
+}
+
+// ValidationError is a marker error used to signal that some of the rendered
+// resources of a component have been rejected by the API server validation.
+// It holds one error per rejected resource.
+type ValidationError struct {
+	failures []error
+}
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.failures))
+	for _, f := range e.failures {
+		msgs = append(msgs, f.Error())
+	}
+
+	return fmt.Sprintf("%d resources failed validation: %s", len(e.failures), strings.Join(msgs, "; "))
+}
+
+func (e ValidationError) Unwrap() []error {
+	return e.failures
+}
+
+func (e ValidationError) Failures() []error {
+	return e.failures
+}
+
+func NewValidationError(failures ...error) ValidationError {
+	return ValidationError{failures: failures}
+}
+
+// DeployError is a marker error used to signal that some of the resources of
+// a component failed to deploy. It holds one error per failed resource, the
+// other resources are deployed anyway.
+type DeployError struct {
+	failures []error
+}
+
+func (e DeployError) Error() string {
+	msgs := make([]string, 0, len(e.failures))
+	for _, f := range e.failures {
+		msgs = append(msgs, f.Error())
+	}
+
+	return fmt.Sprintf("%d resources failed to deploy: %s", len(e.failures), strings.Join(msgs, "; "))
+}
+
+func (e DeployError) Unwrap() []error {
+	return e.failures
+}
+
+func (e DeployError) Failures() []error {
+	return e.failures
+}
+
+func NewDeployError(failures ...error) DeployError {
+	return DeployError{failures: failures}
+}
+
+// LimitExceededError is a marker error used to signal that the rendered
+// resources of a component exceed the configured limits, i.e. because of a
+// runaway loop in a template.
+type LimitExceededError struct {
+	reason error
+}
+
+func (e LimitExceededError) Error() string {
+	return e.reason.Error()
+}
+
+func (e LimitExceededError) Unwrap() error {
+	return e.reason
+}
+
+func NewLimitExceededError(format string, args ...any) LimitExceededError {
+	return LimitExceededError{
+		fmt.Errorf(format, args...),
+	}
+}
@@ -0,0 +1,147 @@
+package exports
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	// ExportsKey is the key under which the imported values are made available
+	// to templates, i.e. {{ .Exports.dashboard.url }}.
+	ExportsKey = "Exports"
+
+	DefaultRequeueAfter = 10 * time.Second
+)
+
+// ValueFn computes a value exported by a component.
+type ValueFn func(ctx context.Context, rr *types.ReconciliationRequest) (any, error)
+
+// Action computes the values a component exports to the other components, i.e.
+// the name of a generated Secret or the hostname of a Service, and publishes
+// them to the store. Values are published under the name of the component,
+// which defaults to the lowercase kind of the reconciled instance.
+type Action struct {
+	store     *Store
+	component string
+	values    map[string]ValueFn
+}
+
+type ActionOpts func(*Action)
+
+// WithStore sets the store values are published to, defaults to DefaultStore.
+func WithStore(value *Store) ActionOpts {
+	return func(action *Action) {
+		action.store = value
+	}
+}
+
+// WithComponentName sets the name values are published under.
+func WithComponentName(value string) ActionOpts {
+	return func(action *Action) {
+		action.component = value
+	}
+}
+
+// WithValue exports the given static value.
+func WithValue(key string, value any) ActionOpts {
+	return WithValueFn(key, func(context.Context, *types.ReconciliationRequest) (any, error) {
+		return value, nil
+	})
+}
+
+// WithValueFn exports the value computed by the given function.
+func WithValueFn(key string, fn ValueFn) ActionOpts {
+	return func(action *Action) {
+		action.values[key] = fn
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	component, err := componentName(rr, a.component)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]any, len(a.values))
+
+	for k, fn := range a.values {
+		v, err := fn(ctx, rr)
+		if err != nil {
+			return fmt.Errorf("unable to compute exported value %s: %w", k, err)
+		}
+
+		values[k] = v
+	}
+
+	if a.store.Set(component, values) {
+		logf.FromContext(ctx).V(3).Info("exported values updated", "component", component)
+	}
+
+	return nil
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		store:  DefaultStore,
+		values: map[string]ValueFn{},
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
+
+// DataFn returns a function, meant to be used with the template.WithDataFn
+// option, that makes the values exported by the given components available
+// to templates under the ExportsKey key. A WaitError is returned while any of
+// the components has not exported its values yet.
+//
+// As imported values are not part of the render cache key, templates reading
+// values that may change over time should be rendered with the cache disabled.
+func DataFn(store *Store, components ...string) func(context.Context, *types.ReconciliationRequest) (map[string]any, error) {
+	return func(_ context.Context, _ *types.ReconciliationRequest) (map[string]any, error) {
+		imported := make(map[string]any, len(components))
+		missing := make([]string, 0)
+
+		for _, c := range components {
+			values, ok := store.Get(c)
+			if !ok {
+				missing = append(missing, c)
+				continue
+			}
+
+			imported[c] = values
+		}
+
+		if len(missing) > 0 {
+			return nil, odherrors.NewWaitError(DefaultRequeueAfter,
+				"waiting for values exported by %s", strings.Join(missing, ", "))
+		}
+
+		return map[string]any{ExportsKey: imported}, nil
+	}
+}
+
+func componentName(rr *types.ReconciliationRequest, name string) (string, error) {
+	if name != "" {
+		return name, nil
+	}
+
+	kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(kind), nil
+}
@@ -0,0 +1,90 @@
+package exports_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/exports"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/template"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+const exportingTemplate = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: consumer
+  namespace: ns
+data:
+  url: {{ .Exports.dashboard.url }}
+`
+
+func TestExports(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	store := exports.NewStore()
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(&componentApi.Dashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+		}),
+		fakerequest.WithDSCI(&dsciv2.DSCInitialization{}),
+		fakerequest.WithTemplates(types.TemplateInfo{
+			FS:   fstest.MapFS{"consumer.tmpl.yaml": &fstest.MapFile{Data: []byte(exportingTemplate)}},
+			Path: "consumer.tmpl.yaml",
+		}),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	render := template.NewAction(
+		template.WithCache(false),
+		template.WithDataFn(exports.DataFn(store, "dashboard")),
+	)
+
+	// the consumer waits until the values are exported
+	err = render(ctx, rr)
+	g.Expect(errors.As(err, &odherrors.WaitError{})).Should(BeTrue())
+	g.Expect(err).Should(MatchError(ContainSubstring("waiting for values exported by dashboard")))
+
+	err = exports.NewAction(
+		exports.WithStore(store),
+		exports.WithValueFn("url", func(context.Context, *types.ReconciliationRequest) (any, error) {
+			return "https://dashboard.example.com", nil
+		}),
+	)(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	values, ok := store.Get("dashboard")
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(values).Should(HaveKeyWithValue("url", "https://dashboard.example.com"))
+
+	err = render(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.Resources).Should(HaveLen(1))
+	g.Expect(rr.Resources[0].Object).Should(HaveKeyWithValue("data", HaveKeyWithValue("url", "https://dashboard.example.com")))
+}
+
+func TestStore(t *testing.T) {
+	g := NewWithT(t)
+
+	store := exports.NewStore()
+
+	g.Expect(store.Set("foo", map[string]any{"a": "b"})).Should(BeTrue())
+	g.Expect(store.Set("foo", map[string]any{"a": "b"})).Should(BeFalse())
+	g.Expect(store.Set("foo", map[string]any{"a": "c"})).Should(BeTrue())
+
+	store.Delete("foo")
+
+	_, ok := store.Get("foo")
+	g.Expect(ok).Should(BeFalse())
+}
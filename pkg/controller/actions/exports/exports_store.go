@@ -0,0 +1,61 @@
+package exports
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+)
+
+// DefaultStore is the store shared by all the components of the operator.
+var DefaultStore = NewStore()
+
+// Store holds the values exported by each component, so that they can be read
+// by the other components. It is safe for concurrent use.
+type Store struct {
+	lock   sync.RWMutex
+	values map[string]map[string]any
+}
+
+func NewStore() *Store {
+	return &Store{
+		values: map[string]map[string]any{},
+	}
+}
+
+// Set replaces the values exported by the given component and returns whether
+// they have changed.
+func (s *Store) Set(component string, values map[string]any) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if current, ok := s.values[component]; ok && reflect.DeepEqual(current, values) {
+		return false
+	}
+
+	s.values[component] = maps.Clone(values)
+
+	return true
+}
+
+// Get returns a copy of the values exported by the given component, and false
+// if the component has not exported any value yet.
+func (s *Store) Get(component string) (map[string]any, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	values, ok := s.values[component]
+	if !ok {
+		return nil, false
+	}
+
+	return maps.Clone(values), true
+}
+
+// Delete removes the values exported by the given component, i.e. when it is
+// removed.
+func (s *Store) Delete(component string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.values, component)
+}
@@ -0,0 +1,318 @@
+package expose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+type Mode string
+
+const (
+	// ModeAuto exposes the Service with a Route when the cluster serves Routes,
+	// and with an Ingress otherwise.
+	ModeAuto    Mode = "auto"
+	ModeRoute   Mode = "route"
+	ModeIngress Mode = "ingress"
+	// ModeGateway exposes the Service with a Gateway API HTTPRoute attached to
+	// the platform gateway.
+	ModeGateway Mode = "gateway"
+)
+
+const (
+	DefaultGatewayNamespace = "openshift-ingress"
+	DefaultGatewayName      = "data-science-gateway"
+	DefaultPath             = "/"
+	DefaultRequeueAfter     = 30 * time.Second
+)
+
+// URLSetter is implemented by the instances reporting the URL they are exposed
+// at in their status.
+type URLSetter interface {
+	SetURL(value string)
+}
+
+// IngressConfig holds the settings of the generated Ingress.
+type IngressConfig struct {
+	ClassName     string
+	Host          string
+	TLSSecretName string
+}
+
+// IngressConfigurer is implemented by the instances letting users configure
+// the generated Ingress, the non-empty fields of the returned config take
+// precedence over the options of the action.
+type IngressConfigurer interface {
+	GetIngressConfig() IngressConfig
+}
+
+// Action exposes a Service of a component outside the cluster by generating
+// an OpenShift Route with TLS termination or, on clusters not serving Routes,
+// an Ingress. Alternatively, the Service can be attached to the platform gateway
+// with a Gateway API HTTPRoute. The generated resource is appended to the
+// rendered resources, so the action must be placed between the render and the
+// deploy actions.
+//
+// Once the resource is admitted, its URL is reported in the status of instances
+// implementing URLSetter; as the host is only known after the resource has been
+// deployed, the component should own it so that admission triggers a new
+// reconciliation.
+//
+// In ModeGateway, the HTTPRoute CRD may be installed after the operator has
+// started, the component should then own HTTPRoutes with a deferred watch:
+//
+//	OwnsGVK(gvk.HTTPRoute, reconciler.Dynamic(reconciler.CrdExists(gvk.HTTPRoute)))
+//
+// and the action waits for the CRD to be installed.
+type Action struct {
+	service     string
+	name        string
+	port        string
+	host        string
+	mode        Mode
+	termination routev1.TLSTerminationType
+	ingress     IngressConfig
+	gateway     client.ObjectKey
+	path        string
+}
+
+type ActionOpts func(*Action)
+
+// WithName sets the name of the generated resources, defaults to the name of
+// the Service.
+func WithName(value string) ActionOpts {
+	return func(action *Action) {
+		action.name = value
+	}
+}
+
+// WithPort sets the name of the Service port traffic is routed to, defaults to
+// the first port of the Service.
+func WithPort(value string) ActionOpts {
+	return func(action *Action) {
+		action.port = value
+	}
+}
+
+// WithHost sets the host the Service is exposed at, defaults to a host
+// generated from the cluster domain.
+func WithHost(value string) ActionOpts {
+	return func(action *Action) {
+		action.host = value
+	}
+}
+
+// WithMode sets how the Service is exposed, defaults to ModeAuto.
+func WithMode(value Mode) ActionOpts {
+	return func(action *Action) {
+		action.mode = value
+	}
+}
+
+// WithIngressClass sets the class of the generated Ingress.
+func WithIngressClass(value string) ActionOpts {
+	return func(action *Action) {
+		action.ingress.ClassName = value
+	}
+}
+
+// WithIngressTLSSecret sets the name of the Secret holding the certificate of
+// the generated Ingress, TLS is not configured if not set.
+func WithIngressTLSSecret(value string) ActionOpts {
+	return func(action *Action) {
+		action.ingress.TLSSecretName = value
+	}
+}
+
+// WithGateway sets the gateway the HTTPRoute is attached to, defaults to the
+// platform gateway.
+func WithGateway(namespace string, name string) ActionOpts {
+	return func(action *Action) {
+		action.gateway = client.ObjectKey{Namespace: namespace, Name: name}
+	}
+}
+
+// WithPath sets the path prefix the Service is exposed at on the gateway,
+// defaults to "/".
+func WithPath(value string) ActionOpts {
+	return func(action *Action) {
+		action.path = value
+	}
+}
+
+// WithTLSTermination sets the TLS termination of the Route, defaults to edge.
+func WithTLSTermination(value routev1.TLSTerminationType) ActionOpts {
+	return func(action *Action) {
+		action.termination = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	if rr.DSCI == nil {
+		return errors.New("unable to expose service: DSCInitialization is not set")
+	}
+
+	ns := rr.DSCI.Spec.ApplicationsNamespace
+
+	name := a.name
+	if name == "" {
+		name = a.service
+	}
+
+	mode := a.mode
+	if mode == ModeAuto {
+		hasRoutes, err := cluster.HasAPI(rr.Client, gvk.Route)
+		if err != nil {
+			return fmt.Errorf("unable to detect whether routes are available: %w", err)
+		}
+
+		mode = ModeIngress
+		if hasRoutes {
+			mode = ModeRoute
+		}
+	}
+
+	var url string
+
+	switch mode {
+	case ModeRoute:
+		if err := rr.AddResources(a.route(ns, name)); err != nil {
+			return fmt.Errorf("unable to add route %s: %w", name, err)
+		}
+
+		u, err := a.routeURL(ctx, rr.Client, ns, name)
+		if err != nil {
+			return err
+		}
+
+		url = u
+	case ModeIngress:
+		cfg := a.ingressConfig(rr)
+
+		in, err := a.ingressResource(rr, cfg, ns, name)
+		if err != nil {
+			return err
+		}
+
+		if err := rr.AddResources(in); err != nil {
+			return fmt.Errorf("unable to add ingress %s: %w", name, err)
+		}
+
+		u, err := a.ingressURL(ctx, rr.Client, cfg, ns, name)
+		if err != nil {
+			return err
+		}
+
+		url = u
+	case ModeGateway:
+		route, err := a.httpRoute(rr, ns, name)
+		if err != nil {
+			return err
+		}
+
+		if err := rr.AddResources(route); err != nil {
+			return fmt.Errorf("unable to add http route %s: %w", name, err)
+		}
+
+		u, err := a.httpRouteURL(ctx, rr.Client, ns, name)
+		if err != nil {
+			return err
+		}
+
+		url = u
+	default:
+		return fmt.Errorf("unsupported expose mode %s", mode)
+	}
+
+	if s, ok := rr.Instance.(URLSetter); ok {
+		s.SetURL(url)
+	}
+
+	return nil
+}
+
+func (a *Action) route(ns string, name string) *routev1.Route {
+	route := routev1.Route{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: routev1.GroupVersion.String(),
+			Kind:       "Route",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: routev1.RouteSpec{
+			Host: a.host,
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: a.service,
+			},
+			TLS: &routev1.TLSConfig{
+				Termination:                   a.termination,
+				InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+			},
+		},
+	}
+
+	if a.port != "" {
+		route.Spec.Port = &routev1.RoutePort{
+			TargetPort: intstr.FromString(a.port),
+		}
+	}
+
+	return &route
+}
+
+// routeURL returns the URL of the deployed Route, or an empty string if it has
+// not been deployed or admitted yet.
+func (a *Action) routeURL(ctx context.Context, cli client.Client, ns string, name string) (string, error) {
+	route := routev1.Route{}
+
+	err := cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &route)
+	switch {
+	case k8serr.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("unable to get route %s: %w", name, err)
+	}
+
+	host := resources.IngressHost(route)
+	if host == "" {
+		return "", nil
+	}
+
+	return "https://" + host, nil
+}
+
+// NewAction creates an action exposing the given Service.
+func NewAction(service string, opts ...ActionOpts) actions.Fn {
+	action := Action{
+		service:     service,
+		mode:        ModeAuto,
+		termination: routev1.TLSTerminationEdge,
+		gateway: client.ObjectKey{
+			Namespace: DefaultGatewayNamespace,
+			Name:      DefaultGatewayName,
+		},
+		path: DefaultPath,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
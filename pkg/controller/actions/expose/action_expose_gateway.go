@@ -0,0 +1,159 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+// httpRoute returns the HTTPRoute attaching the Service to the gateway, or a
+// WaitError while the HTTPRoute CRD is not installed on the cluster.
+func (a *Action) httpRoute(rr *types.ReconciliationRequest, ns string, name string) (*gwapiv1.HTTPRoute, error) {
+	hasRoutes, err := cluster.HasAPI(rr.Client, gvk.HTTPRoute)
+	if err != nil {
+		return nil, fmt.Errorf("unable to detect whether http routes are available: %w", err)
+	}
+
+	if !hasRoutes {
+		return nil, odherrors.NewWaitError(DefaultRequeueAfter, "waiting for the %s CRD to be installed", gvk.HTTPRoute.Kind)
+	}
+
+	port, err := a.gatewayPort(rr)
+	if err != nil {
+		return nil, err
+	}
+
+	route := gwapiv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gwapiv1.GroupVersion.String(),
+			Kind:       gvk.HTTPRoute.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: gwapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gwapiv1.CommonRouteSpec{
+				ParentRefs: []gwapiv1.ParentReference{{
+					Name:      gwapiv1.ObjectName(a.gateway.Name),
+					Namespace: ptr.To(gwapiv1.Namespace(a.gateway.Namespace)),
+				}},
+			},
+			Rules: []gwapiv1.HTTPRouteRule{{
+				Matches: []gwapiv1.HTTPRouteMatch{{
+					Path: &gwapiv1.HTTPPathMatch{
+						Type:  ptr.To(gwapiv1.PathMatchPathPrefix),
+						Value: ptr.To(a.path),
+					},
+				}},
+				BackendRefs: []gwapiv1.HTTPBackendRef{{
+					BackendRef: gwapiv1.BackendRef{
+						BackendObjectReference: gwapiv1.BackendObjectReference{
+							Name: gwapiv1.ObjectName(a.service),
+							Port: ptr.To(gwapiv1.PortNumber(port)),
+						},
+					},
+				}},
+			}},
+		},
+	}
+
+	if a.host != "" {
+		route.Spec.Hostnames = []gwapiv1.Hostname{gwapiv1.Hostname(a.host)}
+	}
+
+	return &route, nil
+}
+
+// gatewayPort returns the number of the Service port traffic is routed to, as
+// backends of an HTTPRoute can't reference ports by name.
+func (a *Action) gatewayPort(rr *types.ReconciliationRequest) (int32, error) {
+	svc, err := a.renderedService(rr)
+	if err != nil {
+		return 0, err
+	}
+
+	if svc != nil {
+		for _, p := range svc.Spec.Ports {
+			if a.port == "" || p.Name == a.port {
+				return p.Port, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unable to find a port of service %s, set it explicitly", a.service)
+}
+
+// httpRouteURL returns the URL of the deployed HTTPRoute, or an empty string if
+// it has not been deployed or accepted by the gateway yet. Unless a host is set,
+// the host is the one of the gateway listeners.
+func (a *Action) httpRouteURL(ctx context.Context, cli client.Client, ns string, name string) (string, error) {
+	route := gwapiv1.HTTPRoute{}
+
+	err := cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &route)
+	switch {
+	case k8serr.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("unable to get http route %s: %w", name, err)
+	}
+
+	if !a.accepted(&route) {
+		return "", nil
+	}
+
+	host := a.host
+	if host == "" {
+		gw := gwapiv1.Gateway{}
+
+		err := cli.Get(ctx, a.gateway, &gw)
+		switch {
+		case k8serr.IsNotFound(err):
+			return "", nil
+		case err != nil:
+			return "", fmt.Errorf("unable to get gateway %s: %w", a.gateway.Name, err)
+		}
+
+		for _, l := range gw.Spec.Listeners {
+			if l.Hostname != nil && !strings.HasPrefix(string(*l.Hostname), "*") {
+				host = string(*l.Hostname)
+				break
+			}
+		}
+	}
+
+	if host == "" {
+		return "", nil
+	}
+
+	return "https://" + host + strings.TrimSuffix(a.path, "/"), nil
+}
+
+// accepted returns true if the gateway the HTTPRoute is attached to has
+// accepted it.
+func (a *Action) accepted(route *gwapiv1.HTTPRoute) bool {
+	for _, p := range route.Status.Parents {
+		if string(p.ParentRef.Name) != a.gateway.Name {
+			continue
+		}
+		if p.ParentRef.Namespace != nil && string(*p.ParentRef.Namespace) != a.gateway.Namespace {
+			continue
+		}
+
+		return meta.IsStatusConditionTrue(p.Conditions, string(gwapiv1.RouteConditionAccepted))
+	}
+
+	return false
+}
@@ -0,0 +1,162 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+func (a *Action) ingressConfig(rr *types.ReconciliationRequest) IngressConfig {
+	cfg := a.ingress
+	cfg.Host = a.host
+
+	c, ok := rr.Instance.(IngressConfigurer)
+	if !ok {
+		return cfg
+	}
+
+	override := c.GetIngressConfig()
+	if override.ClassName != "" {
+		cfg.ClassName = override.ClassName
+	}
+	if override.Host != "" {
+		cfg.Host = override.Host
+	}
+	if override.TLSSecretName != "" {
+		cfg.TLSSecretName = override.TLSSecretName
+	}
+
+	return cfg
+}
+
+func (a *Action) ingressResource(rr *types.ReconciliationRequest, cfg IngressConfig, ns string, name string) (*networkingv1.Ingress, error) {
+	port, err := a.servicePort(rr)
+	if err != nil {
+		return nil, err
+	}
+
+	in := networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+			Kind:       "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				Host: cfg.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: ptr.To(networkingv1.PathTypePrefix),
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: a.service,
+									Port: port,
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+
+	if cfg.ClassName != "" {
+		in.Spec.IngressClassName = ptr.To(cfg.ClassName)
+	}
+
+	if cfg.TLSSecretName != "" {
+		tls := networkingv1.IngressTLS{SecretName: cfg.TLSSecretName}
+		if cfg.Host != "" {
+			tls.Hosts = []string{cfg.Host}
+		}
+
+		in.Spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+
+	return &in, nil
+}
+
+// servicePort returns the backend port of the Ingress: the port set with the
+// WithPort option or the first port of the rendered Service.
+func (a *Action) servicePort(rr *types.ReconciliationRequest) (networkingv1.ServiceBackendPort, error) {
+	if a.port != "" {
+		return networkingv1.ServiceBackendPort{Name: a.port}, nil
+	}
+
+	svc, err := a.renderedService(rr)
+	if err != nil {
+		return networkingv1.ServiceBackendPort{}, err
+	}
+
+	if svc != nil && len(svc.Spec.Ports) > 0 {
+		return networkingv1.ServiceBackendPort{Number: svc.Spec.Ports[0].Port}, nil
+	}
+
+	return networkingv1.ServiceBackendPort{}, fmt.Errorf("unable to find a port of service %s, set it explicitly", a.service)
+}
+
+// ingressURL returns the URL of the deployed Ingress, or an empty string if it
+// has not been deployed or assigned an address yet.
+func (a *Action) ingressURL(ctx context.Context, cli client.Client, cfg IngressConfig, ns string, name string) (string, error) {
+	in := networkingv1.Ingress{}
+
+	err := cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &in)
+	switch {
+	case k8serr.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("unable to get ingress %s: %w", name, err)
+	}
+
+	if len(in.Status.LoadBalancer.Ingress) == 0 {
+		return "", nil
+	}
+
+	host := cfg.Host
+	if host == "" {
+		host = in.Status.LoadBalancer.Ingress[0].Hostname
+	}
+	if host == "" {
+		host = in.Status.LoadBalancer.Ingress[0].IP
+	}
+
+	if cfg.TLSSecretName == "" {
+		return "http://" + host, nil
+	}
+
+	return "https://" + host, nil
+}
+
+// renderedService returns the rendered Service being exposed, or nil if it is
+// not part of the rendered resources.
+func (a *Action) renderedService(rr *types.ReconciliationRequest) (*corev1.Service, error) {
+	for i := range rr.Resources {
+		if rr.Resources[i].GroupVersionKind() != gvk.Service || rr.Resources[i].GetName() != a.service {
+			continue
+		}
+
+		svc := corev1.Service{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rr.Resources[i].Object, &svc); err != nil {
+			return nil, fmt.Errorf("unable to decode service %s: %w", a.service, err)
+		}
+
+		return &svc, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,308 @@
+package expose_test
+
+import (
+	"errors"
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/expose"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	exposeNamespace = "opendatahub"
+	exposeService   = "ui"
+)
+
+type exposedDashboard struct {
+	*componentApi.Dashboard
+
+	url string
+}
+
+func (d *exposedDashboard) SetURL(value string) {
+	d.url = value
+}
+
+type configuredDashboard struct {
+	*exposedDashboard
+}
+
+func (d *configuredDashboard) GetIngressConfig() expose.IngressConfig {
+	return expose.IngressConfig{
+		Host:          "ui.example.com",
+		TLSSecretName: "ui-tls",
+	}
+}
+
+func newInstance() *exposedDashboard {
+	return &exposedDashboard{Dashboard: &componentApi.Dashboard{}}
+}
+
+func newDSCI() *dsciv2.DSCInitialization {
+	return &dsciv2.DSCInitialization{
+		Spec: dsciv2.DSCInitializationSpec{
+			ApplicationsNamespace: exposeNamespace,
+		},
+	}
+}
+
+func newService() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      exposeService,
+			Namespace: exposeNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+}
+
+func TestExposeRoute(t *testing.T) {
+	g := NewWithT(t)
+
+	instance := newInstance()
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(instance),
+		fakerequest.WithDSCI(newDSCI()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = expose.NewAction(exposeService, expose.WithName("ui-route"), expose.WithPort("https"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(instance.url).Should(BeEmpty())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		HaveEach(And(
+			jq.Match(`.kind == "Route"`),
+			jq.Match(`.metadata.name == "ui-route"`),
+			jq.Match(`.metadata.namespace == "%s"`, exposeNamespace),
+			jq.Match(`.spec.to.name == "%s"`, exposeService),
+			jq.Match(`.spec.port.targetPort == "https"`),
+			jq.Match(`.spec.tls.termination == "edge"`),
+			jq.Match(`.spec.tls.insecureEdgeTerminationPolicy == "Redirect"`),
+		)),
+	))
+}
+
+func TestExposeRouteURL(t *testing.T) {
+	g := NewWithT(t)
+
+	live := routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      exposeService,
+			Namespace: exposeNamespace,
+		},
+		Status: routev1.RouteStatus{
+			Ingress: []routev1.RouteIngress{{
+				Host: "ui.apps.example.com",
+				Conditions: []routev1.RouteIngressCondition{{
+					Type:   routev1.RouteAdmitted,
+					Status: corev1.ConditionTrue,
+				}},
+			}},
+		},
+	}
+
+	instance := newInstance()
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(instance),
+		fakerequest.WithDSCI(newDSCI()),
+		fakerequest.WithClientOpts(fakeclient.WithObjects(&live)),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = expose.NewAction(exposeService)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(instance.url).Should(Equal("https://ui.apps.example.com"))
+}
+
+func TestExposeIngressWithoutRoutes(t *testing.T) {
+	g := NewWithT(t)
+
+	// a scheme without the OpenShift types, as on vanilla Kubernetes
+	s := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(s)).Should(Succeed())
+	g.Expect(componentApi.AddToScheme(s)).Should(Succeed())
+
+	live := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      exposeService,
+			Namespace: exposeNamespace,
+		},
+		Status: networkingv1.IngressStatus{
+			LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+				Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "10.0.0.1"}},
+			},
+		},
+	}
+
+	instance := newInstance()
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(instance),
+		fakerequest.WithDSCI(newDSCI()),
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s), fakeclient.WithObjects(&live)),
+		fakerequest.WithResources(newService()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = expose.NewAction(exposeService, expose.WithIngressClass("nginx"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(instance.url).Should(Equal("http://10.0.0.1"))
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(2),
+		ContainElement(And(
+			jq.Match(`.kind == "Ingress"`),
+			jq.Match(`.spec.ingressClassName == "nginx"`),
+			jq.Match(`.spec.rules[0].http.paths[0].backend.service.name == "%s"`, exposeService),
+			jq.Match(`.spec.rules[0].http.paths[0].backend.service.port.number == 8080`),
+			jq.Match(`.spec | has("tls") | not`),
+		)),
+	))
+}
+
+func TestExposeIngressConfiguredByInstance(t *testing.T) {
+	g := NewWithT(t)
+
+	instance := &configuredDashboard{exposedDashboard: newInstance()}
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(instance),
+		fakerequest.WithDSCI(newDSCI()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = expose.NewAction(exposeService, expose.WithMode(expose.ModeIngress), expose.WithPort("http"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		HaveEach(And(
+			jq.Match(`.kind == "Ingress"`),
+			jq.Match(`.spec.rules[0].host == "ui.example.com"`),
+			jq.Match(`.spec.rules[0].http.paths[0].backend.service.port.name == "http"`),
+			jq.Match(`.spec.tls[0].secretName == "ui-tls"`),
+			jq.Match(`.spec.tls[0].hosts[0] == "ui.example.com"`),
+		)),
+	))
+}
+
+func TestExposeIngressMissingPort(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(newInstance()),
+		fakerequest.WithDSCI(newDSCI()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = expose.NewAction(exposeService, expose.WithMode(expose.ModeIngress))(t.Context(), rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to find a port of service")))
+}
+
+func TestExposeGateway(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(gwapiv1.Install(s)).Should(Succeed())
+
+	gw := gwapiv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      expose.DefaultGatewayName,
+			Namespace: expose.DefaultGatewayNamespace,
+		},
+		Spec: gwapiv1.GatewaySpec{
+			Listeners: []gwapiv1.Listener{{
+				Name:     "https",
+				Hostname: ptr.To(gwapiv1.Hostname("data-science-gateway.apps.example.com")),
+			}},
+		},
+	}
+
+	live := gwapiv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      exposeService,
+			Namespace: exposeNamespace,
+		},
+		Status: gwapiv1.HTTPRouteStatus{
+			RouteStatus: gwapiv1.RouteStatus{
+				Parents: []gwapiv1.RouteParentStatus{{
+					ParentRef: gwapiv1.ParentReference{
+						Name:      expose.DefaultGatewayName,
+						Namespace: ptr.To(gwapiv1.Namespace(expose.DefaultGatewayNamespace)),
+					},
+					Conditions: []metav1.Condition{{
+						Type:   string(gwapiv1.RouteConditionAccepted),
+						Status: metav1.ConditionTrue,
+					}},
+				}},
+			},
+		},
+	}
+
+	instance := newInstance()
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(instance),
+		fakerequest.WithDSCI(newDSCI()),
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s), fakeclient.WithObjects(&gw, &live)),
+		fakerequest.WithResources(newService()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = expose.NewAction(exposeService,
+		expose.WithMode(expose.ModeGateway),
+		expose.WithPort("http"),
+		expose.WithPath("/ui/"),
+	)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(instance.url).Should(Equal("https://data-science-gateway.apps.example.com/ui"))
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(2),
+		ContainElement(And(
+			jq.Match(`.kind == "HTTPRoute"`),
+			jq.Match(`.metadata.namespace == "%s"`, exposeNamespace),
+			jq.Match(`.spec.parentRefs[0].name == "%s"`, expose.DefaultGatewayName),
+			jq.Match(`.spec.parentRefs[0].namespace == "%s"`, expose.DefaultGatewayNamespace),
+			jq.Match(`.spec.rules[0].matches[0].path.value == "/ui/"`),
+			jq.Match(`.spec.rules[0].backendRefs[0].name == "%s"`, exposeService),
+			jq.Match(`.spec.rules[0].backendRefs[0].port == 8080`),
+			jq.Match(`.spec | has("hostnames") | not`),
+		)),
+	))
+}
+
+func TestExposeGatewayWithoutCRD(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(newInstance()),
+		fakerequest.WithDSCI(newDSCI()),
+		fakerequest.WithResources(newService()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = expose.NewAction(exposeService, expose.WithMode(expose.ModeGateway))(t.Context(), rr)
+	g.Expect(errors.As(err, &odherrors.WaitError{})).Should(BeTrue())
+	g.Expect(rr.Resources).Should(HaveLen(1))
+}
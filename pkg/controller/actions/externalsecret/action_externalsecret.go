@@ -0,0 +1,177 @@
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	// DefaultStoreKind is the kind of the store referenced by name only.
+	DefaultStoreKind = "SecretStore"
+
+	// DefaultRefreshInterval is how often the External Secrets Operator syncs the
+	// Secrets from the store.
+	DefaultRefreshInterval = 1 * time.Hour
+)
+
+// StoreRef references the store the External Secrets Operator reads secrets from.
+type StoreRef struct {
+	Kind string
+	Name string
+}
+
+// Action replaces the rendered Secrets annotated with annotations.ExternalSecretKey
+// with ExternalSecret resources, so that their content is provisioned by the
+// External Secrets Operator from a secret store instead of being part of the
+// manifests. If the ExternalSecret CRD is not installed, the Secrets are deployed
+// as rendered. It must be placed between the render and the deploy actions, and
+// the reconciler is expected to watch the ExternalSecrets dynamically:
+//
+//	OwnsGVK(gvk.ExternalSecret, reconciler.Dynamic(reconciler.CrdExists(gvk.ExternalSecret)))
+type Action struct {
+	store           StoreRef
+	refreshInterval time.Duration
+}
+
+type ActionOpts func(*Action)
+
+// WithSecretStore sets the store used for the Secrets which do not select one
+// with the annotations.ExternalSecretStore annotation.
+func WithSecretStore(kind string, name string) ActionOpts {
+	return func(action *Action) {
+		action.store = StoreRef{Kind: kind, Name: name}
+	}
+}
+
+// WithRefreshInterval sets how often the Secrets are synced from the store,
+// defaults to DefaultRefreshInterval.
+func WithRefreshInterval(value time.Duration) ActionOpts {
+	return func(action *Action) {
+		action.refreshInterval = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	var installed *bool
+
+	res := make([]unstructured.Unstructured, 0, len(rr.Resources))
+
+	for i := range rr.Resources {
+		obj := rr.Resources[i]
+
+		key := resources.GetAnnotation(&obj, annotations.ExternalSecretKey)
+		if obj.GroupVersionKind() != gvk.Secret || key == "" {
+			res = append(res, obj)
+			continue
+		}
+
+		if installed == nil {
+			ok, err := cluster.HasAPI(rr.Client, gvk.ExternalSecret)
+			if err != nil {
+				return fmt.Errorf("unable to detect whether the External Secrets Operator is available: %w", err)
+			}
+
+			installed = &ok
+		}
+
+		if !*installed {
+			logf.FromContext(ctx).V(3).Info("ExternalSecret CRD not found, deploying the rendered secret",
+				"secret", resources.FormatObjectReference(&obj))
+
+			res = append(res, obj)
+			continue
+		}
+
+		es, err := a.externalSecret(&obj, key)
+		if err != nil {
+			return fmt.Errorf("unable to provision secret %s externally: %w",
+				resources.FormatObjectReference(&obj), err)
+		}
+
+		res = append(res, *es)
+	}
+
+	rr.Resources = res
+
+	return nil
+}
+
+// externalSecret returns the ExternalSecret provisioning the given Secret from
+// the given key of the store.
+func (a *Action) externalSecret(secret *unstructured.Unstructured, key string) (*unstructured.Unstructured, error) {
+	store := a.store
+
+	if ref := resources.GetAnnotation(secret, annotations.ExternalSecretStore); ref != "" {
+		kind, name, found := strings.Cut(ref, "/")
+		if !found {
+			kind, name = DefaultStoreKind, ref
+		}
+
+		store = StoreRef{Kind: kind, Name: name}
+	}
+
+	if store.Name == "" {
+		return nil, errors.New("no secret store configured")
+	}
+	if store.Kind == "" {
+		store.Kind = DefaultStoreKind
+	}
+
+	target := map[string]any{
+		"name":           secret.GetName(),
+		"creationPolicy": "Owner",
+	}
+
+	if t, ok, _ := unstructured.NestedString(secret.Object, "type"); ok && t != "" {
+		target["template"] = map[string]any{
+			"type": t,
+		}
+	}
+
+	es := resources.GvkToUnstructured(gvk.ExternalSecret)
+	es.SetName(secret.GetName())
+	es.SetNamespace(secret.GetNamespace())
+	es.SetLabels(secret.GetLabels())
+	es.Object["spec"] = map[string]any{
+		"refreshInterval": a.refreshInterval.String(),
+		"secretStoreRef": map[string]any{
+			"kind": store.Kind,
+			"name": store.Name,
+		},
+		"target": target,
+		"dataFrom": []any{
+			map[string]any{
+				"extract": map[string]any{
+					"key": key,
+				},
+			},
+		},
+	}
+
+	return es, nil
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		refreshInterval: DefaultRefreshInterval,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
@@ -0,0 +1,136 @@
+package externalsecret_test
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/externalsecret"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+func newResources(secretAnnotations map[string]string) []client.Object {
+	return []client.Object{
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "db-credentials",
+				Namespace:   "opendatahub",
+				Annotations: secretAnnotations,
+			},
+			Type:       corev1.SecretTypeBasicAuth,
+			StringData: map[string]string{"password": "changeme"},
+		},
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "opendatahub"},
+		},
+	}
+}
+
+func TestExternalSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	s.AddKnownTypeWithName(gvk.ExternalSecret, &unstructured.Unstructured{})
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s)),
+		fakerequest.WithResources(newResources(map[string]string{
+			annotations.ExternalSecretKey: "platform/db",
+		})...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = externalsecret.NewAction(
+		externalsecret.WithSecretStore("ClusterSecretStore", "vault"),
+	)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(2),
+		ContainElement(jq.Match(`.kind == "ConfigMap"`)),
+		Not(ContainElement(jq.Match(`.kind == "Secret"`))),
+		ContainElement(And(
+			jq.Match(`.kind == "ExternalSecret"`),
+			jq.Match(`.metadata.name == "db-credentials"`),
+			jq.Match(`.metadata.namespace == "opendatahub"`),
+			jq.Match(`.spec.secretStoreRef.kind == "ClusterSecretStore"`),
+			jq.Match(`.spec.secretStoreRef.name == "vault"`),
+			jq.Match(`.spec.target.name == "db-credentials"`),
+			jq.Match(`.spec.target.template.type == "%s"`, corev1.SecretTypeBasicAuth),
+			jq.Match(`.spec.dataFrom[0].extract.key == "platform/db"`),
+		)),
+	))
+}
+
+func TestExternalSecretStoreAnnotation(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	s.AddKnownTypeWithName(gvk.ExternalSecret, &unstructured.Unstructured{})
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s)),
+		fakerequest.WithResources(newResources(map[string]string{
+			annotations.ExternalSecretKey:   "platform/db",
+			annotations.ExternalSecretStore: "local",
+		})...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = externalsecret.NewAction()(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(ContainElement(And(
+		jq.Match(`.kind == "ExternalSecret"`),
+		jq.Match(`.spec.secretStoreRef.kind == "%s"`, externalsecret.DefaultStoreKind),
+		jq.Match(`.spec.secretStoreRef.name == "local"`),
+	)))
+
+	// without a store, the secret cannot be provisioned
+	rr, err = fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s)),
+		fakerequest.WithResources(newResources(map[string]string{
+			annotations.ExternalSecretKey: "platform/db",
+		})...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = externalsecret.NewAction()(t.Context(), rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("no secret store configured")))
+}
+
+func TestExternalSecretNotInstalled(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newResources(map[string]string{
+			annotations.ExternalSecretKey: "platform/db",
+		})...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = externalsecret.NewAction(
+		externalsecret.WithSecretStore("ClusterSecretStore", "vault"),
+	)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(2),
+		ContainElement(jq.Match(`.kind == "Secret"`)),
+		Not(ContainElement(jq.Match(`.kind == "ExternalSecret"`))),
+	))
+}
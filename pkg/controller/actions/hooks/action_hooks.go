@@ -0,0 +1,195 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+type Phase string
+
+const (
+	// PreDeploy hooks are run before the resources of the component are deployed,
+	// i.e. to perform database schema migrations.
+	PreDeploy Phase = "pre-deploy"
+	// PostDeploy hooks are run once the resources of the component are deployed.
+	PostDeploy Phase = "post-deploy"
+	// PreDelete hooks are run when the component is removed, before its resources
+	// are garbage collected, i.e. to deregister external resources. The action
+	// must be registered as a finalizer, after the actions rendering the hooks.
+	PreDelete Phase = "pre-delete"
+)
+
+const (
+	DefaultTimeout      = 10 * time.Minute
+	DefaultRequeueAfter = 10 * time.Second
+
+	// EventReasonHookFailed is the reason of the event recorded when a pre-delete
+	// hook fails or times out.
+	EventReasonHookFailed = "HookFailed"
+)
+
+// Action runs the Jobs rendered for the current reconciliation and marked as hooks
+// of the given phase by the annotations.Hook annotation. A hook is run once per
+// platform version and instance generation: the Job left by a previous run is
+// deleted and re-created when any of them changes. While any of the hooks is not
+// complete, the remaining actions are skipped and the request is requeued.
+//
+// Hooks are not deployed by the deploy action, which skips any resource with
+// the annotations.Hook annotation.
+//
+// A failing or timed out PreDelete hook does not fail the action, as that would
+// block the removal of the component forever: a warning event is recorded on
+// the instance and the removal proceeds.
+type Action struct {
+	phase        Phase
+	timeout      time.Duration
+	requeueAfter time.Duration
+}
+
+type ActionOpts func(*Action)
+
+// WithTimeout sets the time a hook Job is given to complete before the hook is
+// reported as failed.
+func WithTimeout(value time.Duration) ActionOpts {
+	return func(action *Action) {
+		action.timeout = value
+	}
+}
+
+// WithRequeueAfter sets the delay after which the request is requeued while
+// waiting for hooks to complete.
+func WithRequeueAfter(value time.Duration) ActionOpts {
+	return func(action *Action) {
+		action.requeueAfter = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	revision := rr.Release.Version.String() + "." + strconv.FormatInt(rr.Instance.GetGeneration(), 10)
+	pending := make([]string, 0)
+
+	for i := range rr.Resources {
+		if resources.GetAnnotation(&rr.Resources[i], annotations.Hook) != string(a.phase) {
+			continue
+		}
+
+		if rr.Resources[i].GroupVersionKind() != gvk.Job {
+			return fmt.Errorf("unsupported %s hook %s %s: only Jobs are supported",
+				a.phase, rr.Resources[i].GetKind(), rr.Resources[i].GetName())
+		}
+
+		done, err := a.runHook(ctx, rr, rr.Resources[i].DeepCopy(), revision)
+		switch {
+		case err != nil && a.phase == PreDelete:
+			logf.FromContext(ctx).Error(err, "hook failed, proceeding with removal", "phase", a.phase, "name", rr.Resources[i].GetName())
+			rr.RecordEvent(corev1.EventTypeWarning, EventReasonHookFailed, "%s hook %s failed: %v", a.phase, rr.Resources[i].GetName(), err)
+
+			continue
+		case err != nil:
+			return fmt.Errorf("%s hook %s failed: %w", a.phase, rr.Resources[i].GetName(), err)
+		}
+
+		if !done {
+			pending = append(pending, rr.Resources[i].GetName())
+		}
+	}
+
+	if len(pending) > 0 {
+		return odherrors.NewWaitError(a.requeueAfter, "waiting for %s hooks to complete: %v", a.phase, pending)
+	}
+
+	return nil
+}
+
+// runHook creates the hook Job if needed and returns true once it is complete.
+func (a *Action) runHook(ctx context.Context, rr *types.ReconciliationRequest, obj *unstructured.Unstructured, revision string) (bool, error) {
+	l := logf.FromContext(ctx)
+
+	current := batchv1.Job{}
+
+	err := rr.Client.Get(ctx, client.ObjectKeyFromObject(obj), &current)
+	switch {
+	case k8serr.IsNotFound(err):
+		resources.SetAnnotation(obj, annotations.HookRevision, revision)
+
+		if err := controllerutil.SetOwnerReference(rr.Instance, obj, rr.Client.Scheme()); err != nil {
+			return false, err
+		}
+
+		l.V(3).Info("running hook", "phase", a.phase, "name", client.ObjectKeyFromObject(obj), "revision", revision)
+
+		if err := rr.Client.Create(ctx, obj); err != nil {
+			return false, fmt.Errorf("unable to create job: %w", err)
+		}
+
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("unable to get job: %w", err)
+	}
+
+	if !current.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+
+	// the hook has been run for a previous revision, re-run it
+	if current.Annotations[annotations.HookRevision] != revision {
+		l.V(3).Info("deleting previous hook run", "phase", a.phase, "name", client.ObjectKeyFromObject(obj))
+
+		err := rr.Client.Delete(ctx, &current, client.PropagationPolicy("Background"))
+		if err != nil && !k8serr.IsNotFound(err) {
+			return false, fmt.Errorf("unable to delete job: %w", err)
+		}
+
+		return false, nil
+	}
+
+	for _, c := range current.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("job failed: %s", c.Message)
+		}
+	}
+
+	if a.timeout > 0 && time.Since(current.CreationTimestamp.Time) > a.timeout {
+		return false, fmt.Errorf("job did not complete within %s", a.timeout)
+	}
+
+	return false, nil
+}
+
+func NewAction(phase Phase, opts ...ActionOpts) actions.Fn {
+	action := Action{
+		phase:        phase,
+		timeout:      DefaultTimeout,
+		requeueAfter: DefaultRequeueAfter,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
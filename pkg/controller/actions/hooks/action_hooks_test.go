@@ -0,0 +1,278 @@
+package hooks_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/operator-framework/api/pkg/lib/version"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/hooks"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	hookNamespace = "hooks"
+	hookName      = "migrate"
+	hookRevision  = "1.2.3.2"
+)
+
+func newHookJob(phase hooks.Phase) *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hookName,
+			Namespace: hookNamespace,
+			Annotations: map[string]string{
+				annotations.Hook: string(phase),
+			},
+		},
+	}
+}
+
+func newLiveJob(revision string, conditionType batchv1.JobConditionType, created time.Time) *batchv1.Job {
+	j := newHookJob(hooks.PreDeploy)
+	j.Annotations[annotations.HookRevision] = revision
+	j.CreationTimestamp = metav1.NewTime(created)
+
+	if conditionType != "" {
+		j.Status.Conditions = []batchv1.JobCondition{{
+			Type:    conditionType,
+			Status:  corev1.ConditionTrue,
+			Message: "reason",
+		}}
+	}
+
+	return j
+}
+
+func newInstance() *componentApi.Dashboard {
+	return &componentApi.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       componentApi.DashboardInstanceName,
+			UID:        "uid",
+			Generation: 2,
+		},
+	}
+}
+
+func newRelease() common.Release {
+	return common.Release{
+		Name: cluster.OpenDataHub,
+		Version: version.OperatorVersion{Version: semver.Version{
+			Major: 1, Minor: 2, Patch: 3,
+		}},
+	}
+}
+
+func TestHooksCreate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newInstance()),
+		fakerequest.WithRelease(newRelease()),
+		fakerequest.WithResources(newHookJob(hooks.PreDeploy)),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = hooks.NewAction(hooks.PreDeploy, hooks.WithRequeueAfter(time.Second))(ctx, rr)
+
+	we := odherrors.WaitError{}
+	g.Expect(errors.As(err, &we)).Should(BeTrue())
+	g.Expect(we.RequeueAfter()).Should(Equal(time.Second))
+
+	job := batchv1.Job{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: hookNamespace, Name: hookName}, &job)).Should(Succeed())
+	g.Expect(job.Annotations).Should(HaveKeyWithValue(annotations.HookRevision, hookRevision))
+	g.Expect(job.OwnerReferences).Should(HaveLen(1))
+}
+
+func TestHooksPhase(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newInstance()),
+		fakerequest.WithRelease(newRelease()),
+		fakerequest.WithResources(newHookJob(hooks.PostDeploy)),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = hooks.NewAction(hooks.PreDeploy)(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = cl.Get(ctx, client.ObjectKey{Namespace: hookNamespace, Name: hookName}, &batchv1.Job{})
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestHooksUnsupportedKind(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newInstance()),
+		fakerequest.WithRelease(newRelease()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	rr.Resources = append(rr.Resources, unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name":        hookName,
+			"namespace":   hookNamespace,
+			"annotations": map[string]any{annotations.Hook: string(hooks.PreDeploy)},
+		},
+	}})
+
+	err = hooks.NewAction(hooks.PreDeploy)(ctx, rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("only Jobs are supported")))
+}
+
+func TestHooksStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		live    *batchv1.Job
+		matcher func(g *WithT, err error)
+		deleted bool
+	}{
+		{
+			name: "complete",
+			live: newLiveJob(hookRevision, batchv1.JobComplete, time.Now()),
+			matcher: func(g *WithT, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			},
+		},
+		{
+			name: "running",
+			live: newLiveJob(hookRevision, "", time.Now()),
+			matcher: func(g *WithT, err error) {
+				g.Expect(errors.As(err, &odherrors.WaitError{})).Should(BeTrue())
+			},
+		},
+		{
+			name: "failed",
+			live: newLiveJob(hookRevision, batchv1.JobFailed, time.Now()),
+			matcher: func(g *WithT, err error) {
+				g.Expect(err).Should(MatchError(ContainSubstring("job failed: reason")))
+				g.Expect(errors.As(err, &odherrors.WaitError{})).Should(BeFalse())
+			},
+		},
+		{
+			name: "timed out",
+			live: newLiveJob(hookRevision, "", time.Now().Add(-time.Hour)),
+			matcher: func(g *WithT, err error) {
+				g.Expect(err).Should(MatchError(ContainSubstring("did not complete within")))
+			},
+		},
+		{
+			name: "previous revision",
+			live: newLiveJob("1.0.0.1", batchv1.JobComplete, time.Now()),
+			matcher: func(g *WithT, err error) {
+				g.Expect(errors.As(err, &odherrors.WaitError{})).Should(BeTrue())
+			},
+			deleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			cl, err := fakeclient.New(fakeclient.WithObjects(tt.live))
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newInstance()),
+				fakerequest.WithRelease(newRelease()),
+				fakerequest.WithResources(newHookJob(hooks.PreDeploy)),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			err = hooks.NewAction(hooks.PreDeploy)(ctx, rr)
+			tt.matcher(g, err)
+
+			err = cl.Get(ctx, client.ObjectKeyFromObject(tt.live), &batchv1.Job{})
+			if tt.deleted {
+				g.Expect(err).Should(HaveOccurred())
+			} else {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestHooksPreDeleteFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		live *batchv1.Job
+	}{
+		{
+			name: "failed",
+			live: newLiveJob(hookRevision, batchv1.JobFailed, time.Now()),
+		},
+		{
+			name: "timed out",
+			live: newLiveJob(hookRevision, "", time.Now().Add(-time.Hour)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			cl, err := fakeclient.New(fakeclient.WithObjects(tt.live))
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			recorder := record.NewFakeRecorder(10)
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClient(cl),
+				fakerequest.WithInstance(newInstance()),
+				fakerequest.WithRelease(newRelease()),
+				fakerequest.WithResources(newHookJob(hooks.PreDelete)),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			rr.Controller = fakecontroller.New(fakecontroller.WithEventRecorder(recorder))
+
+			err = hooks.NewAction(hooks.PreDelete)(ctx, rr)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			g.Expect(recorder.Events).Should(Receive(ContainSubstring(hooks.EventReasonHookFailed)))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package mesh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+type MTLSMode string
+
+const (
+	MTLSStrict     MTLSMode = "STRICT"
+	MTLSPermissive MTLSMode = "PERMISSIVE"
+)
+
+// memberName is the name Maistra requires ServiceMeshMembers to have.
+const memberName = "default"
+
+// injectedKinds are the workloads the sidecar is injected in, Jobs are left out
+// as the sidecar would keep their pods from completing.
+var injectedKinds = []schema.GroupKind{
+	gvk.Deployment.GroupKind(),
+	gvk.StatefulSet.GroupKind(),
+	gvk.DaemonSet.GroupKind(),
+}
+
+// Action enrolls the rendered workloads of a component in the platform service
+// mesh, when it is managed by the DSCInitialization: the namespace of the
+// component is made a member of the control plane with a ServiceMeshMember,
+// the sidecar is injected in the pods of the workloads and a PeerAuthentication
+// enforces mutual TLS on them. It must be placed between the render and the
+// deploy actions.
+//
+// The workloads setting the labels.SidecarInject label on their pod template
+// are left untouched, so that manifests can opt out. The ServiceMeshMember and
+// the PeerAuthentications are only generated once their CRD exists, the
+// component should then own them with deferred watches:
+//
+//	OwnsGVK(gvk.ServiceMeshMember, reconciler.Dynamic(reconciler.CrdExists(gvk.ServiceMeshMember)))
+//	OwnsGVK(gvk.PeerAuthentication, reconciler.Dynamic(reconciler.CrdExists(gvk.PeerAuthentication)))
+type Action struct {
+	namespace string
+	mtlsMode  MTLSMode
+}
+
+type ActionOpts func(*Action)
+
+// WithNamespace sets the namespace enrolled in the mesh, defaults to the
+// applications namespace.
+func WithNamespace(value string) ActionOpts {
+	return func(action *Action) {
+		action.namespace = value
+	}
+}
+
+// WithMTLSMode sets the mutual TLS mode of the PeerAuthentications, defaults to
+// MTLSStrict.
+func WithMTLSMode(value MTLSMode) ActionOpts {
+	return func(action *Action) {
+		action.mtlsMode = value
+	}
+}
+
+func (a *Action) run(_ context.Context, rr *types.ReconciliationRequest) error {
+	if rr.DSCI == nil {
+		return errors.New("unable to enroll in the service mesh: DSCInitialization is not set")
+	}
+
+	sm := rr.DSCI.Spec.ServiceMesh
+	if sm == nil || sm.ManagementState != operatorv1.Managed {
+		return nil
+	}
+
+	ns := a.namespace
+	if ns == "" {
+		ns = rr.DSCI.Spec.ApplicationsNamespace
+	}
+
+	hasMembers, err := cluster.HasAPI(rr.Client, gvk.ServiceMeshMember)
+	if err != nil {
+		return fmt.Errorf("unable to detect whether service mesh members are available: %w", err)
+	}
+
+	hasPeerAuthentications, err := cluster.HasAPI(rr.Client, gvk.PeerAuthentication)
+	if err != nil {
+		return fmt.Errorf("unable to detect whether peer authentications are available: %w", err)
+	}
+
+	generated := make([]*unstructured.Unstructured, 0)
+
+	if hasMembers {
+		generated = append(generated, member(ns, sm.ControlPlane.Namespace, sm.ControlPlane.Name))
+	}
+
+	for i := range rr.Resources {
+		obj := &rr.Resources[i]
+
+		injected, err := inject(obj)
+		if err != nil {
+			return fmt.Errorf("unable to inject sidecar in %s: %w", resources.FormatObjectReference(obj), err)
+		}
+
+		if !injected || !hasPeerAuthentications {
+			continue
+		}
+
+		pa, err := a.peerAuthentication(obj)
+		if err != nil {
+			return fmt.Errorf("unable to generate peer authentication of %s: %w", resources.FormatObjectReference(obj), err)
+		}
+
+		if pa != nil {
+			generated = append(generated, pa)
+		}
+	}
+
+	for _, obj := range generated {
+		if err := rr.AddResources(obj); err != nil {
+			return fmt.Errorf("unable to add %s: %w", resources.FormatObjectReference(obj), err)
+		}
+	}
+
+	return nil
+}
+
+// inject labels the pod template of the given workload for the sidecar to be
+// injected, and returns true if the sidecar is injected.
+func inject(obj *unstructured.Unstructured) (bool, error) {
+	if !slices.Contains(injectedKinds, obj.GroupVersionKind().GroupKind()) {
+		return false, nil
+	}
+
+	path := []string{"spec", "template", "metadata", "labels", labels.SidecarInject}
+
+	value, found, err := unstructured.NestedString(obj.Object, path...)
+	if err != nil {
+		return false, err
+	}
+
+	if found {
+		return value == labels.True, nil
+	}
+
+	return true, unstructured.SetNestedField(obj.Object, labels.True, path...)
+}
+
+// peerAuthentication returns the PeerAuthentication enforcing mutual TLS on the
+// pods of the given workload, or nil if they can't be selected.
+func (a *Action) peerAuthentication(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	matchLabels, found, err := unstructured.NestedMap(obj.Object, "spec", "selector", "matchLabels")
+	if err != nil || !found || len(matchLabels) == 0 {
+		return nil, err
+	}
+
+	pa := resources.GvkToUnstructured(gvk.PeerAuthentication)
+	pa.SetName(obj.GetName())
+	pa.SetNamespace(obj.GetNamespace())
+	pa.Object["spec"] = map[string]any{
+		"selector": map[string]any{
+			"matchLabels": matchLabels,
+		},
+		"mtls": map[string]any{
+			"mode": string(a.mtlsMode),
+		},
+	}
+
+	return pa, nil
+}
+
+// member returns the ServiceMeshMember making the given namespace a member of
+// the given control plane.
+func member(ns string, controlPlaneNamespace string, controlPlaneName string) *unstructured.Unstructured {
+	smm := resources.GvkToUnstructured(gvk.ServiceMeshMember)
+	smm.SetName(memberName)
+	smm.SetNamespace(ns)
+	smm.Object["spec"] = map[string]any{
+		"controlPlaneRef": map[string]any{
+			"namespace": controlPlaneNamespace,
+			"name":      controlPlaneName,
+		},
+	}
+
+	return smm
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		mtlsMode: MTLSStrict,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
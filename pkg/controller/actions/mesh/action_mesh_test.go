@@ -0,0 +1,164 @@
+package mesh_test
+
+import (
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/api/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/mesh"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+const meshNamespace = "opendatahub"
+
+func newDeployment(name string, podLabels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: meshNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			},
+		},
+	}
+}
+
+func newDSCI(state operatorv1.ManagementState) *dsciv2.DSCInitialization {
+	return &dsciv2.DSCInitialization{
+		Spec: dsciv2.DSCInitializationSpec{
+			ApplicationsNamespace: meshNamespace,
+			ServiceMesh: &infrav1.ServiceMeshSpec{
+				ManagementState: state,
+				ControlPlane: infrav1.ControlPlaneSpec{
+					Name:      "data-science-smcp",
+					Namespace: "istio-system",
+				},
+			},
+		},
+	}
+}
+
+func newResources() []client.Object {
+	return []client.Object{
+		newDeployment("ui", nil),
+		newDeployment("agent", map[string]string{labels.SidecarInject: "false"}),
+		&batchv1.Job{
+			TypeMeta: metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "migrate",
+				Namespace: meshNamespace,
+			},
+		},
+	}
+}
+
+func TestMeshEnroll(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	s.AddKnownTypeWithName(gvk.ServiceMeshMember, &unstructured.Unstructured{})
+	s.AddKnownTypeWithName(gvk.PeerAuthentication, &unstructured.Unstructured{})
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s)),
+		fakerequest.WithDSCI(newDSCI(operatorv1.Managed)),
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = mesh.NewAction()(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(5),
+		ContainElement(And(
+			jq.Match(`.kind == "Deployment" and .metadata.name == "ui"`),
+			jq.Match(`.spec.template.metadata.labels."%s" == "true"`, labels.SidecarInject),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "Deployment" and .metadata.name == "agent"`),
+			jq.Match(`.spec.template.metadata.labels."%s" == "false"`, labels.SidecarInject),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "Job"`),
+			jq.Match(`.spec.template.metadata.labels == null`),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "ServiceMeshMember"`),
+			jq.Match(`.metadata.name == "default"`),
+			jq.Match(`.metadata.namespace == "%s"`, meshNamespace),
+			jq.Match(`.spec.controlPlaneRef == {"namespace": "istio-system", "name": "data-science-smcp"}`),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "PeerAuthentication"`),
+			jq.Match(`.metadata.name == "ui"`),
+			jq.Match(`.spec.selector.matchLabels == {"app": "ui"}`),
+			jq.Match(`.spec.mtls.mode == "STRICT"`),
+		)),
+	))
+}
+
+func TestMeshWithoutCRDs(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithDSCI(newDSCI(operatorv1.Managed)),
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = mesh.NewAction()(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// the sidecar is injected, the mesh resources wait for their CRDs
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(3),
+		ContainElement(And(
+			jq.Match(`.kind == "Deployment" and .metadata.name == "ui"`),
+			jq.Match(`.spec.template.metadata.labels."%s" == "true"`, labels.SidecarInject),
+		)),
+	))
+}
+
+func TestMeshNotManaged(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithDSCI(newDSCI(operatorv1.Removed)),
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = mesh.NewAction()(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(3),
+		ContainElement(And(
+			jq.Match(`.kind == "Deployment" and .metadata.name == "ui"`),
+			jq.Match(`.spec.template.metadata.labels == null`),
+		)),
+	))
+}
@@ -0,0 +1,162 @@
+package monitors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// Groups are the API groups of the Prometheus Operator resources, served by the
+// community operator and by the Cluster Observability Operator.
+var Groups = []string{
+	gvk.ServiceMonitorServiceMesh.Group,
+	gvk.ServiceMonitor.Group,
+}
+
+// Action makes the monitoring resources of a component optional: the rendered
+// resources of the Prometheus Operator API groups (ServiceMonitors, PodMonitors,
+// PrometheusRules, ...) are dropped while their CRD is not installed, instead of
+// failing the deployment of the whole component. It must be placed between the
+// render and the deploy actions, and the component should own the monitoring
+// resources with deferred watches, i.e.:
+//
+//	OwnsGVK(gvk.ServiceMonitor, reconciler.Dynamic(reconciler.CrdExists(gvk.ServiceMonitor)))
+//
+// so that they are deployed once the CRDs are installed.
+//
+// The rendered PrometheusRules are validated with ValidatePrometheusRule, so
+// that malformed alerting rules bundled with a component are reported before
+// being applied.
+//
+// With WithMetricsEndpoint, the pods of the given workloads are additionally
+// labeled to be scraped by the platform metrics collector when metrics are
+// enabled in the DSCInitialization. The collector scrapes the /metrics path on
+// port 8080.
+type Action struct {
+	workloads []string
+}
+
+type ActionOpts func(*Action)
+
+// WithMetricsEndpoint registers the pods of the given rendered workloads in the
+// platform monitoring.
+func WithMetricsEndpoint(values ...string) ActionOpts {
+	return func(action *Action) {
+		action.workloads = append(action.workloads, values...)
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	if err := dropUnserved(ctx, rr); err != nil {
+		return err
+	}
+
+	for i := range rr.Resources {
+		obj := &rr.Resources[i]
+		if obj.GetKind() != gvk.PrometheusRule.Kind || !slices.Contains(Groups, obj.GroupVersionKind().Group) {
+			continue
+		}
+
+		if err := ValidatePrometheusRule(obj); err != nil {
+			return fmt.Errorf("invalid prometheus rule %s: %w", resources.FormatObjectReference(obj), err)
+		}
+	}
+
+	if len(a.workloads) == 0 {
+		return nil
+	}
+
+	if rr.DSCI == nil {
+		return errors.New("unable to register metrics endpoints: DSCInitialization is not set")
+	}
+
+	m := rr.DSCI.Spec.Monitoring
+	if m.ManagementState != operatorv1.Managed || m.Metrics == nil {
+		return nil
+	}
+
+	for i := range rr.Resources {
+		obj := &rr.Resources[i]
+
+		if !slices.Contains(a.workloads, obj.GetName()) {
+			continue
+		}
+		path, ok := resources.PodSpecPath(obj.GroupVersionKind().GroupKind())
+		if !ok {
+			continue
+		}
+
+		// the pod labels are siblings of the pod spec
+		path = append(path[:len(path)-1], "metadata", "labels", labels.MonitoringScrape)
+
+		if err := unstructured.SetNestedField(obj.Object, labels.True, path...); err != nil {
+			return fmt.Errorf("unable to register metrics endpoint of %s: %w", resources.FormatObjectReference(obj), err)
+		}
+	}
+
+	return nil
+}
+
+// dropUnserved removes from the rendered resources the monitoring resources
+// the cluster does not serve.
+func dropUnserved(ctx context.Context, rr *types.ReconciliationRequest) error {
+	served := map[schema.GroupVersionKind]bool{}
+	missing := false
+
+	for i := range rr.Resources {
+		k := rr.Resources[i].GroupVersionKind()
+		if !slices.Contains(Groups, k.Group) {
+			continue
+		}
+		if _, ok := served[k]; ok {
+			continue
+		}
+
+		ok, err := cluster.HasAPI(rr.Client, k)
+		if err != nil {
+			return fmt.Errorf("unable to detect whether %s is available: %w", k, err)
+		}
+
+		served[k] = ok
+		missing = missing || !ok
+	}
+
+	if !missing {
+		return nil
+	}
+
+	l := logf.FromContext(ctx)
+
+	return rr.RemoveResources(func(obj *unstructured.Unstructured) bool {
+		ok, found := served[obj.GroupVersionKind()]
+		if found && !ok {
+			l.V(3).Info("skipping monitoring resource, CRD not installed", "resource", resources.FormatObjectReference(obj))
+			return true
+		}
+
+		return false
+	})
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
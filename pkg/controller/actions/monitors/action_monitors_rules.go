@@ -0,0 +1,146 @@
+package monitors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	// durationRegexp is the format of Prometheus durations, i.e. 1h30m.
+	durationRegexp = regexp.MustCompile(`^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`)
+	metricRegexp   = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelRegexp    = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// ValidatePrometheusRule checks the syntax of the rules of the given
+// PrometheusRule: group names are set and unique, each rule is either an alert
+// or a recording rule with a well-formed expression, durations, record and
+// label names are valid. It does not evaluate the PromQL expressions, only
+// whether their delimiters and quotes are balanced.
+func ValidatePrometheusRule(obj *unstructured.Unstructured) error {
+	pr := monitoringv1.PrometheusRule{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pr); err != nil {
+		return fmt.Errorf("unable to decode prometheus rule: %w", err)
+	}
+
+	var errs *multierror.Error
+
+	groups := make(map[string]struct{}, len(pr.Spec.Groups))
+
+	for _, g := range pr.Spec.Groups {
+		if g.Name == "" {
+			errs = multierror.Append(errs, errors.New("group name is not set"))
+			continue
+		}
+
+		if _, ok := groups[g.Name]; ok {
+			errs = multierror.Append(errs, fmt.Errorf("group %q: duplicated name", g.Name))
+		}
+
+		groups[g.Name] = struct{}{}
+
+		if g.Interval != nil && !durationRegexp.MatchString(string(*g.Interval)) {
+			errs = multierror.Append(errs, fmt.Errorf("group %q: invalid interval %q", g.Name, *g.Interval))
+		}
+
+		for i := range g.Rules {
+			if err := validateRule(&g.Rules[i]); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("group %q, rule %d: %w", g.Name, i, err))
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func validateRule(r *monitoringv1.Rule) error {
+	var errs *multierror.Error
+
+	switch {
+	case r.Alert == "" && r.Record == "":
+		errs = multierror.Append(errs, errors.New("one of alert or record must be set"))
+	case r.Alert != "" && r.Record != "":
+		errs = multierror.Append(errs, errors.New("only one of alert or record can be set"))
+	case r.Record != "" && !metricRegexp.MatchString(r.Record):
+		errs = multierror.Append(errs, fmt.Errorf("invalid record name %q", r.Record))
+	}
+
+	if r.Record != "" && (r.For != nil || r.KeepFiringFor != nil || len(r.Annotations) != 0) {
+		errs = multierror.Append(errs, errors.New("for, keep_firing_for and annotations are only valid for alerts"))
+	}
+
+	expr := strings.TrimSpace(r.Expr.String())
+	if expr == "" {
+		errs = multierror.Append(errs, errors.New("expr is not set"))
+	} else if err := checkBalanced(expr); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("invalid expr: %w", err))
+	}
+
+	if r.For != nil && !durationRegexp.MatchString(string(*r.For)) {
+		errs = multierror.Append(errs, fmt.Errorf("invalid for duration %q", *r.For))
+	}
+
+	if r.KeepFiringFor != nil && !durationRegexp.MatchString(string(*r.KeepFiringFor)) {
+		errs = multierror.Append(errs, fmt.Errorf("invalid keep_firing_for duration %q", *r.KeepFiringFor))
+	}
+
+	for k := range r.Labels {
+		if !labelRegexp.MatchString(k) {
+			errs = multierror.Append(errs, fmt.Errorf("invalid label name %q", k))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// checkBalanced checks the parentheses, brackets and braces of the given
+// expression are balanced and its strings terminated.
+func checkBalanced(expr string) error {
+	closing := map[rune]rune{')': '(', ']': '[', '}': '{'}
+	stack := make([]rune, 0)
+
+	var quote rune
+
+	escaped := false
+
+	for _, c := range expr {
+		switch {
+		case quote != 0:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\' && quote != '`':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, c)
+		case closing[c] != 0:
+			if len(stack) == 0 || stack[len(stack)-1] != closing[c] {
+				return fmt.Errorf("unexpected %q", c)
+			}
+
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if quote != 0 {
+		return fmt.Errorf("unterminated string, missing %q", quote)
+	}
+
+	if len(stack) != 0 {
+		return fmt.Errorf("unclosed %q", stack[len(stack)-1])
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package monitors_test
+
+import (
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/monitors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+const monitorsNamespace = "opendatahub"
+
+func newDSCI(monitoring serviceApi.DSCIMonitoring) *dsciv2.DSCInitialization {
+	return &dsciv2.DSCInitialization{
+		Spec: dsciv2.DSCInitializationSpec{
+			ApplicationsNamespace: monitorsNamespace,
+			Monitoring:            monitoring,
+		},
+	}
+}
+
+func newResources() []client.Object {
+	sm := resources.GvkToUnstructured(gvk.ServiceMonitor)
+	sm.SetName("ui")
+	sm.SetNamespace(monitorsNamespace)
+
+	pr := resources.GvkToUnstructured(gvk.PrometheusRule)
+	pr.SetName("ui")
+	pr.SetNamespace(monitorsNamespace)
+
+	return []client.Object{
+		sm,
+		pr,
+		&appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ui",
+				Namespace: monitorsNamespace,
+			},
+		},
+	}
+}
+
+func TestMonitorsWithoutCRDs(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithDSCI(newDSCI(serviceApi.DSCIMonitoring{})),
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = monitors.NewAction()(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		ContainElement(jq.Match(`.kind == "Deployment"`)),
+	))
+}
+
+func TestMonitorsWithCRDs(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	s.AddKnownTypeWithName(gvk.ServiceMonitor, &unstructured.Unstructured{})
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s)),
+		fakerequest.WithDSCI(newDSCI(serviceApi.DSCIMonitoring{})),
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = monitors.NewAction()(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// only the PrometheusRule CRD is missing
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(2),
+		ContainElement(jq.Match(`.kind == "ServiceMonitor"`)),
+		ContainElement(jq.Match(`.kind == "Deployment"`)),
+	))
+}
+
+func TestMonitorsMetricsEndpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithDSCI(newDSCI(serviceApi.DSCIMonitoring{
+			ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
+			MonitoringCommonSpec: serviceApi.MonitoringCommonSpec{
+				Metrics: &serviceApi.Metrics{},
+			},
+		})),
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = monitors.NewAction(monitors.WithMetricsEndpoint("ui"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		ContainElement(And(
+			jq.Match(`.kind == "Deployment"`),
+			jq.Match(`.spec.template.metadata.labels."%s" == "true"`, labels.MonitoringScrape),
+		)),
+	))
+}
+
+func TestMonitorsMetricsDisabled(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithDSCI(newDSCI(serviceApi.DSCIMonitoring{
+			ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
+		})),
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = monitors.NewAction(monitors.WithMetricsEndpoint("ui"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(ContainElement(And(
+		jq.Match(`.kind == "Deployment"`),
+		jq.Match(`.spec.template.metadata.labels == null`),
+	)))
+}
+
+func newRule(name string, rules ...any) *unstructured.Unstructured {
+	pr := resources.GvkToUnstructured(gvk.PrometheusRule)
+	pr.SetName(name)
+	pr.SetNamespace(monitorsNamespace)
+	pr.Object["spec"] = map[string]any{
+		"groups": []any{
+			map[string]any{"name": "ui", "rules": rules},
+		},
+	}
+
+	return pr
+}
+
+func TestMonitorsRulesValidation(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	s.AddKnownTypeWithName(gvk.PrometheusRule, &unstructured.Unstructured{})
+
+	cl, err := fakeclient.New(fakeclient.WithScheme(s))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{Client: cl}
+
+	err = rr.AddResources(newRule("valid",
+		map[string]any{
+			"alert":  "UIDown",
+			"expr":   `absent(up{job="ui"} == 1)`,
+			"for":    "5m",
+			"labels": map[string]any{"severity": "critical"},
+		},
+		map[string]any{
+			"record": "ui:requests:rate5m",
+			"expr":   `sum(rate(http_requests_total{job="ui"}[5m]))`,
+		},
+	))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = monitors.NewAction()(t.Context(), &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = rr.AddResources(newRule("invalid",
+		map[string]any{
+			"alert": "UIDown",
+			"expr":  `absent(up{job="ui"} == 1`,
+			"for":   "5 minutes",
+		},
+		map[string]any{
+			"record": "ui requests",
+			"expr":   "1",
+		},
+	))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = monitors.NewAction()(t.Context(), &rr)
+	g.Expect(err).Should(And(
+		MatchError(ContainSubstring("invalid prometheus rule")),
+		MatchError(ContainSubstring(`unclosed '('`)),
+		MatchError(ContainSubstring(`invalid for duration "5 minutes"`)),
+		MatchError(ContainSubstring(`invalid record name "ui requests"`)),
+	))
+}
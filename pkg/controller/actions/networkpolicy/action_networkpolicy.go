@@ -0,0 +1,204 @@
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const DefaultDenySuffix = "-default-deny"
+
+// workloadKinds are the workloads default deny policies are generated for.
+var workloadKinds = []schema.GroupKind{
+	gvk.Deployment.GroupKind(),
+	gvk.StatefulSet.GroupKind(),
+	gvk.DaemonSet.GroupKind(),
+}
+
+// Action generates the NetworkPolicies isolating the rendered workloads of a
+// component: for each rendered Service, a policy allowing ingress traffic to
+// the Service ports from the pods of the same namespace and from the platform
+// namespaces (ingress, monitoring, namespaces generated or customized by the
+// platform), the same peers as the applications namespace default policy. It
+// must be placed between the render and the deploy actions.
+//
+// With WithDefaultDeny, a policy denying all ingress traffic is additionally
+// generated for each rendered workload, so that the pods not exposed by a
+// Service are not reachable at all.
+type Action struct {
+	defaultDeny bool
+	peers       []networkingv1.NetworkPolicyPeer
+}
+
+type ActionOpts func(*Action)
+
+// WithDefaultDeny generates a policy denying all ingress traffic to each
+// rendered workload.
+func WithDefaultDeny() ActionOpts {
+	return func(action *Action) {
+		action.defaultDeny = true
+	}
+}
+
+// WithAllowedNamespace additionally allows traffic from the namespaces with the
+// given label.
+func WithAllowedNamespace(key string, value string) ActionOpts {
+	return func(action *Action) {
+		action.peers = append(action.peers, namespacePeer(key, value))
+	}
+}
+
+func (a *Action) run(_ context.Context, rr *types.ReconciliationRequest) error {
+	generated := make([]*networkingv1.NetworkPolicy, 0)
+
+	for i := range rr.Resources {
+		obj := &rr.Resources[i]
+
+		switch {
+		case obj.GroupVersionKind() == gvk.Service:
+			np, err := a.allow(obj)
+			if err != nil {
+				return fmt.Errorf("unable to generate network policy of %s: %w", resources.FormatObjectReference(obj), err)
+			}
+
+			if np != nil {
+				generated = append(generated, np)
+			}
+		case a.defaultDeny && slices.Contains(workloadKinds, obj.GroupVersionKind().GroupKind()):
+			np, err := deny(obj)
+			if err != nil {
+				return fmt.Errorf("unable to generate network policy of %s: %w", resources.FormatObjectReference(obj), err)
+			}
+
+			if np != nil {
+				generated = append(generated, np)
+			}
+		}
+	}
+
+	for _, np := range generated {
+		if err := rr.AddResources(np); err != nil {
+			return fmt.Errorf("unable to add network policy %s: %w", np.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// allow returns the policy allowing traffic to the ports of the given Service,
+// or nil if the Service does not select any pod.
+func (a *Action) allow(obj *unstructured.Unstructured) (*networkingv1.NetworkPolicy, error) {
+	svc := corev1.Service{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &svc); err != nil {
+		return nil, err
+	}
+
+	if len(svc.Spec.Selector) == 0 || len(svc.Spec.Ports) == 0 {
+		return nil, nil
+	}
+
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		port := p.TargetPort
+		if port.Type == intstr.Int && port.IntVal == 0 {
+			port = intstr.FromInt32(p.Port)
+		}
+
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		})
+	}
+
+	peers := append(platformPeers(), a.peers...)
+
+	np := newPolicy(svc.Name, svc.Namespace, svc.Spec.Selector)
+	np.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{
+		From:  peers,
+		Ports: ports,
+	}}
+
+	return np, nil
+}
+
+// deny returns the policy denying all ingress traffic to the pods of the given
+// workload, or nil if they can't be selected.
+func deny(obj *unstructured.Unstructured) (*networkingv1.NetworkPolicy, error) {
+	matchLabels, found, err := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+	if err != nil || !found || len(matchLabels) == 0 {
+		return nil, err
+	}
+
+	return newPolicy(obj.GetName()+DefaultDenySuffix, obj.GetNamespace(), matchLabels), nil
+}
+
+func newPolicy(name string, ns string, selector map[string]string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+			Kind:       gvk.NetworkPolicy.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
+}
+
+// platformPeers returns the peers traffic is allowed from by default: the pods
+// of the same namespace and the platform namespaces.
+func platformPeers() []networkingv1.NetworkPolicyPeer {
+	return []networkingv1.NetworkPolicyPeer{
+		{PodSelector: &metav1.LabelSelector{}},
+		namespacePeer(labels.ODH.OwnedNamespace, labels.True),
+		namespacePeer(labels.CustomizedAppNamespace, labels.True),
+		namespacePeer("network.openshift.io/policy-group", "ingress"),
+		namespacePeer("kubernetes.io/metadata.name", "openshift-host-network"),
+		namespacePeer("kubernetes.io/metadata.name", "openshift-monitoring"),
+	}
+}
+
+func namespacePeer(key string, value string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{key: value},
+		},
+	}
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
@@ -0,0 +1,111 @@
+package networkpolicy_test
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/networkpolicy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+const npNamespace = "opendatahub"
+
+func newResources() []client.Object {
+	return []client.Object{
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ui",
+				Namespace: npNamespace,
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "ui"},
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+					{Name: "metrics", Port: 9090},
+				},
+			},
+		},
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "external",
+				Namespace: npNamespace,
+			},
+			Spec: corev1.ServiceSpec{
+				Type:         corev1.ServiceTypeExternalName,
+				ExternalName: "example.com",
+			},
+		},
+		&appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ui",
+				Namespace: npNamespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "ui"},
+				},
+			},
+		},
+	}
+}
+
+func TestNetworkPolicyAllow(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = networkpolicy.NewAction(networkpolicy.WithAllowedNamespace("team", "ml"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(4),
+		ContainElement(And(
+			jq.Match(`.kind == "NetworkPolicy"`),
+			jq.Match(`.metadata.name == "ui"`),
+			jq.Match(`.metadata.namespace == "%s"`, npNamespace),
+			jq.Match(`.spec.podSelector.matchLabels == {"app": "ui"}`),
+			jq.Match(`.spec.policyTypes == ["Ingress"]`),
+			jq.Match(`.spec.ingress[0].ports == [{"protocol": "TCP", "port": "http"}, {"protocol": "TCP", "port": 9090}]`),
+			jq.Match(`.spec.ingress[0].from[0] == {"podSelector": {}}`),
+			jq.Match(`.spec.ingress[0].from | any(.namespaceSelector.matchLabels."network.openshift.io/policy-group" == "ingress")`),
+			jq.Match(`.spec.ingress[0].from[-1].namespaceSelector.matchLabels == {"team": "ml"}`),
+		)),
+	))
+}
+
+func TestNetworkPolicyDefaultDeny(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = networkpolicy.NewAction(networkpolicy.WithDefaultDeny())(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(5),
+		ContainElement(And(
+			jq.Match(`.kind == "NetworkPolicy"`),
+			jq.Match(`.metadata.name == "ui%s"`, networkpolicy.DefaultDenySuffix),
+			jq.Match(`.spec.podSelector.matchLabels == {"app": "ui"}`),
+			jq.Match(`.spec.policyTypes == ["Ingress"]`),
+			jq.Match(`.spec | has("ingress") | not`),
+		)),
+	))
+}
@@ -0,0 +1,381 @@
+package oauthproxy
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	// ImageEnvVar is the environment variable the oauth-proxy image is read
+	// from, unless set with the WithImage option.
+	ImageEnvVar   = "RELATED_IMAGE_OSE_OAUTH_PROXY_IMAGE"
+	ContainerName = "oauth-proxy"
+	PortName      = "https"
+	Port          = int32(8443)
+
+	// oauthRedirectReference lets the service account of the proxy be used as
+	// an OAuth client redirecting to the given Route.
+	oauthRedirectReference = "serviceaccounts.openshift.io/oauth-redirectreference.primary"
+
+	tlsMountPath    = "/etc/tls/private"
+	cookieMountPath = "/etc/oauth/config"
+	cookieSecretKey = "cookie_secret"
+)
+
+// Action injects the OpenShift oauth-proxy as a sidecar of the rendered UI
+// Deployment of a component, so that the UI is only reachable by users
+// authenticated against the cluster OAuth server and allowed by the configured
+// SubjectAccessReview. It must be placed between the render and the deploy
+// actions, after the action exposing the UI if any.
+//
+// Besides the sidecar container, the action:
+//   - rewires the Service of the UI to the proxy port, with a serving certificate
+//   - re-encrypts the traffic of the Route of the UI, if rendered
+//   - registers the Route as OAuth redirect of the Deployment service account
+//   - generates the Secret holding the proxy cookie secret, preserved across
+//     reconciliations
+type Action struct {
+	deployment   string
+	service      string
+	route        string
+	image        string
+	upstreamPort int32
+	sar          string
+}
+
+type ActionOpts func(*Action)
+
+// WithService sets the name of the Service of the UI, defaults to the name of
+// the Deployment.
+func WithService(value string) ActionOpts {
+	return func(action *Action) {
+		action.service = value
+	}
+}
+
+// WithRoute sets the name of the Route of the UI, defaults to the name of the
+// Service.
+func WithRoute(value string) ActionOpts {
+	return func(action *Action) {
+		action.route = value
+	}
+}
+
+// WithImage sets the oauth-proxy image, defaults to the value of ImageEnvVar.
+func WithImage(value string) ActionOpts {
+	return func(action *Action) {
+		action.image = value
+	}
+}
+
+// WithUpstreamPort sets the port the UI listens on, defaults to the first
+// container port of the Deployment.
+func WithUpstreamPort(value int32) ActionOpts {
+	return func(action *Action) {
+		action.upstreamPort = value
+	}
+}
+
+// WithSAR sets the SubjectAccessReview, in JSON, users must be allowed by to
+// access the UI, defaults to being allowed to get the Service of the UI.
+func WithSAR(value string) ActionOpts {
+	return func(action *Action) {
+		action.sar = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	image := a.image
+	if image == "" {
+		image = os.Getenv(ImageEnvVar)
+	}
+	if image == "" {
+		return fmt.Errorf("unable to inject oauth-proxy: image not set, %s is empty", ImageEnvVar)
+	}
+
+	idx := slices.IndexFunc(rr.Resources, matches(gvk.Deployment, a.deployment))
+	if idx < 0 {
+		return fmt.Errorf("unable to inject oauth-proxy: deployment %s not found in the rendered resources", a.deployment)
+	}
+
+	deployment := appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rr.Resources[idx].Object, &deployment); err != nil {
+		return fmt.Errorf("unable to decode deployment %s: %w", a.deployment, err)
+	}
+
+	ns := deployment.Namespace
+
+	sa := deployment.Spec.Template.Spec.ServiceAccountName
+	if sa == "" {
+		return fmt.Errorf("unable to inject oauth-proxy: deployment %s has no service account", a.deployment)
+	}
+
+	if err := a.rewireServiceAccount(rr, sa); err != nil {
+		return err
+	}
+
+	if err := a.rewireService(rr); err != nil {
+		return err
+	}
+
+	if err := a.rewireRoute(rr); err != nil {
+		return err
+	}
+
+	cookie, err := a.cookieSecret(ctx, rr.Client, ns)
+	if err != nil {
+		return err
+	}
+
+	if err := a.inject(&deployment, image, sa, ns, cookie.Name); err != nil {
+		return err
+	}
+
+	u, err := resources.ToUnstructured(&deployment)
+	if err != nil {
+		return fmt.Errorf("unable to encode deployment %s: %w", a.deployment, err)
+	}
+
+	rr.Resources[idx] = *u
+
+	return rr.AddResources(cookie)
+}
+
+// inject adds the oauth-proxy container, and the volumes it mounts, to the pod
+// template of the given Deployment.
+func (a *Action) inject(deployment *appsv1.Deployment, image string, sa string, ns string, cookieSecret string) error {
+	spec := &deployment.Spec.Template.Spec
+
+	upstream := a.upstreamPort
+	if upstream == 0 {
+		for _, c := range spec.Containers {
+			if c.Name != ContainerName && len(c.Ports) > 0 {
+				upstream = c.Ports[0].ContainerPort
+				break
+			}
+		}
+	}
+	if upstream == 0 {
+		return fmt.Errorf("unable to find the port deployment %s listens on, set it explicitly", a.deployment)
+	}
+
+	sar := a.sar
+	if sar == "" {
+		sar = fmt.Sprintf(`{"namespace":%q,"resource":"services","resourceName":%q,"verb":"get"}`, ns, a.service)
+	}
+
+	tlsVolume := a.deployment + "-proxy-tls"
+	cookieVolume := cookieSecret
+
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   "/oauth/healthz",
+				Port:   intstr.FromString(PortName),
+				Scheme: corev1.URISchemeHTTPS,
+			},
+		},
+	}
+
+	resourceList := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("64Mi"),
+	}
+
+	container := corev1.Container{
+		Name:  ContainerName,
+		Image: image,
+		Args: []string{
+			"--https-address=:" + strconv.Itoa(int(Port)),
+			"--provider=openshift",
+			"--openshift-service-account=" + sa,
+			"--upstream=http://localhost:" + strconv.Itoa(int(upstream)),
+			"--tls-cert=" + tlsMountPath + "/" + corev1.TLSCertKey,
+			"--tls-key=" + tlsMountPath + "/" + corev1.TLSPrivateKeyKey,
+			"--cookie-secret-file=" + cookieMountPath + "/" + cookieSecretKey,
+			"--openshift-sar=" + sar,
+		},
+		Ports: []corev1.ContainerPort{{
+			Name:          PortName,
+			ContainerPort: Port,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: tlsVolume, MountPath: tlsMountPath, ReadOnly: true},
+			{Name: cookieVolume, MountPath: cookieMountPath, ReadOnly: true},
+		},
+		LivenessProbe:  probe,
+		ReadinessProbe: probe,
+		Resources: corev1.ResourceRequirements{
+			Requests: resourceList,
+			Limits:   resourceList,
+		},
+	}
+
+	spec.Containers = slices.DeleteFunc(spec.Containers, func(c corev1.Container) bool {
+		return c.Name == ContainerName
+	})
+	spec.Containers = append(spec.Containers, container)
+
+	spec.Volumes = slices.DeleteFunc(spec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == tlsVolume || v.Name == cookieVolume
+	})
+	spec.Volumes = append(spec.Volumes,
+		corev1.Volume{
+			Name: tlsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: tlsVolume},
+			},
+		},
+		corev1.Volume{
+			Name: cookieVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: cookieSecret},
+			},
+		},
+	)
+
+	return nil
+}
+
+// rewireServiceAccount registers the Route of the UI as OAuth redirect of the
+// given service account.
+func (a *Action) rewireServiceAccount(rr *types.ReconciliationRequest, name string) error {
+	idx := slices.IndexFunc(rr.Resources, matches(gvk.ServiceAccount, name))
+	if idx < 0 {
+		return fmt.Errorf("unable to inject oauth-proxy: service account %s not found in the rendered resources", name)
+	}
+
+	ref := fmt.Sprintf(`{"kind":"OAuthRedirectReference","apiVersion":"v1","reference":{"kind":"Route","name":%q}}`, a.route)
+	resources.SetAnnotation(&rr.Resources[idx], oauthRedirectReference, ref)
+
+	return nil
+}
+
+// rewireService routes the traffic of the Service of the UI to the proxy, and
+// has a serving certificate generated for it.
+func (a *Action) rewireService(rr *types.ReconciliationRequest) error {
+	idx := slices.IndexFunc(rr.Resources, matches(gvk.Service, a.service))
+	if idx < 0 {
+		return fmt.Errorf("unable to inject oauth-proxy: service %s not found in the rendered resources", a.service)
+	}
+
+	svc := &rr.Resources[idx]
+	resources.SetAnnotation(svc, annotations.ServingCertSecretName, a.deployment+"-proxy-tls")
+
+	return unstructured.SetNestedSlice(svc.Object, []any{
+		map[string]any{
+			"name":       PortName,
+			"port":       int64(Port),
+			"targetPort": PortName,
+			"protocol":   string(corev1.ProtocolTCP),
+		},
+	}, "spec", "ports")
+}
+
+// rewireRoute has the Route of the UI, if rendered, re-encrypt the traffic to
+// the proxy.
+func (a *Action) rewireRoute(rr *types.ReconciliationRequest) error {
+	idx := slices.IndexFunc(rr.Resources, matches(gvk.Route, a.route))
+	if idx < 0 {
+		return nil
+	}
+
+	route := &rr.Resources[idx]
+
+	if err := unstructured.SetNestedField(route.Object, PortName, "spec", "port", "targetPort"); err != nil {
+		return err
+	}
+
+	return unstructured.SetNestedMap(route.Object, map[string]any{
+		"termination":                   "reencrypt",
+		"insecureEdgeTerminationPolicy": "Redirect",
+	}, "spec", "tls")
+}
+
+// cookieSecret returns the Secret holding the cookie secret of the proxy, the
+// value is generated once and then read back from the cluster so that sessions
+// survive reconciliations.
+func (a *Action) cookieSecret(ctx context.Context, cli client.Client, ns string) (*corev1.Secret, error) {
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.deployment + "-oauth-config",
+			Namespace: ns,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+
+	live := corev1.Secret{}
+
+	err := cli.Get(ctx, client.ObjectKeyFromObject(&secret), &live)
+	switch {
+	case err == nil && len(live.Data[cookieSecretKey]) > 0:
+		secret.Data = map[string][]byte{cookieSecretKey: live.Data[cookieSecretKey]}
+		return &secret, nil
+	case err != nil && !k8serr.IsNotFound(err):
+		return nil, fmt.Errorf("unable to get secret %s: %w", secret.Name, err)
+	}
+
+	value := make([]byte, 32)
+	if _, err := rand.Read(value); err != nil {
+		return nil, fmt.Errorf("unable to generate cookie secret: %w", err)
+	}
+
+	secret.Data = map[string][]byte{
+		cookieSecretKey: []byte(base64.StdEncoding.EncodeToString(value)),
+	}
+
+	return &secret, nil
+}
+
+func matches(kind schema.GroupVersionKind, name string) func(unstructured.Unstructured) bool {
+	return func(u unstructured.Unstructured) bool {
+		return u.GroupVersionKind() == kind && u.GetName() == name
+	}
+}
+
+// NewAction creates an action injecting the oauth-proxy in the given Deployment.
+func NewAction(deployment string, opts ...ActionOpts) actions.Fn {
+	action := Action{
+		deployment: deployment,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	if action.service == "" {
+		action.service = action.deployment
+	}
+	if action.route == "" {
+		action.route = action.service
+	}
+
+	return action.run
+}
@@ -0,0 +1,168 @@
+package oauthproxy_test
+
+import (
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/oauthproxy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	proxyNamespace = "opendatahub"
+	proxyName      = "ui"
+	proxyImage     = "quay.io/openshift/oauth-proxy:latest"
+)
+
+func newResources() []client.Object {
+	meta := metav1.ObjectMeta{
+		Name:      proxyName,
+		Namespace: proxyNamespace,
+	}
+
+	return []client.Object{
+		&corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: meta,
+		},
+		&corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: meta,
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+			},
+		},
+		&routev1.Route{
+			TypeMeta:   metav1.TypeMeta{APIVersion: routev1.GroupVersion.String(), Kind: "Route"},
+			ObjectMeta: meta,
+			Spec: routev1.RouteSpec{
+				TLS: &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+			},
+		},
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+			ObjectMeta: meta,
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						ServiceAccountName: proxyName,
+						Containers: []corev1.Container{{
+							Name:  "ui",
+							Ports: []corev1.ContainerPort{{ContainerPort: 3000}},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestOAuthProxyInject(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = oauthproxy.NewAction(proxyName, oauthproxy.WithImage(proxyImage))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(5),
+		ContainElement(And(
+			jq.Match(`.kind == "Deployment"`),
+			jq.Match(`.spec.template.spec.containers | length == 2`),
+			jq.Match(`.spec.template.spec.containers[1].name == "%s"`, oauthproxy.ContainerName),
+			jq.Match(`.spec.template.spec.containers[1].image == "%s"`, proxyImage),
+			jq.Match(`.spec.template.spec.containers[1].args | index("--upstream=http://localhost:3000") != null`),
+			jq.Match(`.spec.template.spec.containers[1].args | index("--openshift-service-account=%s") != null`, proxyName),
+			jq.Match(`[.spec.template.spec.volumes[].secret.secretName] == ["ui-proxy-tls", "ui-oauth-config"]`),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "Service"`),
+			jq.Match(`.metadata.annotations."%s" == "ui-proxy-tls"`, annotations.ServingCertSecretName),
+			jq.Match(`.spec.ports == [{"name": "https", "port": 8443, "targetPort": "https", "protocol": "TCP"}]`),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "Route"`),
+			jq.Match(`.spec.port.targetPort == "https"`),
+			jq.Match(`.spec.tls.termination == "reencrypt"`),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "ServiceAccount"`),
+			jq.Match(`.metadata.annotations."serviceaccounts.openshift.io/oauth-redirectreference.primary" | fromjson | .reference.name == "%s"`, proxyName),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "Secret"`),
+			jq.Match(`.metadata.name == "ui-oauth-config"`),
+			jq.Match(`.data.cookie_secret | length > 0`),
+		)),
+	))
+}
+
+func TestOAuthProxyCookieSecretPreserved(t *testing.T) {
+	g := NewWithT(t)
+
+	live := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ui-oauth-config",
+			Namespace: proxyNamespace,
+		},
+		Data: map[string][]byte{
+			"cookie_secret": []byte("secret"),
+		},
+	}
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithObjects(&live)),
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	t.Setenv(oauthproxy.ImageEnvVar, proxyImage)
+
+	err = oauthproxy.NewAction(proxyName)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(ContainElement(And(
+		jq.Match(`.kind == "Secret"`),
+		jq.Match(`.data.cookie_secret == "c2VjcmV0"`),
+	)))
+}
+
+func TestOAuthProxyMissingImage(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	t.Setenv(oauthproxy.ImageEnvVar, "")
+
+	err = oauthproxy.NewAction(proxyName)(t.Context(), rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("image not set")))
+}
+
+func TestOAuthProxyMissingDeployment(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newResources()...),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = oauthproxy.NewAction("missing", oauthproxy.WithImage(proxyImage))(t.Context(), rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("deployment missing not found")))
+}
@@ -0,0 +1,283 @@
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	odhLabels "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// OwnerTypesFn computes the types of the owners whose orphaned resources should
+// be collected.
+type OwnerTypesFn func(context.Context, *odhTypes.ReconciliationRequest) ([]schema.GroupVersionKind, error)
+
+type ActionOpts func(*Action)
+
+// Action deletes cluster scoped resources deployed on behalf of an owner that no
+// longer exists.
+//
+// Owner references can't be used to garbage collect those resources in all the
+// cases, hence the action relies on the platform.opendatahub.io/instance.uid and
+// platform.opendatahub.io/part-of labels set by the deploy action: a resource is
+// considered orphaned if it is part of one of the configured owner types and no
+// owner with the recorded UID exists anymore.
+//
+// Only the cluster scoped types the operator deploys are inspected, see
+// DefaultTypes and WithTypes, and they are listed through the client cache
+// the component controllers already maintain to watch them.
+type Action struct {
+	ownerTypesFn      OwnerTypesFn
+	types             []schema.GroupVersionKind
+	unremovables      map[schema.GroupVersionKind]struct{}
+	propagationPolicy client.PropagationPolicy
+}
+
+// DefaultTypes are the cluster scoped types the components deploy, and the
+// action inspects unless WithTypes is set.
+var DefaultTypes = []schema.GroupVersionKind{
+	gvk.ClusterRole,
+	gvk.ClusterRoleBinding,
+}
+
+func WithOwnerTypes(items ...schema.GroupVersionKind) ActionOpts {
+	return func(action *Action) {
+		action.ownerTypesFn = func(_ context.Context, _ *odhTypes.ReconciliationRequest) ([]schema.GroupVersionKind, error) {
+			return items, nil
+		}
+	}
+}
+
+func WithOwnerTypesFn(fn OwnerTypesFn) ActionOpts {
+	return func(action *Action) {
+		if fn == nil {
+			return
+		}
+
+		action.ownerTypesFn = fn
+	}
+}
+
+func WithUnremovables(items ...schema.GroupVersionKind) ActionOpts {
+	return func(action *Action) {
+		for _, item := range items {
+			action.unremovables[item] = struct{}{}
+		}
+	}
+}
+
+// WithTypes sets the cluster scoped types inspected for orphaned resources,
+// replacing DefaultTypes. Types should be watched by the operator, as they are
+// listed through the client cache.
+func WithTypes(items ...schema.GroupVersionKind) ActionOpts {
+	return func(action *Action) {
+		action.types = items
+	}
+}
+
+func WithDeletePropagationPolicy(policy metav1.DeletionPropagation) ActionOpts {
+	return func(action *Action) {
+		action.propagationPolicy = client.PropagationPolicy(policy)
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) error {
+	// Same as the GC action, run it only when resources have been
+	// generated to avoid expensive lookups on each reconciliation
+	if !rr.Generated {
+		return nil
+	}
+
+	ownerTypes, err := a.ownerTypesFn(ctx, rr)
+	if err != nil {
+		return fmt.Errorf("unable to compute owner types: %w", err)
+	}
+
+	if len(ownerTypes) == 0 {
+		return nil
+	}
+
+	owners, partOf, err := a.listOwners(ctx, rr.Client, ownerTypes)
+	if err != nil {
+		return err
+	}
+
+	selector, err := computeSelector(partOf)
+	if err != nil {
+		return err
+	}
+
+	controllerName := strings.ToLower(rr.Instance.GetObjectKind().GroupVersionKind().Kind)
+
+	logf.FromContext(ctx).V(3).Info("run", "selector", selector.String())
+
+	for _, t := range a.types {
+		if _, ok := a.unremovables[t]; ok {
+			continue
+		}
+
+		objects, err := list(ctx, rr.Client, t, selector)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range objects {
+			if !isOrphan(obj, owners) {
+				continue
+			}
+
+			if err := a.delete(ctx, rr.Client, t, obj); err != nil {
+				return err
+			}
+
+			DeletedTotal.WithLabelValues(controllerName).Inc()
+		}
+	}
+
+	return nil
+}
+
+// list returns the resources of the given type matching the given selector,
+// using a typed list so that the cache of the watched type is used.
+func list(ctx context.Context, cli client.Client, t schema.GroupVersionKind, selector labels.Selector) ([]client.Object, error) {
+	ro, err := cli.Scheme().New(t.GroupVersion().WithKind(t.Kind + "List"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create list for type %s: %w", t, err)
+	}
+
+	ol, ok := ro.(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a list", t)
+	}
+
+	err = cli.List(ctx, ol, client.MatchingLabelsSelector{Selector: selector})
+	switch {
+	case meta.IsNoMatchError(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot list resources %s: %w", t, err)
+	}
+
+	items, err := meta.ExtractList(ol)
+	if err != nil {
+		return nil, fmt.Errorf("cannot extract resources %s: %w", t, err)
+	}
+
+	result := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok {
+			result = append(result, obj)
+		}
+	}
+
+	return result, nil
+}
+
+func (a *Action) listOwners(
+	ctx context.Context,
+	cli client.Client,
+	ownerTypes []schema.GroupVersionKind,
+) (sets.Set[string], []string, error) {
+	owners := sets.New[string]()
+	partOf := make([]string, 0, len(ownerTypes))
+
+	for _, ot := range ownerTypes {
+		items := metav1.PartialObjectMetadataList{}
+		items.SetGroupVersionKind(ot.GroupVersion().WithKind(ot.Kind + "List"))
+
+		err := cli.List(ctx, &items)
+		switch {
+		case meta.IsNoMatchError(err):
+			// the owner API is not available, hence all the resources
+			// would be considered orphans. As this is most likely an
+			// unexpected condition, skip the owner type altogether
+			continue
+		case err != nil:
+			return nil, nil, fmt.Errorf("cannot list owners of type %s: %w", ot, err)
+		}
+
+		for i := range items.Items {
+			owners.Insert(string(items.Items[i].GetUID()))
+		}
+
+		partOf = append(partOf, strings.ToLower(ot.Kind))
+	}
+
+	return owners, partOf, nil
+}
+
+func (a *Action) delete(ctx context.Context, cli client.Client, t schema.GroupVersionKind, obj client.Object) error {
+	logf.FromContext(ctx).Info(
+		"delete orphan",
+		"gvk", t,
+		"name", obj.GetName(),
+		"owner", resources.GetLabel(obj, odhLabels.PlatformInstanceUID),
+	)
+
+	err := cli.Delete(ctx, obj, a.propagationPolicy)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return fmt.Errorf("cannot delete orphan resource %s %s: %w", t, obj.GetName(), err)
+	}
+
+	return nil
+}
+
+func isOrphan(obj client.Object, owners sets.Set[string]) bool {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return false
+	}
+	if resources.HasAnnotation(obj, annotations.ManagedByODHOperator, "false") {
+		return false
+	}
+
+	return !owners.Has(resources.GetLabel(obj, odhLabels.PlatformInstanceUID))
+}
+
+func computeSelector(partOf []string) (labels.Selector, error) {
+	uid, err := labels.NewRequirement(odhLabels.PlatformInstanceUID, selection.Exists, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute selector: %w", err)
+	}
+
+	po, err := labels.NewRequirement(odhLabels.PlatformPartOf, selection.In, partOf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute selector: %w", err)
+	}
+
+	return labels.NewSelector().Add(*uid, *po), nil
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		ownerTypesFn: func(_ context.Context, _ *odhTypes.ReconciliationRequest) ([]schema.GroupVersionKind, error) {
+			return nil, nil
+		},
+		types:             DefaultTypes,
+		unremovables:      map[schema.GroupVersionKind]struct{}{},
+		propagationPolicy: client.PropagationPolicy(metav1.DeletePropagationForeground),
+	}
+
+	// default unremovables
+	action.unremovables[gvk.CustomResourceDefinition] = struct{}{}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
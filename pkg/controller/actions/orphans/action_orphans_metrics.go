@@ -0,0 +1,29 @@
+package orphans
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// DeletedTotal is a prometheus counter metrics which holds the total number
+	// of orphaned resource deleted by the action per controller. It has one label.
+	// controller label refers to the controller name.
+	DeletedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "action_orphans_deleted_total",
+			Help: "Number of deleted orphaned resources",
+		},
+		[]string{
+			"controller",
+		},
+	)
+)
+
+// init register metrics to the global registry from controller-runtime/pkg/metrics.
+// see https://book.kubebuilder.io/reference/metrics#publishing-additional-metrics
+//
+//nolint:gochecknoinits
+func init() {
+	metrics.Registry.MustRegister(DeletedTotal)
+}
@@ -0,0 +1,124 @@
+package orphans_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/xid"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apytypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/orphans"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func newClusterRole(name string, partOf string, uid apytypes.UID) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				labels.PlatformPartOf:      partOf,
+				labels.PlatformInstanceUID: string(uid),
+			},
+		},
+	}
+}
+
+func TestOrphansAction(t *testing.T) {
+	dashboard := &componentApi.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: componentApi.DashboardInstanceName,
+			UID:  apytypes.UID(xid.New().String()),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		opts    []orphans.ActionOpts
+		deleted bool
+	}{
+		{
+			name:    "default types",
+			deleted: true,
+		},
+		{
+			name:    "not an inspected type",
+			opts:    []orphans.ActionOpts{orphans.WithTypes(gvk.ClusterRoleBinding)},
+			deleted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			live := newClusterRole("live", "dashboard", dashboard.UID)
+			orphan := newClusterRole("orphan", "dashboard", apytypes.UID(xid.New().String()))
+			foreign := newClusterRole("foreign", "monitoring", apytypes.UID(xid.New().String()))
+
+			cl, err := fakeclient.New(
+				fakeclient.WithObjects(dashboard.DeepCopy(), live, orphan, foreign),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			opts := append([]orphans.ActionOpts{orphans.WithOwnerTypes(gvk.Dashboard)}, tt.opts...)
+
+			orphans.DeletedTotal.Reset()
+
+			err = orphans.NewAction(opts...)(ctx, &types.ReconciliationRequest{
+				Client: cl,
+				Instance: &dscv2.DataScienceCluster{
+					TypeMeta: metav1.TypeMeta{Kind: gvk.DataScienceCluster.Kind},
+				},
+				Generated: true,
+			})
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(live), &rbacv1.ClusterRole{})).
+				Should(Succeed())
+			g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(foreign), &rbacv1.ClusterRole{})).
+				Should(Succeed())
+
+			if tt.deleted {
+				g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(orphan), &rbacv1.ClusterRole{})).
+					Should(WithTransform(k8serr.IsNotFound, BeTrue()))
+				g.Expect(testutil.ToFloat64(orphans.DeletedTotal)).Should(BeNumerically("==", 1))
+			} else {
+				g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(orphan), &rbacv1.ClusterRole{})).
+					Should(Succeed())
+			}
+		})
+	}
+}
+
+func TestOrphansActionNotGenerated(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	action := orphans.NewAction(
+		orphans.WithOwnerTypesFn(func(_ context.Context, _ *types.ReconciliationRequest) ([]schema.GroupVersionKind, error) {
+			t.Fatal("owner types should not be computed when no resources have been generated")
+			return nil, nil
+		}),
+	)
+
+	err := action(ctx, &types.ReconciliationRequest{Generated: false})
+	g.Expect(err).ShouldNot(HaveOccurred())
+}
@@ -0,0 +1,106 @@
+package pinnedsecrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// Action pins the values of the rendered Secrets annotated with
+// annotations.PinnedData: the keys of such Secrets which already exist in the
+// cluster keep their stored value instead of the rendered one, so that values
+// generated on the first rendering are not rotated, restarting the workloads, on
+// every reconciliation. Keys not yet stored get the rendered value, keys no longer
+// rendered are dropped. It must be placed between the render and the deploy
+// actions.
+type Action struct{}
+
+type ActionOpts func(*Action)
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	for i := range rr.Resources {
+		obj := &rr.Resources[i]
+
+		if obj.GroupVersionKind() != gvk.Secret {
+			continue
+		}
+		if pinned, _ := strconv.ParseBool(resources.GetAnnotation(obj, annotations.PinnedData)); !pinned {
+			continue
+		}
+
+		if err := pin(ctx, rr.Client, obj); err != nil {
+			return fmt.Errorf("unable to pin the data of secret %s: %w", resources.FormatObjectReference(obj), err)
+		}
+	}
+
+	return nil
+}
+
+func pin(ctx context.Context, cli client.Client, obj *unstructured.Unstructured) error {
+	live := corev1.Secret{}
+
+	err := cli.Get(ctx, client.ObjectKeyFromObject(obj), &live)
+	if err != nil {
+		// nothing stored yet, the rendered values are the ones to be pinned
+		return client.IgnoreNotFound(err)
+	}
+
+	data, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		return err
+	}
+
+	stringData, _, err := unstructured.NestedStringMap(obj.Object, "stringData")
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		data = map[string]string{}
+	}
+
+	for k, v := range live.Data {
+		_, inData := data[k]
+		_, inStringData := stringData[k]
+		if !inData && !inStringData {
+			continue
+		}
+
+		data[k] = base64.StdEncoding.EncodeToString(v)
+		delete(stringData, k)
+	}
+
+	if len(data) > 0 {
+		if err := unstructured.SetNestedStringMap(obj.Object, data, "data"); err != nil {
+			return err
+		}
+	}
+
+	if len(stringData) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "stringData")
+		return nil
+	}
+
+	return unstructured.SetNestedStringMap(obj.Object, stringData, "stringData")
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
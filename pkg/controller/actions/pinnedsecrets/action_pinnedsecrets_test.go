@@ -0,0 +1,98 @@
+package pinnedsecrets_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/pinnedsecrets"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func newSecret(name string, pinned bool) *corev1.Secret {
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "opendatahub",
+		},
+		StringData: map[string]string{
+			"user":     "admin",
+			"password": "generated",
+		},
+	}
+
+	if pinned {
+		secret.Annotations = map[string]string{annotations.PinnedData: "true"}
+	}
+
+	return &secret
+}
+
+func TestPinnedSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	live := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pinned", Namespace: "opendatahub"},
+			Data: map[string][]byte{
+				"password": []byte("stored"),
+				"token":    []byte("no longer rendered"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unpinned", Namespace: "opendatahub"},
+			Data: map[string][]byte{
+				"password": []byte("stored"),
+			},
+		},
+	}
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(live[0], live[1]))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client:   cl,
+		Instance: &componentApi.Dashboard{},
+	}
+
+	err = rr.AddResources(
+		newSecret("pinned", true),
+		newSecret("unpinned", false),
+		newSecret("new", true),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = pinnedsecrets.NewAction()(t.Context(), &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	stored := base64.StdEncoding.EncodeToString([]byte("stored"))
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(3),
+		ContainElement(And(
+			jq.Match(`.metadata.name == "pinned"`),
+			jq.Match(`.data == {"password": "%s"}`, stored),
+			jq.Match(`.stringData == {"user": "admin"}`),
+		)),
+		ContainElement(And(
+			jq.Match(`.metadata.name == "unpinned"`),
+			jq.Match(`has("data") | not`),
+			jq.Match(`.stringData.password == "generated"`),
+		)),
+		// not stored yet, the generated values are kept
+		ContainElement(And(
+			jq.Match(`.metadata.name == "new"`),
+			jq.Match(`has("data") | not`),
+			jq.Match(`.stringData.password == "generated"`),
+		)),
+	))
+}
@@ -0,0 +1,292 @@
+package plan
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+)
+
+const (
+	DefaultRequeueAfter = 30 * time.Second
+
+	// PlanKey is the key of the plan ConfigMap holding the planned changes.
+	PlanKey = "plan.yaml"
+	// HashKey is the key of the plan ConfigMap holding the hash to approve.
+	HashKey = "hash"
+)
+
+// Change is a change the deploy action is going to make to a resource. For
+// updates, Fields lists the paths of the fields that differ from the live
+// resource, values are omitted as they may be sensitive.
+type Change struct {
+	Operation  Operation `json:"operation"`
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	Fields     []string  `json:"fields,omitempty"`
+}
+
+// Action gates the deployment of the resources of a component behind a human
+// approval, when the component CR has the annotations.ApprovalRequired annotation
+// set to true. It must be placed right before the deploy action.
+//
+// The action computes the changes the rendered resources would make to the
+// cluster and publishes them, along with a hash of the rendered resources, in a
+// ConfigMap named after the component. Until the annotations.ApprovedPlan
+// annotation of the component CR is set to that hash, the remaining actions are
+// skipped and the request is requeued. Any change to the rendered resources
+// results in a new hash, and so requires a new approval.
+type Action struct {
+	namespace    string
+	requeueAfter time.Duration
+}
+
+type ActionOpts func(*Action)
+
+// WithNamespace sets the namespace of the plan ConfigMap, defaults to the
+// applications namespace.
+func WithNamespace(value string) ActionOpts {
+	return func(action *Action) {
+		action.namespace = value
+	}
+}
+
+// WithRequeueAfter sets the delay after which the request is requeued while
+// waiting for the plan to be approved.
+func WithRequeueAfter(value time.Duration) ActionOpts {
+	return func(action *Action) {
+		action.requeueAfter = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	if resources.GetAnnotation(rr.Instance, annotations.ApprovalRequired) != "true" {
+		return nil
+	}
+
+	hash, err := Hash(rr.Resources)
+	if err != nil {
+		return fmt.Errorf("unable to compute the plan hash: %w", err)
+	}
+
+	if resources.GetAnnotation(rr.Instance, annotations.ApprovedPlan) == hash {
+		return nil
+	}
+
+	changes, err := Compute(ctx, rr)
+	if err != nil {
+		return fmt.Errorf("unable to compute the plan: %w", err)
+	}
+
+	cm, err := a.publish(ctx, rr, hash, changes)
+	if err != nil {
+		return fmt.Errorf("unable to publish the plan: %w", err)
+	}
+
+	return odherrors.NewWaitError(a.requeueAfter,
+		"waiting for plan %s (%d changes) to be approved, see ConfigMap %s/%s",
+		hash, len(changes), cm.Namespace, cm.Name)
+}
+
+func (a *Action) publish(ctx context.Context, rr *types.ReconciliationRequest, hash string, changes []Change) (*corev1.ConfigMap, error) {
+	kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := a.namespace
+	if ns == "" && rr.DSCI != nil {
+		ns = rr.DSCI.Spec.ApplicationsNamespace
+	}
+
+	data, err := yaml.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.ToLower(kind) + "-" + rr.Instance.GetName() + "-plan",
+			Namespace: ns,
+		},
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, rr.Client, &cm, func() error {
+		cm.Data = map[string]string{
+			HashKey: hash,
+			PlanKey: string(data),
+		}
+
+		return controllerutil.SetOwnerReference(rr.Instance, &cm, rr.Client.Scheme())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cm, nil
+}
+
+// Hash returns a hash of the given resources, excluding hooks, that does not
+// depend on their order.
+func Hash(in []unstructured.Unstructured) (string, error) {
+	docs := make([]string, 0, len(in))
+
+	for i := range in {
+		if resources.GetAnnotation(&in[i], annotations.Hook) != "" {
+			continue
+		}
+
+		data, err := in[i].MarshalJSON()
+		if err != nil {
+			return "", err
+		}
+
+		docs = append(docs, string(data))
+	}
+
+	slices.Sort(docs)
+
+	hash := sha256.New()
+	for _, d := range docs {
+		if _, err := hash.Write([]byte(d)); err != nil {
+			return "", err
+		}
+	}
+
+	return resources.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Compute returns the changes deploying the resources of the given request would
+// make: resources to be created, resources whose live state differs from the
+// rendered one, and resources deployed by the previous reconciliation that are
+// no longer rendered, so would be garbage collected.
+func Compute(ctx context.Context, rr *types.ReconciliationRequest) ([]Change, error) {
+	changes := make([]Change, 0)
+	rendered := make(map[string]struct{}, len(rr.Resources))
+
+	for i := range rr.Resources {
+		res := &rr.Resources[i]
+
+		if resources.GetAnnotation(res, annotations.Hook) != "" {
+			continue
+		}
+
+		rendered[resources.FormatObjectReference(res)] = struct{}{}
+
+		change := Change{
+			APIVersion: res.GetAPIVersion(),
+			Kind:       res.GetKind(),
+			Namespace:  res.GetNamespace(),
+			Name:       res.GetName(),
+		}
+
+		current := resources.GvkToUnstructured(res.GroupVersionKind())
+
+		err := rr.Client.Get(ctx, client.ObjectKeyFromObject(res), current)
+		switch {
+		case k8serr.IsNotFound(err):
+			change.Operation = OperationCreate
+		case err != nil:
+			return nil, fmt.Errorf("failed to lookup object %s: %w", resources.FormatObjectReference(res), err)
+		default:
+			change.Operation = OperationUpdate
+			change.Fields = diff(res.Object, current.Object, nil)
+		}
+
+		if change.Operation == OperationUpdate && len(change.Fields) == 0 {
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	for _, d := range rr.Instance.GetStatus().DeployedResources {
+		obj := resources.GvkToUnstructured(schema.GroupVersionKind{Group: d.Group, Version: d.Version, Kind: d.Kind})
+		obj.SetNamespace(d.Namespace)
+		obj.SetName(d.Name)
+
+		if _, ok := rendered[resources.FormatObjectReference(obj)]; ok {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Operation:  OperationDelete,
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       d.Kind,
+			Namespace:  d.Namespace,
+			Name:       d.Name,
+		})
+	}
+
+	return changes, nil
+}
+
+// diff returns the paths of the fields of desired whose value differs in live.
+// Fields only set in live, i.e. defaulted by the API server, are ignored, lists
+// are compared as a whole.
+func diff(desired map[string]any, live map[string]any, path []string) []string {
+	fields := make([]string, 0)
+
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		p := append(slices.Clone(path), k)
+
+		dm, dok := desired[k].(map[string]any)
+		lm, lok := live[k].(map[string]any)
+
+		switch {
+		case dok && lok:
+			fields = append(fields, diff(dm, lm, p)...)
+		case !reflect.DeepEqual(desired[k], live[k]):
+			fields = append(fields, strings.Join(p, "."))
+		}
+	}
+
+	return fields
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		requeueAfter: DefaultRequeueAfter,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
@@ -0,0 +1,146 @@
+package plan_test
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/plan"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+const ns = "opendatahub"
+
+func configMap(name string, value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Data:       map[string]string{"key": value},
+	}
+}
+
+func newInstance(instanceAnnotations map[string]string) *componentApi.Dashboard {
+	return &componentApi.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        componentApi.DashboardInstanceName,
+			Annotations: instanceAnnotations,
+		},
+		Status: componentApi.DashboardStatus{
+			Status: common.Status{
+				DeployedResources: []common.DeployedResource{
+					{Version: "v1", Kind: "ConfigMap", Namespace: ns, Name: "changed"},
+					{Version: "v1", Kind: "ConfigMap", Namespace: ns, Name: "removed"},
+				},
+			},
+		},
+	}
+}
+
+func newDSCI() *dsciv2.DSCInitialization {
+	return &dsciv2.DSCInitialization{
+		Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: ns},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(
+		configMap("unchanged", "a"),
+		configMap("changed", "a"),
+	))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClient(cl),
+		fakerequest.WithInstance(newInstance(map[string]string{annotations.ApprovalRequired: "true"})),
+		fakerequest.WithDSCI(newDSCI()),
+		fakerequest.WithResources(
+			configMap("unchanged", "a"),
+			configMap("changed", "b"),
+			configMap("created", "c"),
+		),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	hash, err := plan.Hash(rr.Resources)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = plan.NewAction()(ctx, rr)
+
+	we := odherrors.WaitError{}
+	g.Expect(errors.As(err, &we)).Should(BeTrue())
+	g.Expect(err).Should(MatchError(ContainSubstring(hash)))
+
+	cm := corev1.ConfigMap{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: "dashboard-default-dashboard-plan"}, &cm)).Should(Succeed())
+	g.Expect(cm.Data).Should(HaveKeyWithValue(plan.HashKey, hash))
+
+	changes, err := plan.Compute(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changes).Should(ConsistOf(
+		plan.Change{Operation: plan.OperationUpdate, APIVersion: "v1", Kind: "ConfigMap", Namespace: ns, Name: "changed", Fields: []string{"data.key"}},
+		plan.Change{Operation: plan.OperationCreate, APIVersion: "v1", Kind: "ConfigMap", Namespace: ns, Name: "created"},
+		plan.Change{Operation: plan.OperationDelete, APIVersion: "v1", Kind: "ConfigMap", Namespace: ns, Name: "removed"},
+	))
+}
+
+func TestPlanApproved(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(newInstance(nil)),
+		fakerequest.WithDSCI(newDSCI()),
+		fakerequest.WithResources(
+			configMap("unchanged", "a"),
+			configMap("changed", "b"),
+			configMap("created", "c"),
+		),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	hash, err := plan.Hash(rr.Resources)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	resources.SetAnnotation(rr.Instance, annotations.ApprovalRequired, "true")
+	resources.SetAnnotation(rr.Instance, annotations.ApprovedPlan, hash)
+
+	g.Expect(plan.NewAction()(ctx, rr)).Should(Succeed())
+
+	// a change to the rendered resources requires a new approval
+	rr.Resources[0].Object["data"] = map[string]any{"key": "z"}
+	g.Expect(plan.NewAction()(ctx, rr)).ShouldNot(Succeed())
+}
+
+func TestPlanNotRequired(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(newInstance(nil)),
+		fakerequest.WithDSCI(newDSCI()),
+		fakerequest.WithResources(
+			configMap("unchanged", "a"),
+			configMap("changed", "b"),
+			configMap("created", "c"),
+		),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	rr.Resources = append(rr.Resources, unstructured.Unstructured{})
+
+	g.Expect(plan.NewAction()(t.Context(), rr)).Should(Succeed())
+}
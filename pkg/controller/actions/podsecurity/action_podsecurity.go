@@ -0,0 +1,160 @@
+package podsecurity
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+var (
+	// podFields are the pod securityContext fields pinning IDs, which the
+	// restricted-v2 SCC assigns from the namespace ranges instead.
+	podFields = []string{"runAsUser", "runAsGroup", "fsGroup", "supplementalGroups"}
+
+	// containerFields are the container securityContext fields pinning IDs.
+	containerFields = []string{"runAsUser", "runAsGroup"}
+)
+
+// Action adjusts the pod templates of the rendered workloads so that they are
+// admitted by the OpenShift restricted-v2 SCC: the fixed user and group IDs set
+// in the securityContext of pods and containers are removed, letting OpenShift
+// pick them from the range allocated to the namespace. It must be placed between
+// the render and the deploy actions of the components whose manifests hard-code
+// such IDs.
+type Action struct {
+	restrictedDefaults bool
+}
+
+type ActionOpts func(*Action)
+
+// WithRestrictedDefaults additionally sets, when not already set, the fields
+// required by the restricted Pod Security Standard: runAsNonRoot, a RuntimeDefault
+// seccomp profile, no privilege escalation and all capabilities dropped.
+func WithRestrictedDefaults() ActionOpts {
+	return func(action *Action) {
+		action.restrictedDefaults = true
+	}
+}
+
+func (a *Action) run(_ context.Context, rr *types.ReconciliationRequest) error {
+	for i := range rr.Resources {
+		if err := a.transform(&rr.Resources[i]); err != nil {
+			return fmt.Errorf("unable to adjust security context of %s: %w",
+				resources.FormatObjectReference(&rr.Resources[i]), err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Action) transform(obj *unstructured.Unstructured) error {
+	path, ok := resources.PodSpecPath(obj.GroupVersionKind().GroupKind())
+	if !ok {
+		return nil
+	}
+
+	spec, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil || !found {
+		return err
+	}
+
+	sc, found, err := unstructured.NestedMap(spec, "securityContext")
+	if err != nil {
+		return err
+	}
+	if !found {
+		sc = map[string]any{}
+	}
+
+	for _, f := range podFields {
+		delete(sc, f)
+	}
+
+	if a.restrictedDefaults {
+		setDefault(sc, true, "runAsNonRoot")
+		setDefault(sc, "RuntimeDefault", "seccompProfile", "type")
+	}
+
+	if err := setOrRemove(spec, sc, "securityContext"); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, err := unstructured.NestedSlice(spec, field)
+		if err != nil {
+			return err
+		}
+
+		for i := range containers {
+			c, ok := containers[i].(map[string]any)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for %s[%d]", containers[i], field, i)
+			}
+
+			csc, found, err := unstructured.NestedMap(c, "securityContext")
+			if err != nil {
+				return err
+			}
+			if !found {
+				csc = map[string]any{}
+			}
+
+			for _, f := range containerFields {
+				delete(csc, f)
+			}
+
+			if a.restrictedDefaults {
+				setDefault(csc, false, "allowPrivilegeEscalation")
+				setDefault(csc, []any{"ALL"}, "capabilities", "drop")
+			}
+
+			if err := setOrRemove(c, csc, "securityContext"); err != nil {
+				return err
+			}
+		}
+
+		if len(containers) > 0 {
+			if err := unstructured.SetNestedSlice(spec, containers, field); err != nil {
+				return err
+			}
+		}
+	}
+
+	return unstructured.SetNestedMap(obj.Object, spec, path...)
+}
+
+// setDefault sets the given field of the map, which must not be nil, if it is
+// not already set.
+func setDefault(m map[string]any, value any, fields ...string) {
+	if _, found, _ := unstructured.NestedFieldNoCopy(m, fields...); found {
+		return
+	}
+
+	_ = unstructured.SetNestedField(m, value, fields...)
+}
+
+// setOrRemove sets the given field of the map to value, or removes it if value
+// is empty.
+func setOrRemove(m map[string]any, value map[string]any, field string) error {
+	if len(value) == 0 {
+		unstructured.RemoveNestedField(m, field)
+		return nil
+	}
+
+	return unstructured.SetNestedMap(m, value, field)
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
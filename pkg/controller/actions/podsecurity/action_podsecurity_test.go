@@ -0,0 +1,111 @@
+package podsecurity_test
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/podsecurity"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+
+	. "github.com/onsi/gomega"
+)
+
+func newStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "ns",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsUser:  ptr.To[int64](1001),
+						FSGroup:    ptr.To[int64](1001),
+						RunAsGroup: ptr.To[int64](1001),
+					},
+					InitContainers: []corev1.Container{{
+						Name: "init",
+					}},
+					Containers: []corev1.Container{{
+						Name: "db",
+						SecurityContext: &corev1.SecurityContext{
+							RunAsUser:                ptr.To[int64](1001),
+							AllowPrivilegeEscalation: ptr.To(true),
+							ReadOnlyRootFilesystem:   ptr.To(true),
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func render(t *testing.T, opts ...podsecurity.ActionOpts) *appsv1.StatefulSet {
+	t.Helper()
+
+	g := NewWithT(t)
+
+	u, err := resources.ToUnstructured(newStatefulSet())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Resources: []unstructured.Unstructured{*u},
+	}
+
+	err = podsecurity.NewAction(opts...)(t.Context(), &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	sts := appsv1.StatefulSet{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(rr.Resources[0].Object, &sts)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return &sts
+}
+
+func TestPodSecurityStripIDs(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := render(t).Spec.Template.Spec
+
+	g.Expect(spec.SecurityContext).Should(BeNil())
+	g.Expect(spec.InitContainers[0].SecurityContext).Should(BeNil())
+	g.Expect(spec.Containers[0].SecurityContext).Should(Equal(&corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(true),
+		ReadOnlyRootFilesystem:   ptr.To(true),
+	}))
+}
+
+func TestPodSecurityRestrictedDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := render(t, podsecurity.WithRestrictedDefaults()).Spec.Template.Spec
+
+	g.Expect(spec.SecurityContext).Should(Equal(&corev1.PodSecurityContext{
+		RunAsNonRoot:   ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}))
+
+	g.Expect(spec.InitContainers[0].SecurityContext).Should(Equal(&corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}))
+
+	// explicitly set values are preserved
+	g.Expect(spec.Containers[0].SecurityContext).Should(Equal(&corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(true),
+		ReadOnlyRootFilesystem:   ptr.To(true),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}))
+}
@@ -0,0 +1,143 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// EventReasonPolicyViolation is the reason of the warning event recorded on the
+// reconciled instance when a rendered resource violates a policy in warn mode.
+const EventReasonPolicyViolation = "PolicyViolation"
+
+type Enforcement string
+
+const (
+	// Warn records violations as events on the reconciled instance.
+	Warn Enforcement = "warn"
+	// Deny rejects the rendered resources with a ValidationError, so none of
+	// them is deployed.
+	Deny Enforcement = "deny"
+)
+
+// Profile is a named set of rules, together with the way their violations are
+// enforced.
+type Profile struct {
+	Name        string
+	Enforcement Enforcement
+	Rules       []Rule
+}
+
+var (
+	// DefaultProfile reports violations of the built-in rules without blocking
+	// the deployment.
+	DefaultProfile = Profile{
+		Name:        "default",
+		Enforcement: Warn,
+		Rules:       BuiltinRules(),
+	}
+
+	// HardenedProfile rejects the resources violating any of the built-in rules.
+	HardenedProfile = Profile{
+		Name:        "hardened",
+		Enforcement: Deny,
+		Rules:       BuiltinRules(),
+	}
+)
+
+// Action checks the rendered resources against a policy profile. It must be
+// placed between the render and the deploy actions.
+//
+// A resource can be exempted from some rules by listing them in the
+// annotations.PolicyExempt annotation.
+type Action struct {
+	profile          Profile
+	platformProfiles map[common.Platform]Profile
+}
+
+type ActionOpts func(*Action)
+
+// WithProfile sets the profile resources are checked against, defaults to
+// DefaultProfile.
+func WithProfile(value Profile) ActionOpts {
+	return func(action *Action) {
+		action.profile = value
+	}
+}
+
+// WithPlatformProfile sets the profile used when the operator runs as the given
+// platform, i.e. to enforce the HardenedProfile on managed environments only.
+func WithPlatformProfile(platform common.Platform, value Profile) ActionOpts {
+	return func(action *Action) {
+		action.platformProfiles[platform] = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	profile, ok := a.platformProfiles[rr.Release.Name]
+	if !ok {
+		profile = a.profile
+	}
+
+	violations := make([]error, 0)
+
+	for i := range rr.Resources {
+		res := &rr.Resources[i]
+		ref := resources.FormatObjectReference(res)
+		exempt := strings.Split(strings.ReplaceAll(resources.GetAnnotation(res, annotations.PolicyExempt), " ", ""), ",")
+
+		for _, rule := range profile.Rules {
+			if slices.Contains(exempt, rule.Name()) {
+				continue
+			}
+
+			messages, err := rule.Check(res)
+			if err != nil {
+				return fmt.Errorf("unable to check rule %s on %s: %w", rule.Name(), ref, err)
+			}
+
+			for _, m := range messages {
+				violations = append(violations, fmt.Errorf("%s: %s: %s", ref, rule.Name(), m))
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if profile.Enforcement == Deny {
+		return odherrors.NewValidationError(violations...)
+	}
+
+	for _, v := range violations {
+		logf.FromContext(ctx).Info("policy violation", "profile", profile.Name, "violation", v.Error())
+		rr.RecordEvent(corev1.EventTypeWarning, EventReasonPolicyViolation, "%s", v.Error())
+	}
+
+	return nil
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		profile:          DefaultProfile,
+		platformProfiles: map[common.Platform]Profile{},
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
@@ -0,0 +1,179 @@
+package policy_test
+
+import (
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/policy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+func newDeployment(podSpec corev1.PodSpec, ann map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "deployment",
+			Namespace:   "ns",
+			Annotations: ann,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+var limits = corev1.ResourceRequirements{
+	Limits: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	},
+}
+
+func TestBuiltinRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       corev1.PodSpec
+		violations map[string]int
+	}{
+		{
+			name: "compliant",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "c", Resources: limits}},
+			},
+		},
+		{
+			name: "privileged",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{
+					Name:            "init",
+					Resources:       limits,
+					SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+				}},
+				Containers: []corev1.Container{{Name: "c", Resources: limits}},
+			},
+			violations: map[string]int{policy.NoPrivilegedContainersRule: 1},
+		},
+		{
+			name: "host path",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "c", Resources: limits}},
+				Volumes: []corev1.Volume{{
+					Name: "host",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: "/var/run"},
+					},
+				}},
+			},
+			violations: map[string]int{policy.NoHostPathRule: 1},
+		},
+		{
+			name: "missing limits",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "c"}},
+			},
+			violations: map[string]int{policy.ResourceLimitsRequiredRule: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			u, err := resources.ToUnstructured(newDeployment(tt.spec, nil))
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			for _, rule := range policy.BuiltinRules() {
+				messages, err := rule.Check(u)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(messages).Should(HaveLen(tt.violations[rule.Name()]), rule.Name())
+			}
+		})
+	}
+}
+
+func TestPolicyAction(t *testing.T) {
+	privileged := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:            "c",
+			Resources:       limits,
+			SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		platform common.Platform
+		ann      map[string]string
+		err      bool
+		events   int
+	}{
+		{
+			name:     "warn",
+			platform: cluster.OpenDataHub,
+			events:   1,
+		},
+		{
+			name:     "deny on hardened platform",
+			platform: cluster.ManagedRhoai,
+			err:      true,
+		},
+		{
+			name:     "exempted",
+			platform: cluster.ManagedRhoai,
+			ann:      map[string]string{annotations.PolicyExempt: policy.NoHostPathRule + ", " + policy.NoPrivilegedContainersRule},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			recorder := record.NewFakeRecorder(10)
+			rr, err := fakerequest.New(
+				fakerequest.WithInstance(&componentApi.Dashboard{
+					ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+				}),
+				fakerequest.WithRelease(common.Release{Name: tt.platform}),
+				fakerequest.WithController(fakecontroller.New(fakecontroller.WithEventRecorder(recorder))),
+				fakerequest.WithResources(newDeployment(privileged, tt.ann)),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			err = policy.NewAction(
+				policy.WithPlatformProfile(cluster.ManagedRhoai, policy.HardenedProfile),
+			)(t.Context(), rr)
+
+			if tt.err {
+				ve := odherrors.ValidationError{}
+				g.Expect(errors.As(err, &ve)).Should(BeTrue())
+				g.Expect(ve.Failures()).Should(HaveLen(1))
+				g.Expect(err).Should(MatchError(ContainSubstring(policy.NoPrivilegedContainersRule)))
+			} else {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			}
+
+			g.Expect(recorder.Events).Should(HaveLen(tt.events))
+		})
+	}
+}
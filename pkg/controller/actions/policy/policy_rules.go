@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	NoPrivilegedContainersRule = "no-privileged-containers"
+	NoHostPathRule             = "no-host-path"
+	ResourceLimitsRequiredRule = "resource-limits-required"
+)
+
+// Rule is a check performed on every rendered resource. Check returns a message
+// for each violation found, an error is only returned if the resource could not
+// be checked.
+type Rule interface {
+	Name() string
+	Check(obj *unstructured.Unstructured) ([]string, error)
+}
+
+// podSpecRule is a Rule checking the pod template of workload resources, other
+// resources are ignored.
+type podSpecRule struct {
+	name  string
+	check func(spec *corev1.PodSpec) []string
+}
+
+func (r podSpecRule) Name() string {
+	return r.name
+}
+
+func (r podSpecRule) Check(obj *unstructured.Unstructured) ([]string, error) {
+	spec, err := podSpec(obj)
+	if err != nil || spec == nil {
+		return nil, err
+	}
+
+	return r.check(spec), nil
+}
+
+// NoPrivilegedContainers rejects containers running in privileged mode.
+func NoPrivilegedContainers() Rule {
+	return podSpecRule{
+		name: NoPrivilegedContainersRule,
+		check: func(spec *corev1.PodSpec) []string {
+			violations := make([]string, 0)
+
+			for _, c := range containers(spec) {
+				if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+					violations = append(violations, fmt.Sprintf("container %s is privileged", c.Name))
+				}
+			}
+
+			return violations
+		},
+	}
+}
+
+// NoHostPath rejects pods mounting hostPath volumes.
+func NoHostPath() Rule {
+	return podSpecRule{
+		name: NoHostPathRule,
+		check: func(spec *corev1.PodSpec) []string {
+			violations := make([]string, 0)
+
+			for _, v := range spec.Volumes {
+				if v.HostPath != nil {
+					violations = append(violations, fmt.Sprintf("volume %s uses hostPath %s", v.Name, v.HostPath.Path))
+				}
+			}
+
+			return violations
+		},
+	}
+}
+
+// ResourceLimitsRequired rejects containers without cpu and memory limits.
+func ResourceLimitsRequired() Rule {
+	return podSpecRule{
+		name: ResourceLimitsRequiredRule,
+		check: func(spec *corev1.PodSpec) []string {
+			violations := make([]string, 0)
+
+			for _, c := range containers(spec) {
+				for _, r := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+					if _, ok := c.Resources.Limits[r]; !ok {
+						violations = append(violations, fmt.Sprintf("container %s has no %s limit", c.Name, r))
+					}
+				}
+			}
+
+			return violations
+		},
+	}
+}
+
+// BuiltinRules returns all the rules provided by this package.
+func BuiltinRules() []Rule {
+	return []Rule{
+		NoPrivilegedContainers(),
+		NoHostPath(),
+		ResourceLimitsRequired(),
+	}
+}
+
+func containers(spec *corev1.PodSpec) []corev1.Container {
+	result := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	result = append(result, spec.InitContainers...)
+	result = append(result, spec.Containers...)
+
+	return result
+}
+
+// podSpec returns the pod spec of the given resource, or nil if the resource is
+// not a workload.
+func podSpec(obj *unstructured.Unstructured) (*corev1.PodSpec, error) {
+	path, ok := resources.PodSpecPath(obj.GroupVersionKind().GroupKind())
+	if !ok {
+		return nil, nil
+	}
+
+	content, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	spec := corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &spec); err != nil {
+		return nil, fmt.Errorf("unable to decode pod spec: %w", err)
+	}
+
+	return &spec, nil
+}
@@ -2,10 +2,15 @@ package kustomize
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/cacher"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/resourcecacher"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/kustomize"
@@ -21,6 +26,10 @@ type Action struct {
 	cacher resourcecacher.ResourceCacher
 	cache  bool
 
+	budget       time.Duration
+	strictBudget bool
+	limits       render.Limits
+
 	keOpts []kustomize.EngineOptsFn
 	ke     *kustomize.Engine
 }
@@ -69,23 +78,46 @@ func WithCache(enabled bool) ActionOpts {
 	}
 }
 
+// WithRenderBudget bounds the time spent building the manifests, see
+// resourcecacher.ResourceCacher.SetBudget.
+func WithRenderBudget(budget time.Duration, strict bool) ActionOpts {
+	return func(action *Action) {
+		action.budget = budget
+		action.strictBudget = strict
+	}
+}
+
+// WithRenderLimits bounds the number and the size of the resources built from
+// the manifests, see render.Limits.
+func WithRenderLimits(limits render.Limits) ActionOpts {
+	return func(action *Action) {
+		action.limits = limits
+	}
+}
+
 func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
 	return a.cacher.Render(ctx, rr, a.render)
 }
 
-func (a *Action) render(_ context.Context, rr *types.ReconciliationRequest) (resources.UnstructuredList, error) {
+func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (resources.UnstructuredList, error) {
+	log := logf.FromContext(ctx).WithValues("engine", rendererEngine)
 	result := make(resources.UnstructuredList, 0)
 
 	for i := range rr.Manifests {
+		path := rr.Manifests[i].String()
+		log.V(3).Info("rendering manifests", "path", path)
+
 		renderedResources, err := a.ke.Render(
-			rr.Manifests[i].String(),
+			path,
 			kustomize.WithNamespace(rr.DSCI.Spec.ApplicationsNamespace),
 		)
 
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to render manifests from %s: %w", path, err)
 		}
 
+		log.V(4).Info("rendered manifests", "path", path, "count", len(renderedResources))
+
 		result = append(result, renderedResources...)
 	}
 
@@ -103,9 +135,12 @@ func NewAction(opts ...ActionOpts) actions.Fn {
 	}
 
 	if action.cache {
-		action.cacher.SetKeyFn(types.Hash)
+		action.cacher.SetKeyFn(cacher.KeyFn(types.Hash))
 	}
 
+	action.cacher.SetBudget(action.budget, action.strictBudget)
+	action.cacher.SetLimits(action.limits)
+
 	action.ke = kustomize.NewEngine(action.keOpts...)
 
 	return action.run
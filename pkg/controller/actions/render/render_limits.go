@@ -0,0 +1,56 @@
+package render
+
+import (
+	"fmt"
+
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// Limits bounds the output of a rendering, so that a malformed template can't
+// exhaust the operator memory or flood the API server. A zero value disables
+// the related check.
+type Limits struct {
+	// MaxResources is the maximum number of rendered resources.
+	MaxResources int
+	// MaxResourceSize is the maximum size, in bytes, of a rendered resource
+	// serialized as JSON.
+	MaxResourceSize int
+	// MaxTotalSize is the maximum size, in bytes, of all the rendered
+	// resources serialized as JSON.
+	MaxTotalSize int
+}
+
+// Check returns a LimitExceededError if the given resources exceed any of the
+// limits.
+func (l Limits) Check(res resources.UnstructuredList) error {
+	if l.MaxResources > 0 && len(res) > l.MaxResources {
+		return odherrors.NewLimitExceededError("rendered %d resources, exceeding the limit of %d", len(res), l.MaxResources)
+	}
+
+	if l.MaxResourceSize <= 0 && l.MaxTotalSize <= 0 {
+		return nil
+	}
+
+	total := 0
+
+	for i := range res {
+		data, err := res[i].MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("unable to compute the size of %s %s: %w", res[i].GroupVersionKind(), res[i].GetName(), err)
+		}
+
+		if l.MaxResourceSize > 0 && len(data) > l.MaxResourceSize {
+			return odherrors.NewLimitExceededError("%s %s is %d bytes, exceeding the limit of %d",
+				res[i].GroupVersionKind().Kind, res[i].GetName(), len(data), l.MaxResourceSize)
+		}
+
+		total += len(data)
+
+		if l.MaxTotalSize > 0 && total > l.MaxTotalSize {
+			return odherrors.NewLimitExceededError("rendered resources exceed the limit of %d bytes", l.MaxTotalSize)
+		}
+	}
+
+	return nil
+}
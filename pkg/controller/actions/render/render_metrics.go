@@ -21,6 +21,39 @@ var (
 			"engine",
 		},
 	)
+
+	// RenderDurationSeconds is a prometheus histogram metrics which holds the time
+	// spent rendering resources per controller and rendering type. It has two labels.
+	// controller label refers to the controller name.
+	// engine label refers to the rendering engine.
+	RenderDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "action_renderer_duration_seconds",
+			Help:    "Time spent rendering resources",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{
+			"controller",
+			"engine",
+		},
+	)
+
+	// RenderQueueWaitSeconds is a prometheus histogram metrics which holds the
+	// time spent waiting for a rendering slot per controller and rendering type.
+	// It has two labels.
+	// controller label refers to the controller name.
+	// engine label refers to the rendering engine.
+	RenderQueueWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "action_renderer_queue_wait_seconds",
+			Help:    "Time spent waiting for a rendering slot",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{
+			"controller",
+			"engine",
+		},
+	)
 )
 
 // init register metrics to the global registry from controller-runtime/pkg/metrics.
@@ -29,4 +62,6 @@ var (
 //nolint:gochecknoinits
 func init() {
 	metrics.Registry.MustRegister(RenderedResourcesTotal)
+	metrics.Registry.MustRegister(RenderDurationSeconds)
+	metrics.Registry.MustRegister(RenderQueueWaitSeconds)
 }
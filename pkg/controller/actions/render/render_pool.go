@@ -0,0 +1,58 @@
+package render
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// pool bounds the number of renderings running at the same time across all
+// the controllers of the manager, so that many components reconciled at once
+// don't compete for CPU and memory.
+type pool struct {
+	mu    sync.RWMutex
+	slots chan struct{}
+}
+
+var renderPool = &pool{
+	slots: make(chan struct{}, runtime.GOMAXPROCS(0)),
+}
+
+func (p *pool) get() chan struct{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.slots
+}
+
+// SetMaxConcurrency sets the number of renderings allowed to run at the same
+// time, it defaults to GOMAXPROCS and must be set before any controller is
+// started. Values lower than 1 are ignored.
+func SetMaxConcurrency(value int) {
+	if value < 1 {
+		return
+	}
+
+	renderPool.mu.Lock()
+	defer renderPool.mu.Unlock()
+
+	renderPool.slots = make(chan struct{}, value)
+}
+
+// Acquire waits for a rendering slot to be available and returns the function
+// releasing it. The time spent waiting is accounted in RenderQueueWaitSeconds.
+func Acquire(ctx context.Context, controller string, engine string) (func(), error) {
+	slots := renderPool.get()
+	start := time.Now()
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	RenderQueueWaitSeconds.WithLabelValues(controller, engine).Observe(time.Since(start).Seconds())
+
+	return func() { <-slots }, nil
+}
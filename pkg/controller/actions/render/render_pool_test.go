@@ -0,0 +1,40 @@
+package render_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAcquire(t *testing.T) {
+	g := NewWithT(t)
+
+	render.SetMaxConcurrency(1)
+	t.Cleanup(func() { render.SetMaxConcurrency(runtime.GOMAXPROCS(0)) })
+
+	render.RenderQueueWaitSeconds.Reset()
+
+	release, err := render.Acquire(t.Context(), "dashboard", "template")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(testutil.CollectAndCount(render.RenderQueueWaitSeconds)).Should(Equal(1))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = render.Acquire(ctx, "dashboard", "template")
+	g.Expect(err).Should(MatchError(context.DeadlineExceeded))
+
+	release()
+
+	release, err = render.Acquire(t.Context(), "dashboard", "template")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	release()
+}
@@ -1,7 +1,20 @@
 package render
 
 import (
+	"context"
+
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 )
 
-type CachingKeyFn func(rr *types.ReconciliationRequest) ([]byte, error)
+const (
+	// EventReasonRenderError is the reason of the warning event recorded on the
+	// reconciled instance when its resources fail to render.
+	EventReasonRenderError = "RenderError"
+
+	// EventReasonRenderBudgetExceeded is the reason of the warning event recorded
+	// on the reconciled instance when rendering its resources takes longer than
+	// the configured budget.
+	EventReasonRenderBudgetExceeded = "RenderBudgetExceeded"
+)
+
+type CachingKeyFn func(ctx context.Context, rr *types.ReconciliationRequest) ([]byte, error)
@@ -3,15 +3,23 @@ package template
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
+	"slices"
+	"strings"
 	gt "text/template"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/cacher"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/resourcecacher"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
@@ -22,6 +30,15 @@ const (
 	rendererEngine = "template"
 	ComponentKey   = "Component"
 	DSCIKey        = "DSCI"
+	// FIPSKey is set to whether the cluster runs in FIPS mode, so that templates
+	// can select FIPS compliant images or configuration.
+	FIPSKey = "FIPS"
+	// CapabilitiesKey holds the capabilities detected on the cluster when the
+	// WithCapabilities option is set, i.e. {{ if .Capabilities.Routes }}.
+	CapabilitiesKey = "Capabilities"
+	// ClusterKey holds the ClusterValues of the cluster when the
+	// WithClusterValues option is set, i.e. {{ .Cluster.Domain }}.
+	ClusterKey = "Cluster"
 )
 
 // Action takes a set of template locations and render them as Unstructured resources for
@@ -31,8 +48,19 @@ type Action struct {
 	cacher resourcecacher.ResourceCacher
 	cache  bool
 
+	budget       time.Duration
+	strictBudget bool
+	limits       render.Limits
+
 	data   map[string]any
 	dataFn []func(context.Context, *types.ReconciliationRequest) (map[string]any, error)
+	funcs  gt.FuncMap
+
+	// keyFn extends the render cache key with the data which is not derived
+	// from the instance, the DSCI or the release
+	keyFn []cacher.CachingKeyFn
+
+	secrets map[string]*secretResolver
 
 	labels      map[string]string
 	annotations map[string]string
@@ -46,6 +74,24 @@ func WithCache(enabled bool) ActionOpts {
 	}
 }
 
+// WithRenderBudget sets the time rendering the resources is expected to take
+// at most. Exceeding renderings are reported with a RenderBudgetExceeded
+// warning event on the instance, and fail if strict is true.
+func WithRenderBudget(budget time.Duration, strict bool) ActionOpts {
+	return func(action *Action) {
+		action.budget = budget
+		action.strictBudget = strict
+	}
+}
+
+// WithRenderLimits sets the limits the rendered resources are checked against,
+// rendering fails with a RenderLimitExceeded reason when any is exceeded.
+func WithRenderLimits(limits render.Limits) ActionOpts {
+	return func(action *Action) {
+		action.limits = limits
+	}
+}
+
 func WithData(data map[string]any) ActionOpts {
 	return func(action *Action) {
 		for k, v := range data {
@@ -60,6 +106,98 @@ func WithDataFn(fns ...func(context.Context, *types.ReconciliationRequest) (map[
 	}
 }
 
+// WithFuncs registers additional functions available to templates, taking
+// precedence over the platform helpers and the default functions with the
+// same name.
+//
+// Functions are not part of the render cache key, so their output must only
+// depend on the template data.
+func WithFuncs(funcs gt.FuncMap) ActionOpts {
+	return func(action *Action) {
+		maps.Copy(action.funcs, funcs)
+	}
+}
+
+// WithCapabilities makes the availability of the given cluster capabilities,
+// or of cluster.DefaultCapabilities if none is given, available to templates
+// under the CapabilitiesKey key, so they can i.e. render an Ingress when Routes
+// are not served or skip a ServiceMonitor when the CRD is not installed.
+//
+// Capabilities are part of the render cache key, so resources are rendered
+// again as soon as a capability appears or goes away.
+func WithCapabilities(values ...cluster.Capability) ActionOpts {
+	if len(values) == 0 {
+		values = cluster.DefaultCapabilities
+	}
+
+	detect := func(rr *types.ReconciliationRequest) (map[string]bool, error) {
+		capabilities, err := cluster.DetectCapabilities(rr.Client, values...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to detect cluster capabilities: %w", err)
+		}
+
+		return capabilities, nil
+	}
+
+	return func(action *Action) {
+		action.dataFn = append(action.dataFn, func(_ context.Context, rr *types.ReconciliationRequest) (map[string]any, error) {
+			capabilities, err := detect(rr)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{CapabilitiesKey: capabilities}, nil
+		})
+
+		action.keyFn = append(action.keyFn, func(_ context.Context, rr *types.ReconciliationRequest) ([]byte, error) {
+			capabilities, err := detect(rr)
+			if err != nil {
+				return nil, err
+			}
+
+			// maps are marshalled with sorted keys, so the key is stable
+			return json.Marshal(capabilities)
+		})
+	}
+}
+
+// WithClusterValues makes the ClusterValues of the cluster available to
+// templates under the ClusterKey key, so that components do not have to look
+// up the ingress domain or the platform type on their own.
+//
+// As for capabilities, cluster values are part of the render cache key, i.e.
+// resources are rendered again when the ingress domain changes.
+func WithClusterValues() ActionOpts {
+	compute := func(ctx context.Context, rr *types.ReconciliationRequest) (ClusterValues, error) {
+		values, err := NewClusterValues(ctx, rr)
+		if err != nil {
+			return ClusterValues{}, fmt.Errorf("unable to compute cluster values: %w", err)
+		}
+
+		return values, nil
+	}
+
+	return func(action *Action) {
+		action.dataFn = append(action.dataFn, func(ctx context.Context, rr *types.ReconciliationRequest) (map[string]any, error) {
+			values, err := compute(ctx, rr)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{ClusterKey: values}, nil
+		})
+
+		action.keyFn = append(action.keyFn, func(ctx context.Context, rr *types.ReconciliationRequest) ([]byte, error) {
+			values, err := compute(ctx, rr)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(values)
+		})
+	}
+}
+
 func WithLabel(name string, value string) ActionOpts {
 	return func(a *Action) {
 		a.labels[name] = value
@@ -119,29 +257,62 @@ func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (r
 		maps.Copy(data, values)
 	}
 
+	if len(a.secrets) > 0 {
+		for k, v := range data {
+			resolved, err := a.resolveSecrets(ctx, v)
+			if err != nil {
+				return nil, fmt.Errorf("unable to compute template data: %w", err)
+			}
+
+			data[k] = resolved
+		}
+	}
+
 	data[ComponentKey] = rr.Instance
 	data[DSCIKey] = rr.DSCI
+	data[FIPSKey] = cluster.GetClusterInfo().FipsEnabled
+
+	funcs := templateutils.TextTemplateFuncMap()
+	maps.Copy(funcs, platformFuncs(ctx, rr))
+	maps.Copy(funcs, a.funcs)
 
 	result := make(resources.UnstructuredList, 0)
 
 	var buffer bytes.Buffer
 
+	log := logf.FromContext(ctx).WithValues("engine", rendererEngine)
+
 	for i := range rr.Templates {
-		tmpl, err := gt.New("").Option("missingkey=error").Funcs(templateutils.TextTemplateFuncMap()).ParseFS(rr.Templates[i].FS, rr.Templates[i].Path)
+		log.V(3).Info("rendering templates", "path", rr.Templates[i].Path)
+
+		tmpl, err := gt.New("").Option("missingkey=error").Funcs(funcs).ParseFS(rr.Templates[i].FS, rr.Templates[i].Path)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse template from: %w", err)
+			return nil, formatTemplateError("parse", rr.Templates[i].FS, rr.Templates[i].Path, err)
 		}
 
-		for _, t := range tmpl.Templates() {
+		// templates are kept in a map, sort them so that repeated renderings
+		// produce the resources in the same order
+		templates := tmpl.Templates()
+		slices.SortFunc(templates, func(a *gt.Template, b *gt.Template) int {
+			return strings.Compare(a.Name(), b.Name())
+		})
+
+		for _, t := range templates {
 			buffer.Reset()
 			err = t.Execute(&buffer, data)
 			if err != nil {
-				return nil, fmt.Errorf("failed to execute template: %w", err)
+				return nil, formatTemplateError("execute", rr.Templates[i].FS, rr.Templates[i].Path, err)
 			}
 
 			u, err := a.decode(decoder, buffer.Bytes(), rr.Templates[i])
 			if err != nil {
-				return nil, fmt.Errorf("failed to decode template: %w", err)
+				return nil, fmt.Errorf("failed to decode template %s: %w", t.Name(), err)
+			}
+
+			// the rendered content is only meant to debug templates, and must not
+			// leak the data of Secrets nor resolved secrets
+			if log.V(5).Enabled() {
+				log.V(5).Info("rendered template", "path", rr.Templates[i].Path, "name", t.Name(), "content", a.loggable(buffer.Bytes(), u))
 			}
 
 			result = append(result, u...)
@@ -151,9 +322,33 @@ func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (r
 	return result, nil
 }
 
+// dataKeyFn extends the given caching key function with the data registered
+// through keyFn, so that cached resources are rendered again when it changes.
+func (a *Action) dataKeyFn(keyFn cacher.CachingKeyFn) cacher.CachingKeyFn {
+	return func(ctx context.Context, rr *types.ReconciliationRequest) ([]byte, error) {
+		key, err := keyFn(ctx, rr)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fn := range a.keyFn {
+			data, err := fn(ctx, rr)
+			if err != nil {
+				return nil, err
+			}
+
+			key = append(key, data...)
+		}
+
+		return key, nil
+	}
+}
+
 func NewAction(opts ...ActionOpts) actions.Fn {
 	action := Action{
 		data:        make(map[string]any),
+		funcs:       make(gt.FuncMap),
+		secrets:     make(map[string]*secretResolver),
 		cacher:      resourcecacher.NewResourceCacher(rendererEngine),
 		cache:       true,
 		labels:      make(map[string]string),
@@ -165,8 +360,19 @@ func NewAction(opts ...ActionOpts) actions.Fn {
 	}
 
 	if action.cache {
-		action.cacher.SetKeyFn(types.Hash)
+		keyFn := cacher.KeyFn(types.Hash)
+		if len(action.keyFn) > 0 {
+			keyFn = action.dataKeyFn(keyFn)
+		}
+		if len(action.secrets) > 0 {
+			keyFn = action.secretsKeyFn(keyFn)
+		}
+
+		action.cacher.SetKeyFn(keyFn)
 	}
 
+	action.cacher.SetBudget(action.budget, action.strictBudget)
+	action.cacher.SetLimits(action.limits)
+
 	return action.run
 }
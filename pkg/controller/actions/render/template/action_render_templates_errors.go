@@ -0,0 +1,132 @@
+package template
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// templateErrorRe matches the location prefix text/template adds to both parse
+// and execution errors, i.e.:
+//
+//	template: deployment.tmpl.yaml:12: function "foo" not defined
+//	template: deployment.tmpl.yaml:12:34: executing "deployment.tmpl.yaml" at <.Foo>: ...
+var templateErrorRe = regexp.MustCompile(`^template: ([^:]+):(\d+)(?::(\d+))?: (.*)$`)
+
+// TemplateError reports the location of the template that failed to be parsed
+// or executed, so that the failing template can be identified without having to
+// reproduce the rendering locally.
+type TemplateError struct {
+	Path    string
+	Line    int
+	Column  int
+	Source  string
+	Message string
+
+	err error
+}
+
+func (e *TemplateError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(e.Path)
+	sb.WriteString(":")
+	sb.WriteString(strconv.Itoa(e.Line))
+
+	if e.Column > 0 {
+		sb.WriteString(":")
+		sb.WriteString(strconv.Itoa(e.Column))
+	}
+
+	sb.WriteString(": ")
+	sb.WriteString(e.Message)
+
+	if e.Source != "" {
+		sb.WriteString(" (near ")
+		sb.WriteString(strconv.Quote(e.Source))
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.err
+}
+
+// newTemplateError wraps the given text/template error in a TemplateError if the
+// failure location can be determined, the template source is looked up among the
+// files matching the given pattern to include the offending line.
+func newTemplateError(fsys fs.FS, pattern string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	te := &TemplateError{}
+	if errors.As(err, &te) {
+		return err
+	}
+
+	m := templateErrorRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+
+	te = &TemplateError{
+		Path:    m[1],
+		Message: m[4],
+		err:     err,
+	}
+
+	te.Line, _ = strconv.Atoi(m[2])
+	te.Column, _ = strconv.Atoi(m[3])
+
+	// template names are the base name of the parsed files, look for the file
+	// the template has been loaded from to report its full path and source
+	matches, globErr := fs.Glob(fsys, pattern)
+	if globErr != nil {
+		return te
+	}
+
+	for _, match := range matches {
+		if path.Base(match) != te.Path {
+			continue
+		}
+
+		te.Path = match
+		te.Source = sourceLine(fsys, match, te.Line)
+
+		break
+	}
+
+	return te
+}
+
+func sourceLine(fsys fs.FS, name string, line int) string {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return ""
+	}
+
+	defer func() { _ = f.Close() }()
+
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		if n == line {
+			return strings.TrimSpace(s.Text())
+		}
+	}
+
+	return ""
+}
+
+// formatTemplateError keeps the error messages consistent between parse and
+// execution failures.
+func formatTemplateError(op string, fsys fs.FS, pattern string, err error) error {
+	return fmt.Errorf("failed to %s template: %w", op, newTemplateError(fsys, pattern, err))
+}
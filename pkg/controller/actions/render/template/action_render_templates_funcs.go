@@ -0,0 +1,78 @@
+package template
+
+import (
+	"context"
+	"sync"
+	gt "text/template"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+// platformFuncs returns the platform helpers available to every template:
+//
+//   - clusterDomain: the ingress domain of the cluster, looked up at most once
+//     per rendering and only when used
+//   - clusterType: the type of the cluster, i.e. OpenShift
+//   - platformType: the name of the platform, i.e. OpenDataHub
+//   - platformVersion: the version of the platform
+func platformFuncs(ctx context.Context, rr *types.ReconciliationRequest) gt.FuncMap {
+	domain := sync.OnceValues(func() (string, error) {
+		return cluster.GetDomain(ctx, rr.Client)
+	})
+
+	return gt.FuncMap{
+		"clusterDomain": domain,
+		"clusterType": func() string {
+			return cluster.GetClusterInfo().Type
+		},
+		"platformType": func() string {
+			return string(rr.Release.Name)
+		},
+		"platformVersion": func() string {
+			return rr.Release.Version.String()
+		},
+	}
+}
+
+// ClusterValues describes the cluster the resources are rendered for. It is
+// read-only, templates get a copy.
+type ClusterValues struct {
+	// Domain is the ingress domain of the cluster, only set on OpenShift.
+	Domain    string
+	Version   string
+	Type      string
+	OpenShift bool
+	// Platform is the platform the operator runs as, i.e. Open Data Hub.
+	Platform              common.Platform
+	ApplicationsNamespace string
+}
+
+// NewClusterValues returns the ClusterValues of the cluster the given request is
+// reconciled on.
+func NewClusterValues(ctx context.Context, rr *types.ReconciliationRequest) (ClusterValues, error) {
+	info := cluster.GetClusterInfo()
+
+	values := ClusterValues{
+		Version:   info.Version.String(),
+		Type:      info.Type,
+		OpenShift: info.Type == cluster.ClusterTypeOpenShift,
+		Platform:  rr.Release.Name,
+	}
+
+	if rr.DSCI != nil {
+		values.ApplicationsNamespace = rr.DSCI.Spec.ApplicationsNamespace
+	}
+
+	if values.OpenShift {
+		domain, err := cluster.GetDomain(ctx, rr.Client)
+		if err != nil {
+			return ClusterValues{}, err
+		}
+
+		values.Domain = domain
+	}
+
+	return values, nil
+}
@@ -0,0 +1,109 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	// ReleaseKey holds the ReleaseValues of the component when the
+	// WithReleaseValues option is set, i.e. {{ if .Release.IsInstall }}.
+	ReleaseKey = "Release"
+
+	// ReleaseRevisionKey and ReleaseHashKey are the keys of the release record
+	// ConfigMap holding the current revision and the hash of the inputs it has
+	// been rendered from.
+	ReleaseRevisionKey = "revision"
+	ReleaseHashKey     = "hash"
+)
+
+// ReleaseValues describes the release of the resources of a component being
+// rendered. The revision starts at 1 and is incremented each time the resources
+// are rendered from different inputs, i.e. because the spec of the component or
+// the version of the platform has changed.
+type ReleaseValues struct {
+	Name      string
+	Version   string
+	Revision  int64
+	IsInstall bool
+	IsUpgrade bool
+}
+
+// WithReleaseValues makes the ReleaseValues of the component available to
+// templates under the ReleaseKey key, so that templates can i.e. skip bootstrap
+// jobs on upgrades. The revision is stored in a ConfigMap owned by the component
+// in the applications namespace, so that it survives the restarts of the operator.
+func WithReleaseValues() ActionOpts {
+	return WithDataFn(func(ctx context.Context, rr *types.ReconciliationRequest) (map[string]any, error) {
+		values, err := NewReleaseValues(ctx, rr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute release values: %w", err)
+		}
+
+		return map[string]any{ReleaseKey: values}, nil
+	})
+}
+
+// NewReleaseValues returns the ReleaseValues of the component being reconciled,
+// recording a new revision if the inputs of the rendering have changed since
+// the last one.
+func NewReleaseValues(ctx context.Context, rr *types.ReconciliationRequest) (ReleaseValues, error) {
+	if rr.DSCI == nil {
+		return ReleaseValues{}, errors.New("no DSCInitialization available")
+	}
+
+	kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
+	if err != nil {
+		return ReleaseValues{}, err
+	}
+
+	hash, err := types.HashStr(rr)
+	if err != nil {
+		return ReleaseValues{}, err
+	}
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.ToLower(kind) + "-" + rr.Instance.GetName() + "-release",
+			Namespace: rr.DSCI.Spec.ApplicationsNamespace,
+		},
+	}
+
+	var revision int64
+
+	_, err = controllerutil.CreateOrUpdate(ctx, rr.Client, &cm, func() error {
+		// an invalid revision restarts from scratch
+		revision, _ = strconv.ParseInt(cm.Data[ReleaseRevisionKey], 10, 64)
+		if revision == 0 || cm.Data[ReleaseHashKey] != hash {
+			revision++
+		}
+
+		cm.Data = map[string]string{
+			ReleaseRevisionKey: strconv.FormatInt(revision, 10),
+			ReleaseHashKey:     hash,
+		}
+
+		return controllerutil.SetOwnerReference(rr.Instance, &cm, rr.Client.Scheme())
+	})
+	if err != nil {
+		return ReleaseValues{}, fmt.Errorf("unable to record release revision: %w", err)
+	}
+
+	return ReleaseValues{
+		Name:      rr.Instance.GetName(),
+		Version:   rr.Release.Version.String(),
+		Revision:  revision,
+		IsInstall: revision == 1,
+		IsUpgrade: revision > 1,
+	}, nil
+}
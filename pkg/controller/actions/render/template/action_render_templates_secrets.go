@@ -0,0 +1,218 @@
+package template
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/cacher"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+const (
+	// DefaultSecretTTL is how long resolved secrets are cached when no TTL is given.
+	DefaultSecretTTL = 5 * time.Minute
+
+	redacted = "<redacted>"
+)
+
+// SecretResolver resolves secrets stored outside of the cluster, i.e. in Vault,
+// so that credentials do not have to be set in CRs or ConfigMaps.
+type SecretResolver interface {
+	// Resolve returns the value of the given key of the secret at the given path.
+	Resolve(ctx context.Context, path string, key string) (string, error)
+}
+
+// SecretResolverFunc adapts a function to a SecretResolver.
+type SecretResolverFunc func(ctx context.Context, path string, key string) (string, error)
+
+func (f SecretResolverFunc) Resolve(ctx context.Context, path string, key string) (string, error) {
+	return f(ctx, path, key)
+}
+
+// WithSecretResolver replaces the string values of the template data of the
+// form <scheme>://<path>#<key>, i.e. vault://platform/db#password, with the
+// secret returned by the given resolver.
+//
+// Resolved secrets are cached for the given TTL, DefaultSecretTTL if zero, and
+// the resources are rendered again at least once per TTL so that rotated
+// secrets are picked up.
+func WithSecretResolver(scheme string, resolver SecretResolver, ttl time.Duration) ActionOpts {
+	if ttl <= 0 {
+		ttl = DefaultSecretTTL
+	}
+
+	return func(action *Action) {
+		action.secrets[scheme] = &secretResolver{
+			scheme:   scheme,
+			resolver: resolver,
+			ttl:      ttl,
+			entries:  make(map[string]secretEntry),
+		}
+	}
+}
+
+type secretEntry struct {
+	value string
+	epoch int64
+}
+
+type secretResolver struct {
+	scheme   string
+	resolver SecretResolver
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]secretEntry
+}
+
+// epoch returns the index of the TTL window the given time falls in, cached
+// secrets are only valid in the window they have been resolved in.
+func (s *secretResolver) epoch(now time.Time) int64 {
+	return now.UnixNano() / int64(s.ttl)
+}
+
+func (s *secretResolver) resolve(ctx context.Context, ref string) (string, error) {
+	path, key, found := strings.Cut(ref, "#")
+	if !found || path == "" || key == "" {
+		return "", fmt.Errorf("invalid secret reference %s://%s, expected %s://<path>#<key>", s.scheme, ref, s.scheme)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	epoch := s.epoch(time.Now())
+
+	if e, ok := s.entries[ref]; ok && e.epoch == epoch {
+		return e.value, nil
+	}
+
+	value, err := s.resolver.Resolve(ctx, path, key)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve secret %s://%s: %w", s.scheme, ref, err)
+	}
+
+	s.entries[ref] = secretEntry{value: value, epoch: epoch}
+
+	return value, nil
+}
+
+// resolveSecrets returns a copy of the given value with the secret references
+// replaced by the resolved secrets, the value itself is left untouched as it may
+// be shared with the data set by WithData.
+func (a *Action) resolveSecrets(ctx context.Context, value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		scheme, ref, found := strings.Cut(v, "://")
+		r, ok := a.secrets[scheme]
+		if !found || !ok {
+			return v, nil
+		}
+
+		return r.resolve(ctx, ref)
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, e := range v {
+			resolved, err := a.resolveSecrets(ctx, e)
+			if err != nil {
+				return nil, err
+			}
+
+			result[k] = resolved
+		}
+
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, e := range v {
+			resolved, err := a.resolveSecrets(ctx, e)
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = resolved
+		}
+
+		return result, nil
+	default:
+		return v, nil
+	}
+}
+
+// secretsKeyFn extends the given caching key function with the current TTL
+// window of each secret resolver, so that cached resources embedding secrets
+// are rendered again once they may have been rotated.
+func (a *Action) secretsKeyFn(keyFn cacher.CachingKeyFn) cacher.CachingKeyFn {
+	schemes := slices.Sorted(maps.Keys(a.secrets))
+
+	return func(ctx context.Context, rr *types.ReconciliationRequest) ([]byte, error) {
+		key, err := keyFn(ctx, rr)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		for _, s := range schemes {
+			key = binary.AppendVarint(key, a.secrets[s].epoch(now))
+		}
+
+		return key, nil
+	}
+}
+
+// loggable returns the given rendered content in a form which is safe to be
+// logged: the data of Secrets is redacted, and nothing but the kind and name
+// of the resources is kept when resolved secrets may be embedded anywhere.
+func (a *Action) loggable(content []byte, objs []unstructured.Unstructured) string {
+	if len(a.secrets) == 0 && !slices.ContainsFunc(objs, isSecret) {
+		return string(content)
+	}
+
+	var b strings.Builder
+
+	for i := range objs {
+		obj := objs[i].DeepCopy()
+
+		switch {
+		case len(a.secrets) > 0:
+			obj = &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(objs[i].GroupVersionKind())
+			obj.SetNamespace(objs[i].GetNamespace())
+			obj.SetName(objs[i].GetName())
+		case isSecret(objs[i]):
+			for _, field := range []string{"data", "stringData"} {
+				values, _, _ := unstructured.NestedMap(obj.Object, field)
+				for k := range values {
+					values[k] = redacted
+				}
+
+				if len(values) > 0 {
+					_ = unstructured.SetNestedMap(obj.Object, values, field)
+				}
+			}
+		}
+
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return redacted
+		}
+
+		b.WriteString("---\n")
+		b.Write(data)
+	}
+
+	return b.String()
+}
+
+func isSecret(obj unstructured.Unstructured) bool {
+	return obj.GroupVersionKind() == gvk.Secret
+}
@@ -4,18 +4,29 @@ import (
 	"context"
 	"embed"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	apytypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
 	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
 	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/api/infrastructure/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/template"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
@@ -310,6 +321,21 @@ func TestRenderTemplateWithGlob(t *testing.T) {
 		))
 	})
 
+	t.Run("ordered", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for range 10 {
+			rr := rrRef
+			rr.Templates = []types.TemplateInfo{{FS: testFS, Path: "resources/g/*.yaml"}}
+
+			g.Expect(action(ctx, &rr)).Should(Succeed())
+			g.Expect(rr.Resources).Should(HaveExactElements(
+				jq.Match(`.metadata.name == "sm-01"`),
+				jq.Match(`.metadata.name == "sm-02"`),
+			))
+		}
+	})
+
 	t.Run("named", func(t *testing.T) {
 		g := NewWithT(t)
 
@@ -409,3 +435,883 @@ func TestRenderTemplateWithCustomInfo(t *testing.T) {
 		jq.Match(`.metadata.annotations."annotation-override" == "annotation-02"`),
 	))
 }
+
+func TestRenderTemplateErrorLocation(t *testing.T) {
+	tfs := fstest.MapFS{
+		"resources/broken/exec.tmpl.yaml": &fstest.MapFile{
+			Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Missing }}\n"),
+		},
+		"resources/broken/parse.tmpl.yaml": &fstest.MapFile{
+			Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ notAFunction }}\n"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		column  int
+		message string
+	}{
+		{
+			name:    "execute",
+			path:    "resources/broken/exec.tmpl.yaml",
+			column:  11,
+			message: `resources/broken/exec.tmpl.yaml:4:11: executing "exec.tmpl.yaml" at <.Missing>`,
+		},
+		{
+			name:    "parse",
+			path:    "resources/broken/parse.tmpl.yaml",
+			column:  0,
+			message: `resources/broken/parse.tmpl.yaml:4: function "notAFunction" not defined`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := t.Context()
+
+			cl, err := fakeclient.New()
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr := types.ReconciliationRequest{
+				Client:    cl,
+				Instance:  &componentApi.Dashboard{},
+				DSCI:      &dsciv2.DSCInitialization{},
+				Release:   common.Release{Name: cluster.OpenDataHub},
+				Templates: []types.TemplateInfo{{FS: tfs, Path: tt.path}},
+			}
+
+			err = template.NewAction(template.WithCache(false))(ctx, &rr)
+			g.Expect(err).Should(HaveOccurred())
+
+			te := &template.TemplateError{}
+			g.Expect(errors.As(err, &te)).Should(BeTrue())
+			g.Expect(te.Path).Should(Equal(tt.path))
+			g.Expect(te.Line).Should(Equal(4))
+			g.Expect(te.Column).Should(Equal(tt.column))
+			g.Expect(te.Source).Should(HavePrefix("name: {{"))
+			g.Expect(err.Error()).Should(ContainSubstring(tt.message))
+		})
+	}
+}
+
+func TestRenderTemplateWithCapabilities(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	tfs := fstest.MapFS{
+		"resources/capabilities.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: capabilities
+data:
+  routes: "{{ .Capabilities.Routes }}"
+  istio: "{{ .Capabilities.Istio }}"
+`),
+		},
+	}
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client:    cl,
+		Instance:  &componentApi.Dashboard{},
+		DSCI:      &dsciv2.DSCInitialization{},
+		Release:   common.Release{Name: cluster.OpenDataHub},
+		Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/capabilities.tmpl.yaml"}},
+	}
+
+	err = template.NewAction(template.WithCache(false), template.WithCapabilities())(ctx, &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		HaveEach(And(
+			jq.Match(`.data.routes == "true"`),
+			jq.Match(`.data.istio == "false"`),
+		)),
+	))
+}
+
+func TestRenderTemplateWithFuncs(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	tfs := fstest.MapFS{
+		"resources/funcs.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: funcs
+data:
+  platform: "{{ platformType }}"
+  version: "{{ platformVersion }}"
+  custom: "{{ greet .Component.Name }}"
+  overridden: "{{ indent 2 "x" }}"
+`),
+		},
+	}
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client:    cl,
+		Instance:  &componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "dashboard"}},
+		DSCI:      &dsciv2.DSCInitialization{},
+		Release:   common.Release{Name: cluster.OpenDataHub},
+		Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/funcs.tmpl.yaml"}},
+	}
+
+	action := template.NewAction(
+		template.WithCache(false),
+		template.WithFuncs(map[string]any{
+			"greet":  func(name string) string { return "hello " + name },
+			"indent": func(_ int, s string) string { return s + "!" },
+		}),
+	)
+
+	g.Expect(action(ctx, &rr)).Should(Succeed())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		HaveEach(And(
+			jq.Match(`.data.platform == "%s"`, cluster.OpenDataHub),
+			jq.Match(`.data.version == "0.0.0"`),
+			jq.Match(`.data.custom == "hello dashboard"`),
+			jq.Match(`.data.overridden == "x!"`),
+		)),
+	))
+}
+
+func TestRenderTemplateWithClusterValues(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	tfs := fstest.MapFS{
+		"resources/cluster.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster
+  namespace: {{ .Cluster.ApplicationsNamespace }}
+data:
+  platform: "{{ .Cluster.Platform }}"
+  openshift: "{{ .Cluster.OpenShift }}"
+  domain: "{{ .Cluster.Domain }}"
+`),
+		},
+	}
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client:   cl,
+		Instance: &componentApi.Dashboard{},
+		DSCI: &dsciv2.DSCInitialization{
+			Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: "test-ns"},
+		},
+		Release:   common.Release{Name: cluster.OpenDataHub},
+		Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/cluster.tmpl.yaml"}},
+	}
+
+	err = template.NewAction(template.WithCache(false), template.WithClusterValues())(ctx, &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(1),
+		HaveEach(And(
+			jq.Match(`.metadata.namespace == "test-ns"`),
+			jq.Match(`.data.platform == "%s"`, cluster.OpenDataHub),
+			jq.Match(`.data.openshift == "false"`),
+			jq.Match(`.data.domain == ""`),
+		)),
+	))
+}
+
+func TestRenderTemplateWithValueMappings(t *testing.T) {
+	tfs := fstest.MapFS{
+		"resources/values.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Values.name }}
+data:
+  replicas: "{{ add1 .Values.scale.replicas }}"
+  enabled: "{{ .Values.scale.enabled }}"
+`),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		replicas string
+		matcher  func(g *WithT, rr *types.ReconciliationRequest, err error)
+	}{
+		{
+			name:     "mapped",
+			replicas: "3",
+			matcher: func(g *WithT, rr *types.ReconciliationRequest, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(rr.Resources).Should(And(
+					HaveLen(1),
+					HaveEach(And(
+						jq.Match(`.metadata.name == "dashboard"`),
+						jq.Match(`.data.replicas == "4"`),
+						jq.Match(`.data.enabled == "true"`),
+					)),
+				))
+			},
+		},
+		{
+			name:     "invalid",
+			replicas: "three",
+			matcher: func(g *WithT, _ *types.ReconciliationRequest, err error) {
+				g.Expect(err).Should(MatchError(ContainSubstring("invalid value Values.scale.replicas")))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			cl, err := fakeclient.New()
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr := types.ReconciliationRequest{
+				Client: cl,
+				Instance: &componentApi.Dashboard{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "dashboard",
+						Annotations: map[string]string{"replicas": tt.replicas},
+					},
+				},
+				DSCI:      &dsciv2.DSCInitialization{},
+				Release:   common.Release{Name: cluster.OpenDataHub},
+				Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/values.tmpl.yaml"}},
+			}
+
+			action := template.NewAction(
+				template.WithCache(false),
+				template.WithFuncs(map[string]any{"add1": func(v int64) int64 { return v + 1 }}),
+				template.WithValueMappings(
+					template.ValueMapping{Path: "{.metadata.name}", Key: "Values.name", Type: template.ValueString},
+					template.ValueMapping{Path: "{.metadata.annotations.replicas}", Key: "Values.scale.replicas", Type: template.ValueInt},
+					template.ValueMapping{Path: "{.spec.enabled}", Key: "Values.scale.enabled", Default: "true", Type: template.ValueBool},
+				),
+			)
+
+			err = action(ctx, &rr)
+			tt.matcher(g, &rr, err)
+		})
+	}
+}
+
+func TestRenderTemplateWithValueExpressions(t *testing.T) {
+	tfs := fstest.MapFS{
+		"resources/values.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Values.name }}
+  namespace: {{ .Values.namespace }}
+data:
+  replicas: "{{ .Values.replicas }}"
+`),
+		},
+	}
+
+	// 20^5 iterations, exceeding the cost limit
+	list := "[" + strings.TrimSuffix(strings.Repeat("0,", 20), ",") + "]"
+	expensive := fmt.Sprintf("%[1]s.all(a, %[1]s.all(b, %[1]s.all(c, %[1]s.all(d, %[1]s.all(e, true)))))", list)
+
+	tests := []struct {
+		name       string
+		expression string
+		matcher    func(g *WithT, rr *types.ReconciliationRequest, err error)
+	}{
+		{
+			name:       "evaluated",
+			expression: `"replicas" in component.metadata.annotations ? int(component.metadata.annotations.replicas) * 2 : 1`,
+			matcher: func(g *WithT, rr *types.ReconciliationRequest, err error) {
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(rr.Resources).Should(And(
+					HaveLen(1),
+					HaveEach(And(
+						jq.Match(`.metadata.name == "dashboard-odh"`),
+						jq.Match(`.metadata.namespace == "test-ns"`),
+						jq.Match(`.data.replicas == "6"`),
+					)),
+				))
+			},
+		},
+		{
+			name:       "invalid",
+			expression: `component.spec.replicas +`,
+			matcher: func(g *WithT, _ *types.ReconciliationRequest, err error) {
+				g.Expect(err).Should(MatchError(ContainSubstring("invalid expression for value Values.replicas")))
+			},
+		},
+		{
+			name:       "too expensive",
+			expression: expensive,
+			matcher: func(g *WithT, _ *types.ReconciliationRequest, err error) {
+				g.Expect(err).Should(MatchError(ContainSubstring("cost limit exceeded")))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			cl, err := fakeclient.New()
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr := types.ReconciliationRequest{
+				Client: cl,
+				Instance: &componentApi.Dashboard{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "dashboard",
+						Annotations: map[string]string{"replicas": "3"},
+					},
+				},
+				DSCI: &dsciv2.DSCInitialization{
+					Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: "test-ns"},
+				},
+				Release:   common.Release{Name: cluster.OpenDataHub},
+				Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/values.tmpl.yaml"}},
+			}
+
+			action := template.NewAction(
+				template.WithCache(false),
+				template.WithValueExpressions(
+					template.ValueExpression{Key: "Values.name", Expression: `component.metadata.name + "-odh"`},
+					template.ValueExpression{Key: "Values.namespace", Expression: `cluster.applicationsNamespace`},
+					template.ValueExpression{Key: "Values.replicas", Expression: tt.expression},
+				),
+			)
+
+			err = action(ctx, &rr)
+			tt.matcher(g, &rr, err)
+		})
+	}
+}
+
+func TestRenderTemplateWithSecretResolver(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	tfs := fstest.MapFS{
+		"resources/secret.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: test-ns
+stringData:
+  user: {{ .Values.user }}
+  password: {{ .Values.password }}
+  host: {{ index .Values.hosts 1 }}
+`),
+		},
+	}
+
+	calls := 0
+	resolver := template.SecretResolverFunc(func(_ context.Context, path string, key string) (string, error) {
+		calls++
+
+		if path != "platform/db" {
+			return "", errors.New("not found")
+		}
+
+		return path + "-" + key, nil
+	})
+
+	values := map[string]any{
+		"user":     "admin",
+		"password": "vault://platform/db#password",
+		"hosts":    []any{"db-0", "vault://platform/db#host"},
+	}
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client:    cl,
+		Instance:  &componentApi.Dashboard{},
+		DSCI:      &dsciv2.DSCInitialization{},
+		Release:   common.Release{Name: cluster.OpenDataHub},
+		Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/secret.tmpl.yaml"}},
+	}
+
+	action := template.NewAction(
+		template.WithCache(false),
+		template.WithData(map[string]any{"Values": values}),
+		template.WithSecretResolver("vault", resolver, time.Hour),
+	)
+
+	for range 2 {
+		rr.Resources = nil
+
+		err = action(ctx, &rr)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(rr.Resources).Should(And(
+			HaveLen(1),
+			HaveEach(And(
+				jq.Match(`.stringData.user == "admin"`),
+				jq.Match(`.stringData.password == "platform/db-password"`),
+				jq.Match(`.stringData.host == "platform/db-host"`),
+			)),
+		))
+	}
+
+	// resolved secrets are cached, and the data set by WithData is untouched
+	g.Expect(calls).Should(Equal(2))
+	g.Expect(values).Should(HaveKeyWithValue("password", "vault://platform/db#password"))
+
+	for ref, msg := range map[string]string{
+		"vault://platform/db":      "invalid secret reference vault://platform/db",
+		"vault://platform/other#x": "unable to resolve secret vault://platform/other#x: not found",
+	} {
+		rr.Resources = nil
+
+		err = template.NewAction(
+			template.WithCache(false),
+			template.WithData(map[string]any{"Values": map[string]any{"user": ref}}),
+			template.WithSecretResolver("vault", resolver, time.Hour),
+		)(ctx, &rr)
+		g.Expect(err).Should(MatchError(ContainSubstring(msg)))
+	}
+}
+
+func TestRenderTemplateWithReleaseValues(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	tfs := fstest.MapFS{
+		"resources/release.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+  namespace: test-ns
+data:
+  revision: "{{ .Release.Revision }}"
+  install: "{{ .Release.IsInstall }}"
+  upgrade: "{{ .Release.IsUpgrade }}"
+`),
+		},
+	}
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client: cl,
+		Instance: &componentApi.Dashboard{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       componentApi.DashboardInstanceName,
+				UID:        apytypes.UID(xid.New().String()),
+				Generation: 1,
+			},
+		},
+		DSCI: &dsciv2.DSCInitialization{
+			Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: "test-ns"},
+		},
+		Release:   common.Release{Name: cluster.OpenDataHub},
+		Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/release.tmpl.yaml"}},
+	}
+
+	action := template.NewAction(
+		template.WithCache(false),
+		template.WithReleaseValues(),
+	)
+
+	render := func() {
+		rr.Resources = nil
+		g.Expect(action(ctx, &rr)).Should(Succeed())
+	}
+
+	// rendering again the same inputs does not change the revision
+	for range 2 {
+		render()
+		g.Expect(rr.Resources).Should(HaveExactElements(And(
+			jq.Match(`.metadata.name == "%s"`, componentApi.DashboardInstanceName),
+			jq.Match(`.data.revision == "1"`),
+			jq.Match(`.data.install == "true"`),
+			jq.Match(`.data.upgrade == "false"`),
+		)))
+	}
+
+	rr.Instance.SetGeneration(2)
+	render()
+
+	g.Expect(rr.Resources).Should(HaveExactElements(And(
+		jq.Match(`.data.revision == "2"`),
+		jq.Match(`.data.install == "false"`),
+		jq.Match(`.data.upgrade == "true"`),
+	)))
+
+	record := corev1.ConfigMap{}
+	err = cl.Get(ctx, client.ObjectKey{Namespace: "test-ns", Name: "dashboard-default-dashboard-release"}, &record)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(record.Data).Should(HaveKeyWithValue(template.ReleaseRevisionKey, "2"))
+}
+
+// largeTemplatesFS returns a file system holding the given number of
+// templates, each rendering a Deployment.
+func largeTemplatesFS(count int) fstest.MapFS {
+	fsys := fstest.MapFS{}
+
+	for i := range count {
+		fsys[fmt.Sprintf("resources/large/deployment-%03d.tmpl.yaml", i)] = &fstest.MapFile{
+			Data: fmt.Appendf(nil, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deployment-%03d
+  namespace: {{ .DSCI.Spec.ApplicationsNamespace }}
+  labels:
+    app: {{ .Component.Name }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: deployment-%03d
+  template:
+    metadata:
+      labels:
+        app: deployment-%03d
+    spec:
+      containers:
+      - name: manager
+        image: quay.io/opendatahub/manager:latest
+        args:
+        - --namespace={{ .DSCI.Spec.ApplicationsNamespace }}
+        - --component={{ .Component.Name }}
+        {{- with .Component.Labels }}
+        env:
+        {{- range $k, $v := . }}
+        - name: {{ $k }}
+          value: {{ $v }}
+        {{- end }}
+        {{- end }}
+`, i, i, i),
+		}
+	}
+
+	return fsys
+}
+
+func largeRequest(cl client.Client, fsys fstest.MapFS) types.ReconciliationRequest {
+	return types.ReconciliationRequest{
+		Client: cl,
+		Instance: &componentApi.Dashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: xid.New().String()},
+		},
+		DSCI: &dsciv2.DSCInitialization{
+			Spec: dsciv2.DSCInitializationSpec{
+				ApplicationsNamespace: xid.New().String(),
+			},
+		},
+		Release:   common.Release{Name: cluster.OpenDataHub},
+		Templates: []types.TemplateInfo{{FS: fsys, Path: "resources/large/*.tmpl.yaml"}},
+	}
+}
+
+func TestRenderTemplateWithBudget(t *testing.T) {
+	g := NewWithT(t)
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	fsys := largeTemplatesFS(10)
+
+	rr := largeRequest(cl, fsys)
+	err = template.NewAction(template.WithCache(false), template.WithRenderBudget(time.Nanosecond, false))(t.Context(), &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.Resources).Should(HaveLen(10))
+
+	rr = largeRequest(cl, fsys)
+	err = template.NewAction(template.WithCache(false), template.WithRenderBudget(time.Nanosecond, true))(t.Context(), &rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("exceeding the budget of 1ns")))
+	g.Expect(rr.Resources).Should(BeEmpty())
+
+	rr = largeRequest(cl, fsys)
+	err = template.NewAction(template.WithCache(false), template.WithRenderBudget(time.Minute, true))(t.Context(), &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.Resources).Should(HaveLen(10))
+}
+
+func BenchmarkRenderTemplate(b *testing.B) {
+	cl, err := fakeclient.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	fsys := largeTemplatesFS(300)
+	action := template.NewAction(template.WithCache(false))
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		rr := largeRequest(cl, fsys)
+		if err := action(b.Context(), &rr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRenderTemplateWithLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits render.Limits
+		err    string
+	}{
+		{
+			name:   "within limits",
+			limits: render.Limits{MaxResources: 10, MaxResourceSize: 4096, MaxTotalSize: 40960},
+		},
+		{
+			name:   "too many resources",
+			limits: render.Limits{MaxResources: 5},
+			err:    "rendered 10 resources, exceeding the limit of 5",
+		},
+		{
+			name:   "resource too large",
+			limits: render.Limits{MaxResourceSize: 64},
+			err:    "exceeding the limit of 64",
+		},
+		{
+			name:   "total too large",
+			limits: render.Limits{MaxTotalSize: 1024},
+			err:    "rendered resources exceed the limit of 1024 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cl, err := fakeclient.New()
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr := largeRequest(cl, largeTemplatesFS(10))
+
+			err = template.NewAction(template.WithCache(false), template.WithRenderLimits(tt.limits))(t.Context(), &rr)
+			if tt.err == "" {
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(rr.Resources).Should(HaveLen(10))
+
+				return
+			}
+
+			g.Expect(err).Should(MatchError(ContainSubstring(tt.err)))
+			g.Expect(errors.As(err, &odherrors.LimitExceededError{})).Should(BeTrue())
+			g.Expect(rr.Resources).Should(BeEmpty())
+		})
+	}
+}
+
+func TestRenderTemplateWithCapabilitiesCache(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	tfs := fstest.MapFS{
+		"resources/capabilities.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: capabilities
+data:
+  routes: "{{ .Capabilities.Routes }}"
+`),
+		},
+	}
+
+	withRoutes, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	s := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(s)).Should(Succeed())
+
+	withoutRoutes, err := fakeclient.New(fakeclient.WithScheme(s))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	action := template.NewAction(template.WithCapabilities())
+
+	for _, tc := range []struct {
+		cli    client.Client
+		routes string
+	}{
+		{cli: withRoutes, routes: "true"},
+		{cli: withoutRoutes, routes: "false"},
+	} {
+		rr := types.ReconciliationRequest{
+			Client:    tc.cli,
+			Instance:  &componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{Generation: 1}},
+			DSCI:      &dsciv2.DSCInitialization{},
+			Release:   common.Release{Name: cluster.OpenDataHub},
+			Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/capabilities.tmpl.yaml"}},
+		}
+
+		err = action(ctx, &rr)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		// the instance did not change, the resources are rendered again
+		// because the capabilities did
+		g.Expect(rr.Generated).Should(BeTrue())
+		g.Expect(rr.Resources).Should(And(
+			HaveLen(1),
+			HaveEach(jq.Match(`.data.routes == "%s"`, tc.routes)),
+		))
+	}
+}
+
+func TestRenderTemplateWithClusterValuesCache(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	tfs := fstest.MapFS{
+		"resources/cluster.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster
+  namespace: {{ .Cluster.ApplicationsNamespace }}
+`),
+		},
+	}
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	action := template.NewAction(template.WithClusterValues())
+
+	for _, ns := range []string{"ns-1", "ns-2"} {
+		// the generations are left untouched, only the cluster values change
+		rr := types.ReconciliationRequest{
+			Client:   cl,
+			Instance: &componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{Generation: 1}},
+			DSCI: &dsciv2.DSCInitialization{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       dsciv2.DSCInitializationSpec{ApplicationsNamespace: ns},
+			},
+			Release:   common.Release{Name: cluster.OpenDataHub},
+			Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/cluster.tmpl.yaml"}},
+		}
+
+		err = action(ctx, &rr)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(rr.Generated).Should(BeTrue())
+		g.Expect(rr.Resources).Should(And(
+			HaveLen(1),
+			HaveEach(jq.Match(`.metadata.namespace == "%s"`, ns)),
+		))
+	}
+}
+
+func TestRenderTemplateLogRedactsSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	tfs := fstest.MapFS{
+		"resources/secret.tmpl.yaml": &fstest.MapFile{
+			Data: []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: test-ns
+stringData:
+  password: {{ .Values.password }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: db
+  namespace: test-ns
+data:
+  host: {{ .Values.host }}
+`),
+		},
+	}
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	resolver := template.SecretResolverFunc(func(_ context.Context, _ string, key string) (string, error) {
+		return "resolved-" + key, nil
+	})
+
+	tests := []struct {
+		name     string
+		opts     []template.ActionOpts
+		contains []string
+	}{
+		{
+			name: "secret",
+			opts: []template.ActionOpts{
+				template.WithData(map[string]any{"Values": map[string]any{"password": "s3cr3t", "host": "db-0"}}),
+			},
+			contains: []string{"password: <redacted>", "host: db-0"},
+		},
+		{
+			name: "resolver",
+			opts: []template.ActionOpts{
+				template.WithData(map[string]any{"Values": map[string]any{"password": "vault://db#password", "host": "vault://db#host"}}),
+				template.WithSecretResolver("vault", resolver, time.Hour),
+			},
+			contains: []string{"kind: Secret", "kind: ConfigMap"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var logs strings.Builder
+			ctx := logf.IntoContext(t.Context(), funcr.New(func(prefix, args string) {
+				logs.WriteString(args)
+			}, funcr.Options{Verbosity: 5}))
+
+			rr := types.ReconciliationRequest{
+				Client:    cl,
+				Instance:  &componentApi.Dashboard{},
+				DSCI:      &dsciv2.DSCInitialization{},
+				Release:   common.Release{Name: cluster.OpenDataHub},
+				Templates: []types.TemplateInfo{{FS: tfs, Path: "resources/secret.tmpl.yaml"}},
+			}
+
+			opts := append([]template.ActionOpts{template.WithCache(false)}, tt.opts...)
+
+			err := template.NewAction(opts...)(ctx, &rr)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(rr.Resources).Should(HaveLen(2))
+
+			g.Expect(logs.String()).Should(And(
+				ContainSubstring(`"msg"="rendered template"`),
+				Not(ContainSubstring("s3cr3t")),
+				Not(ContainSubstring("resolved-")),
+			))
+
+			for _, s := range tt.contains {
+				g.Expect(logs.String()).Should(ContainSubstring(s))
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+type ValueType string
+
+const (
+	// ValueAny keeps the value as found in the component CR.
+	ValueAny    ValueType = ""
+	ValueString ValueType = "string"
+	ValueInt    ValueType = "int"
+	ValueBool   ValueType = "bool"
+)
+
+// ValueMapping maps a field of the component CR to a template data key.
+type ValueMapping struct {
+	// Path is the JSONPath of the field in the component CR, i.e. {.spec.replicas}.
+	Path string
+	// Key is the dot separated path of the value in the template data, i.e.
+	// Values.replicas for {{ .Values.replicas }}.
+	Key string
+	// Default is the value used when the field is not set.
+	Default any
+	// Type is the type the value is converted to.
+	Type ValueType
+}
+
+// WithValueMappings makes the fields of the component CR available to templates
+// as described by the given mappings, so that simple components do not need to
+// compute their template data in code. Values sharing a key prefix are merged,
+// top level keys override the ones set by WithData.
+func WithValueMappings(mappings ...ValueMapping) ActionOpts {
+	return WithDataFn(func(_ context.Context, rr *types.ReconciliationRequest) (map[string]any, error) {
+		return mapValues(rr, mappings)
+	})
+}
+
+func mapValues(rr *types.ReconciliationRequest, mappings []ValueMapping) (map[string]any, error) {
+	obj, err := resources.ToUnstructured(rr.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any)
+
+	for _, m := range mappings {
+		jp := jsonpath.New(m.Key).AllowMissingKeys(true)
+		if err := jp.Parse(m.Path); err != nil {
+			return nil, fmt.Errorf("invalid path %q for value %s: %w", m.Path, m.Key, err)
+		}
+
+		found, err := jp.FindResults(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate path %q for value %s: %w", m.Path, m.Key, err)
+		}
+
+		value := m.Default
+		if len(found) > 0 && len(found[0]) > 0 && found[0][0].CanInterface() {
+			value = found[0][0].Interface()
+		}
+
+		if value != nil {
+			value, err = coerceValue(value, m.Type)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s: %w", m.Key, err)
+			}
+		}
+
+		if err := setValue(result, strings.Split(m.Key, "."), value); err != nil {
+			return nil, fmt.Errorf("invalid value %s: %w", m.Key, err)
+		}
+	}
+
+	return result, nil
+}
+
+func coerceValue(value any, t ValueType) (any, error) {
+	switch t {
+	case ValueAny:
+		return value, nil
+	case ValueString:
+		return fmt.Sprint(value), nil
+	case ValueInt:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case int:
+			return int64(v), nil
+		case float64:
+			return int64(v), nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case ValueBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %q", t)
+	}
+
+	return nil, fmt.Errorf("cannot convert %v (%T) to %s", value, value, t)
+}
+
+func setValue(data map[string]any, path []string, value any) error {
+	for _, k := range path[:len(path)-1] {
+		next, ok := data[k]
+		if !ok {
+			next = make(map[string]any)
+			data[k] = next
+		}
+
+		m, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s is already set to a non map value", k)
+		}
+
+		data = m
+	}
+
+	data[path[len(path)-1]] = value
+
+	return nil
+}
+
+// DefaultExpressionCostLimit is the maximum cost of the evaluation of a value
+// expression, the same used by the API server for CEL validation rules.
+const DefaultExpressionCostLimit uint64 = 1000000
+
+// ValueExpression computes a template data value with a CEL expression. The
+// expression can access the component CR as component, i.e.
+// component.spec.replicas, and the ClusterValues of the cluster as cluster, with
+// lower camel case field names, i.e. cluster.applicationsNamespace.
+type ValueExpression struct {
+	// Key is the dot separated path of the value in the template data.
+	Key        string
+	Expression string
+}
+
+type compiledExpression struct {
+	key     string
+	program cel.Program
+}
+
+// WithValueExpressions makes the result of the given CEL expressions available
+// to templates, merged as with WithValueMappings. Expressions are compiled once,
+// their evaluation fails if its cost exceeds DefaultExpressionCostLimit.
+func WithValueExpressions(exprs ...ValueExpression) ActionOpts {
+	compile := sync.OnceValues(func() ([]compiledExpression, error) {
+		return compileExpressions(exprs)
+	})
+
+	return WithDataFn(func(ctx context.Context, rr *types.ReconciliationRequest) (map[string]any, error) {
+		programs, err := compile()
+		if err != nil {
+			return nil, err
+		}
+
+		return evalExpressions(ctx, rr, programs)
+	})
+}
+
+func compileExpressions(exprs []ValueExpression) ([]compiledExpression, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("component", cel.DynType),
+		cel.Variable("cluster", cel.DynType),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create expressions environment: %w", err)
+	}
+
+	result := make([]compiledExpression, 0, len(exprs))
+
+	for _, e := range exprs {
+		ast, issues := env.Compile(e.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("invalid expression for value %s: %w", e.Key, issues.Err())
+		}
+
+		program, err := env.Program(ast, cel.CostLimit(DefaultExpressionCostLimit))
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression for value %s: %w", e.Key, err)
+		}
+
+		result = append(result, compiledExpression{key: e.Key, program: program})
+	}
+
+	return result, nil
+}
+
+func evalExpressions(ctx context.Context, rr *types.ReconciliationRequest, programs []compiledExpression) (map[string]any, error) {
+	obj, err := resources.ToUnstructured(rr.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cv, err := NewClusterValues(ctx, rr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute cluster values: %w", err)
+	}
+
+	vars := map[string]any{
+		"component": obj.Object,
+		"cluster": map[string]any{
+			"domain":                cv.Domain,
+			"version":               cv.Version,
+			"type":                  cv.Type,
+			"openShift":             cv.OpenShift,
+			"platform":              string(cv.Platform),
+			"applicationsNamespace": cv.ApplicationsNamespace,
+		},
+	}
+
+	result := make(map[string]any)
+
+	for _, p := range programs {
+		out, _, err := p.program.ContextEval(ctx, vars)
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate expression for value %s: %w", p.key, err)
+		}
+
+		native, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+		if err != nil {
+			return nil, fmt.Errorf("unsupported result for value %s: %w", p.key, err)
+		}
+
+		pv, ok := native.(*structpb.Value)
+		if !ok {
+			return nil, fmt.Errorf("unsupported result for value %s: %T", p.key, native)
+		}
+
+		if err := setValue(result, strings.Split(p.key, "."), pv.AsInterface()); err != nil {
+			return nil, fmt.Errorf("invalid value %s: %w", p.key, err)
+		}
+	}
+
+	return result, nil
+}
@@ -2,12 +2,18 @@ package resourcecacher
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/cacher"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
@@ -19,7 +25,28 @@ type Renderer func(ctx context.Context, rr *types.ReconciliationRequest) (resour
 type ResourceCacher struct {
 	cacher.Cacher[resources.UnstructuredList]
 
-	name string
+	// mu serializes the renderings of a component, as the cached resources and
+	// the rendering engine are shared by concurrent reconciles.
+	mu sync.Mutex
+
+	name         string
+	budget       time.Duration
+	strictBudget bool
+	limits       render.Limits
+}
+
+// SetBudget sets the time an actual rendering is expected to take at most. A
+// rendering exceeding it is reported with a warning event, and fails if strict
+// is true.
+func (s *ResourceCacher) SetBudget(budget time.Duration, strict bool) {
+	s.budget = budget
+	s.strictBudget = strict
+}
+
+// SetLimits sets the limits the output of an actual rendering is checked
+// against, a rendering exceeding them fails.
+func (s *ResourceCacher) SetLimits(limits render.Limits) {
+	s.limits = limits
 }
 
 func (s *ResourceCacher) SetKeyFn(key cacher.CachingKeyFn) {
@@ -27,7 +54,10 @@ func (s *ResourceCacher) SetKeyFn(key cacher.CachingKeyFn) {
 }
 
 func (s *ResourceCacher) Render(ctx context.Context, rr *types.ReconciliationRequest, r Renderer) error {
-	log := logf.FromContext(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log := logf.FromContext(ctx).WithValues("engine", s.name)
 	inst, ok := rr.Instance.(common.WithDevFlags)
 	if ok && inst.GetDevFlags() != nil {
 		// if dev flags are enabled, caching is disabled as dev flags are meant for
@@ -36,17 +66,67 @@ func (s *ResourceCacher) Render(ctx context.Context, rr *types.ReconciliationReq
 		s.InvalidateCache()
 	}
 
-	res, acted, err := s.Cacher.Render(ctx, rr, r)
-	if err != nil {
+	controllerName := strings.ToLower(rr.Instance.GetObjectKind().GroupVersionKind().Kind)
+
+	// only account for actual renderings, cache hits are not timed
+	timed := func(ctx context.Context, rr *types.ReconciliationRequest) (resources.UnstructuredList, error) {
+		release, err := render.Acquire(ctx, controllerName, s.name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to acquire a rendering slot: %w", err)
+		}
+
+		defer release()
+
+		start := time.Now()
+		res, err := r(ctx, rr)
+		elapsed := time.Since(start)
+
+		render.RenderDurationSeconds.WithLabelValues(controllerName, s.name).Observe(elapsed.Seconds())
+
+		// normalize the rendered resources, so that they do not spuriously differ
+		// from the live ones
+		for i := range res {
+			if err != nil {
+				break
+			}
+
+			err = resources.Normalize(&res[i])
+		}
+
+		if err == nil {
+			err = s.limits.Check(res)
+		}
+
+		if err != nil || s.budget <= 0 || elapsed <= s.budget {
+			return res, err
+		}
+
+		log.Info("rendering exceeded its budget", "duration", elapsed.String(), "budget", s.budget.String(), "count", len(res))
+		rr.RecordEvent(corev1.EventTypeWarning, render.EventReasonRenderBudgetExceeded,
+			"Rendering %s resources took %s, exceeding the budget of %s", s.name, elapsed, s.budget)
+
+		if s.strictBudget {
+			return nil, fmt.Errorf("rendering took %s, exceeding the budget of %s", elapsed, s.budget)
+		}
+
+		return res, nil
+	}
+
+	res, acted, err := s.Cacher.Render(ctx, rr, timed)
+	if errors.As(err, &odherrors.WaitError{}) {
+		// the data required to render the resources is not yet available
 		return err
 	}
+	if err != nil {
+		rr.RecordEvent(corev1.EventTypeWarning, render.EventReasonRenderError, "Failed to render %s resources: %v", s.name, err)
+		return odherrors.NewRenderError(err)
+	}
 
 	resLen := len(res)
 
 	if acted {
 		log.V(4).Info("accounted rendered resources", "count", resLen)
 
-		controllerName := strings.ToLower(rr.Instance.GetObjectKind().GroupVersionKind().Kind)
 		render.RenderedResourcesTotal.WithLabelValues(controllerName, s.name).Add(float64(resLen))
 
 		// flag new resources, used by GC to avoid useless run
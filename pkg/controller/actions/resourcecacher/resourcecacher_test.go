@@ -3,8 +3,12 @@ package resourcecacher_test
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rs/xid"
 	"github.com/stretchr/testify/mock"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -12,6 +16,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/cacher"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/resourcecacher"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
@@ -74,7 +79,7 @@ func (s *testCacher) GetDevFlags() common.DevFlags {
 	return args.Get(0).(common.DevFlags) //nolint:errcheck,forcetypeassert
 }
 
-func (s *testCacher) hash(rr *types.ReconciliationRequest) ([]byte, error) {
+func (s *testCacher) hash(_ context.Context, rr *types.ReconciliationRequest) ([]byte, error) {
 	args := s.Called(rr)
 	return args.Get(0).([]byte), args.Error(1) //nolint:errcheck,forcetypeassert
 }
@@ -122,6 +127,29 @@ func TestCacherShouldNotRenderSecondRun(t *testing.T) {
 	m.AssertExpectations(t)
 }
 
+func TestCacherShouldOnlyTimeActualRenders(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestCacher()
+
+	m.On("hash", m.rr).Return(newHash(), nil).Twice()
+	m.On("render", m.ctx, m.rr).Return(m.r, nil).Once()
+
+	render.RenderDurationSeconds.Reset()
+
+	_ = m.cacher.Render(m.ctx, m.rr, m.render)
+	g.Expect(testutil.CollectAndCount(render.RenderDurationSeconds)).Should(Equal(1))
+
+	render.RenderDurationSeconds.Reset()
+
+	m.resetGenerated()
+	err := m.cacher.Render(m.ctx, m.rr, m.render)
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(testutil.CollectAndCount(render.RenderDurationSeconds)).Should(Equal(0))
+
+	m.AssertExpectations(t)
+}
+
 func TestCacherShouldRenderDifferentKey(t *testing.T) {
 	g := NewWithT(t)
 	m := newTestCacher()
@@ -234,3 +262,77 @@ func TestCacherShouldAddResources(t *testing.T) {
 
 	m.AssertExpectations(t)
 }
+
+func TestCacherShouldWarnIfBudgetExceeded(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestCacher()
+
+	m.cacher.SetBudget(time.Nanosecond, false)
+
+	m.On("hash", m.rr).Return(newHash(), nil).Once()
+	m.On("render", m.ctx, m.rr).Return(m.r, nil).After(time.Millisecond).Once()
+
+	err := m.cacher.Render(m.ctx, m.rr, m.render)
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(m.rr.Resources).Should(BeEquivalentTo(m.r))
+	g.Expect(m.rr.Generated).Should(BeTrue())
+
+	m.AssertExpectations(t)
+}
+
+func TestCacherShouldErrorIfStrictBudgetExceeded(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestCacher()
+
+	m.cacher.SetBudget(time.Nanosecond, true)
+
+	m.On("hash", m.rr).Return(newHash(), nil).Once()
+	m.On("render", m.ctx, m.rr).Return(m.r, nil).After(time.Millisecond).Once()
+
+	err := m.cacher.Render(m.ctx, m.rr, m.render)
+
+	g.Expect(err).Should(MatchError(ContainSubstring("exceeding the budget of 1ns")))
+	g.Expect(m.rr.Resources).Should(BeEmpty())
+	g.Expect(m.rr.Generated).Should(BeFalse())
+
+	m.AssertExpectations(t)
+}
+
+func TestCacherShouldSerializeRenders(t *testing.T) {
+	g := NewWithT(t)
+
+	c := newCacher(nil)
+
+	var inflight atomic.Int32
+	var overlapped atomic.Bool
+
+	r := func(_ context.Context, _ *types.ReconciliationRequest) (resources.UnstructuredList, error) {
+		if inflight.Add(1) > 1 {
+			overlapped.Store(true)
+		}
+
+		time.Sleep(time.Millisecond)
+		inflight.Add(-1)
+
+		return newResources(), nil
+	}
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			rr := &types.ReconciliationRequest{Instance: &componentApi.Dashboard{}}
+			g.Expect(c.Render(t.Context(), rr, r)).Should(Succeed())
+			g.Expect(rr.Resources).Should(HaveLen(1))
+		}()
+	}
+
+	wg.Wait()
+
+	g.Expect(overlapped.Load()).Should(BeFalse())
+}
@@ -0,0 +1,305 @@
+package servingcert
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+type Provider string
+
+const (
+	// ProviderServiceCA has the certificates generated by the OpenShift service
+	// CA operator.
+	ProviderServiceCA Provider = "service-ca"
+	// ProviderCertManager has the certificates generated by cert-manager.
+	ProviderCertManager Provider = "cert-manager"
+)
+
+const (
+	DefaultMountPath = "/etc/tls/private"
+	SecretNameSuffix = "-tls"
+)
+
+// Certificate is a serving certificate generated for a rendered Service and
+// mounted in the pods of a rendered Deployment.
+type Certificate struct {
+	Service    string
+	Deployment string
+}
+
+// IssuerRef references the cert-manager issuer certificates are requested from.
+type IssuerRef struct {
+	Kind string
+	Name string
+}
+
+// Action has TLS serving certificates generated for the rendered Services of a
+// component and mounted in its rendered Deployments, so that manifests don't
+// need to be aware of the certificate provider of the cluster. It must be placed
+// between the render and the deploy actions.
+//
+// The pod template of the Deployments is annotated with a hash of the
+// certificate so that the pods are rolled out when the certificate is rotated;
+// the component should watch the generated Secrets so that rotation triggers a
+// new reconciliation.
+type Action struct {
+	certificates []Certificate
+	provider     Provider
+	issuer       IssuerRef
+	mountPath    string
+}
+
+type ActionOpts func(*Action)
+
+// WithCertificate has a certificate generated for the given Service and mounted
+// in the containers of the given Deployment, if not empty. The certificate is
+// stored in the Secret set in the annotations.ServingCertSecretName annotation
+// of the Service or, if not set, in a Secret named after the Service with the
+// SecretNameSuffix suffix.
+func WithCertificate(service string, deployment string) ActionOpts {
+	return func(action *Action) {
+		action.certificates = append(action.certificates, Certificate{
+			Service:    service,
+			Deployment: deployment,
+		})
+	}
+}
+
+// WithCertManager has the certificates generated by cert-manager, from the
+// given issuer, instead of the OpenShift service CA operator.
+func WithCertManager(issuerKind string, issuerName string) ActionOpts {
+	return func(action *Action) {
+		action.provider = ProviderCertManager
+		action.issuer = IssuerRef{Kind: issuerKind, Name: issuerName}
+	}
+}
+
+// WithMountPath sets the path certificates are mounted at in the containers,
+// defaults to DefaultMountPath.
+func WithMountPath(value string) ActionOpts {
+	return func(action *Action) {
+		action.mountPath = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	if a.provider == ProviderCertManager && len(a.certificates) > 0 {
+		ok, err := cluster.HasAPI(rr.Client, gvk.CertManagerCertificate)
+		if err != nil {
+			return fmt.Errorf("unable to detect whether cert-manager is available: %w", err)
+		}
+		if !ok {
+			return errors.New("unable to generate serving certificates: cert-manager is not installed")
+		}
+	}
+
+	for _, c := range a.certificates {
+		if err := a.apply(ctx, rr, c); err != nil {
+			return fmt.Errorf("unable to configure serving certificate of service %s: %w", c.Service, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Action) apply(ctx context.Context, rr *types.ReconciliationRequest, c Certificate) error {
+	svc := find(rr, gvk.Service, c.Service)
+	if svc == nil {
+		return errors.New("service not found in the rendered resources")
+	}
+
+	secretName := resources.GetAnnotation(svc, annotations.ServingCertSecretName)
+	if secretName == "" {
+		secretName = c.Service + SecretNameSuffix
+	}
+
+	switch a.provider {
+	case ProviderServiceCA:
+		resources.SetAnnotation(svc, annotations.ServingCertSecretName, secretName)
+	case ProviderCertManager:
+		if err := rr.AddResources(a.certificate(svc, secretName)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported provider %s", a.provider)
+	}
+
+	if c.Deployment == "" {
+		return nil
+	}
+
+	deployment := find(rr, gvk.Deployment, c.Deployment)
+	if deployment == nil {
+		return fmt.Errorf("deployment %s not found in the rendered resources", c.Deployment)
+	}
+
+	if err := a.mount(deployment, secretName); err != nil {
+		return err
+	}
+
+	hash, err := secretHash(ctx, rr.Client, svc.GetNamespace(), secretName)
+	if err != nil || hash == "" {
+		return err
+	}
+
+	return unstructured.SetNestedField(deployment.Object, hash,
+		"spec", "template", "metadata", "annotations", annotations.ServingCertHash)
+}
+
+// certificate returns the cert-manager Certificate for the given Service.
+func (a *Action) certificate(svc *unstructured.Unstructured, secretName string) *unstructured.Unstructured {
+	name := svc.GetName()
+	ns := svc.GetNamespace()
+
+	cert := resources.GvkToUnstructured(gvk.CertManagerCertificate)
+	cert.SetName(name)
+	cert.SetNamespace(ns)
+	cert.Object["spec"] = map[string]any{
+		"secretName": secretName,
+		"dnsNames": []any{
+			name,
+			name + "." + ns,
+			name + "." + ns + ".svc",
+			name + "." + ns + ".svc.cluster.local",
+		},
+		"issuerRef": map[string]any{
+			"group": gvk.CertManagerCertificate.Group,
+			"kind":  a.issuer.Kind,
+			"name":  a.issuer.Name,
+		},
+	}
+
+	return cert
+}
+
+// mount adds a volume for the given Secret to the pod template of the given
+// Deployment, and mounts it in all of its containers.
+func (a *Action) mount(obj *unstructured.Unstructured, secretName string) error {
+	path, _ := resources.PodSpecPath(obj.GroupVersionKind().GroupKind())
+
+	spec, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil {
+		return err
+	}
+	if !found {
+		spec = map[string]any{}
+	}
+
+	volumes, _, err := unstructured.NestedSlice(spec, "volumes")
+	if err != nil {
+		return err
+	}
+
+	if !hasName(volumes, secretName) {
+		volumes = append(volumes, map[string]any{
+			"name": secretName,
+			"secret": map[string]any{
+				"secretName": secretName,
+			},
+		})
+	}
+
+	spec["volumes"] = volumes
+
+	containers, _, err := unstructured.NestedSlice(spec, "containers")
+	if err != nil {
+		return err
+	}
+
+	for i := range containers {
+		container, ok := containers[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		mounts, _, err := unstructured.NestedSlice(container, "volumeMounts")
+		if err != nil {
+			return err
+		}
+
+		if !hasName(mounts, secretName) {
+			mounts = append(mounts, map[string]any{
+				"name":      secretName,
+				"mountPath": a.mountPath,
+				"readOnly":  true,
+			})
+		}
+
+		container["volumeMounts"] = mounts
+	}
+
+	spec["containers"] = containers
+
+	return unstructured.SetNestedMap(obj.Object, spec, path...)
+}
+
+// secretHash returns a hash of the certificate stored in the given Secret, or an
+// empty string if the Secret has not been generated yet.
+func secretHash(ctx context.Context, cli client.Client, ns string, name string) (string, error) {
+	secret := corev1.Secret{}
+
+	err := cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, &secret)
+	switch {
+	case k8serr.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("unable to get secret %s: %w", name, err)
+	}
+
+	crt, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return "", nil
+	}
+
+	sum := sha256.Sum256(crt)
+
+	return resources.EncodeToString(sum[:]), nil
+}
+
+func find(rr *types.ReconciliationRequest, kind schema.GroupVersionKind, name string) *unstructured.Unstructured {
+	for i := range rr.Resources {
+		if rr.Resources[i].GroupVersionKind() == kind && rr.Resources[i].GetName() == name {
+			return &rr.Resources[i]
+		}
+	}
+
+	return nil
+}
+
+func hasName(items []any, name string) bool {
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok && m["name"] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		provider:  ProviderServiceCA,
+		mountPath: DefaultMountPath,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
@@ -0,0 +1,181 @@
+package servingcert_test
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/servingcert"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	certNamespace  = "opendatahub"
+	certService    = "ui"
+	certDeployment = "ui-server"
+)
+
+func newService(svcAnnotations map[string]string) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        certService,
+			Namespace:   certNamespace,
+			Annotations: svcAnnotations,
+		},
+	}
+}
+
+func newDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      certDeployment,
+			Namespace: certNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "server"}, {Name: "proxy"}},
+				},
+			},
+		},
+	}
+}
+
+func TestServingCertServiceCA(t *testing.T) {
+	g := NewWithT(t)
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      certService + servingcert.SecretNameSuffix,
+			Namespace: certNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: []byte("cert"),
+		},
+	}
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithObjects(&secret)),
+		fakerequest.WithResources(newService(nil), newDeployment()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = servingcert.NewAction(servingcert.WithCertificate(certService, certDeployment))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(2),
+		ContainElement(And(
+			jq.Match(`.kind == "Service"`),
+			jq.Match(`.metadata.annotations."%s" == "ui-tls"`, annotations.ServingCertSecretName),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "Deployment"`),
+			jq.Match(`.spec.template.spec.volumes[0].secret.secretName == "ui-tls"`),
+			jq.Match(`[.spec.template.spec.containers[].volumeMounts[0].mountPath] == ["%s", "%s"]`,
+				servingcert.DefaultMountPath, servingcert.DefaultMountPath),
+			jq.Match(`.spec.template.metadata.annotations."%s" | startswith("v")`, annotations.ServingCertHash),
+		)),
+	))
+}
+
+func TestServingCertExistingSecretName(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(
+			newService(map[string]string{annotations.ServingCertSecretName: "custom"}),
+			newDeployment(),
+		),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = servingcert.NewAction(
+		servingcert.WithCertificate(certService, certDeployment),
+		servingcert.WithMountPath("/certs"),
+	)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(ContainElement(And(
+		jq.Match(`.kind == "Deployment"`),
+		jq.Match(`.spec.template.spec.volumes[0].secret.secretName == "custom"`),
+		jq.Match(`.spec.template.spec.containers[0].volumeMounts[0].mountPath == "/certs"`),
+		// the secret has not been generated yet
+		jq.Match(`.spec.template.metadata.annotations == null`),
+	)))
+}
+
+func TestServingCertCertManager(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := scheme.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	s.AddKnownTypeWithName(gvk.CertManagerCertificate, &unstructured.Unstructured{})
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithScheme(s)),
+		fakerequest.WithResources(newService(nil), newDeployment()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = servingcert.NewAction(
+		servingcert.WithCertificate(certService, ""),
+		servingcert.WithCertManager("ClusterIssuer", "platform"),
+	)(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources).Should(And(
+		HaveLen(3),
+		ContainElement(And(
+			jq.Match(`.kind == "Service"`),
+			jq.Match(`.metadata | has("annotations") | not`),
+		)),
+		ContainElement(And(
+			jq.Match(`.kind == "Certificate"`),
+			jq.Match(`.metadata.name == "%s"`, certService),
+			jq.Match(`.spec.secretName == "ui-tls"`),
+			jq.Match(`.spec.dnsNames | index("ui.opendatahub.svc") != null`),
+			jq.Match(`.spec.issuerRef.kind == "ClusterIssuer"`),
+			jq.Match(`.spec.issuerRef.name == "platform"`),
+		)),
+	))
+}
+
+func TestServingCertCertManagerNotInstalled(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newService(nil), newDeployment()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = servingcert.NewAction(
+		servingcert.WithCertificate(certService, certDeployment),
+		servingcert.WithCertManager("ClusterIssuer", "platform"),
+	)(t.Context(), rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("cert-manager is not installed")))
+}
+
+func TestServingCertMissingService(t *testing.T) {
+	g := NewWithT(t)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newService(nil), newDeployment()),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = servingcert.NewAction(servingcert.WithCertificate("missing", ""))(t.Context(), rr)
+	g.Expect(err).Should(MatchError(ContainSubstring("service not found")))
+}
@@ -0,0 +1,84 @@
+package standardlabels
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// Action sets the standard platform labels on the metadata of every rendered
+// resource, regardless of the engine used to render it:
+//   - app.opendatahub.io/<component>: "true"
+//   - app.kubernetes.io/part-of: <component>
+//   - platform.opendatahub.io/part-of: <lowercase kind of the instance>
+//   - platform.opendatahub.io/version and app.kubernetes.io/version: <platform version>
+//
+// Labels already set by the manifests are left untouched, and selectors or pod
+// templates are never modified, so the action can be added to components whose
+// Deployments have immutable selectors. It must be placed between the render and
+// the deploy actions.
+type Action struct {
+	componentName string
+}
+
+type ActionOpts func(*Action)
+
+// WithComponentName sets the name used in the component labels, defaults to
+// the lowercase kind of the reconciled instance.
+func WithComponentName(value string) ActionOpts {
+	return func(action *Action) {
+		action.componentName = value
+	}
+}
+
+func (a *Action) run(_ context.Context, rr *types.ReconciliationRequest) error {
+	kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
+	if err != nil {
+		return err
+	}
+
+	kind = strings.ToLower(kind)
+
+	name := a.componentName
+	if name == "" {
+		name = kind
+	}
+
+	values := map[string]string{
+		labels.ODH.Component(name): labels.True,
+		labels.K8SCommon.PartOf:    name,
+		labels.PlatformPartOf:      kind,
+	}
+
+	// versions are only valid label values if they don't carry build metadata
+	if v := rr.Release.Version.String(); len(validation.IsValidLabelValue(v)) == 0 {
+		values[labels.PlatformVersion] = v
+		values[labels.K8SCommon.Version] = v
+	}
+
+	for i := range rr.Resources {
+		for k, v := range values {
+			if resources.GetLabel(&rr.Resources[i], k) == "" {
+				resources.SetLabel(&rr.Resources[i], k, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
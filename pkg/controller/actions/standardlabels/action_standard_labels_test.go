@@ -0,0 +1,102 @@
+package standardlabels_test
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/operator-framework/api/pkg/lib/version"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/standardlabels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+
+	. "github.com/onsi/gomega"
+)
+
+func newRelease(ver semver.Version) common.Release {
+	return common.Release{
+		Name:    cluster.OpenDataHub,
+		Version: version.OperatorVersion{Version: ver},
+	}
+}
+
+func TestStandardLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cm",
+			Labels: map[string]string{labels.K8SCommon.PartOf: "legacy"},
+		},
+	}
+
+	deployment := appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "dashboard"}},
+		},
+	}
+
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(&componentApi.Dashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+		}),
+		fakerequest.WithRelease(newRelease(semver.Version{Major: 1, Minor: 2, Patch: 3})),
+		fakerequest.WithResources(&cm, &deployment),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = standardlabels.NewAction()(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources[0].GetLabels()).Should(Equal(map[string]string{
+		labels.ODH.Component("dashboard"): labels.True,
+		labels.K8SCommon.PartOf:           "legacy",
+		labels.PlatformPartOf:             "dashboard",
+		labels.PlatformVersion:            "1.2.3",
+		labels.K8SCommon.Version:          "1.2.3",
+	}))
+
+	g.Expect(rr.Resources[1].GetLabels()).Should(HaveKeyWithValue(labels.K8SCommon.PartOf, "dashboard"))
+
+	selector, _, err := unstructured.NestedStringMap(rr.Resources[1].Object, "spec", "selector", "matchLabels")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(selector).Should(Equal(map[string]string{"app": "dashboard"}))
+}
+
+func TestStandardLabelsComponentName(t *testing.T) {
+	g := NewWithT(t)
+
+	cm := corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cm"},
+	}
+
+	rr, err := fakerequest.New(
+		fakerequest.WithInstance(&componentApi.Dashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+		}),
+		fakerequest.WithRelease(newRelease(semver.Version{Major: 1, Build: []string{"abc"}})),
+		fakerequest.WithResources(&cm),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = standardlabels.NewAction(standardlabels.WithComponentName("odh-dashboard"))(t.Context(), rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(rr.Resources[0].GetLabels()).Should(And(
+		HaveKeyWithValue(labels.ODH.Component("odh-dashboard"), labels.True),
+		HaveKeyWithValue(labels.K8SCommon.PartOf, "odh-dashboard"),
+		HaveKeyWithValue(labels.PlatformPartOf, "dashboard"),
+		Not(HaveKey(labels.PlatformVersion)),
+	))
+}
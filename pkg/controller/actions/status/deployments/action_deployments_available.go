@@ -11,6 +11,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/indexes"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
@@ -81,10 +82,16 @@ func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error
 		return fmt.Errorf("unable to compute namespace: %w", err)
 	}
 
+	// the part-of label is looked up through its index, the other ones, if
+	// any, are matched against the indexed objects
+	partOf := l[labels.PlatformPartOf]
+	delete(l, labels.PlatformPartOf)
+
 	err = rr.Client.List(
 		ctx,
 		deployments,
 		client.InNamespace(ns),
+		client.MatchingFields{indexes.PartOf: partOf},
 		client.MatchingLabels(l),
 	)
 
@@ -0,0 +1,239 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	DefaultRequeueAfter = 10 * time.Second
+)
+
+type workloadState int
+
+const (
+	workloadNotReady workloadState = iota
+	workloadReady
+	workloadFailed
+)
+
+// Action checks that the Deployments, StatefulSets and Jobs rendered for the
+// current reconciliation are available (or complete, for Jobs) and reflects
+// the outcome in the WorkloadsAvailable condition. While any of them is not
+// ready, the request is requeued so that readiness is re-evaluated. A failed
+// Job won't recover on its own, so it marks the condition False with the
+// WorkloadFailed reason and no requeue is requested.
+type Action struct {
+	requeueAfter time.Duration
+}
+
+type ActionOpts func(*Action)
+
+// WithRequeueAfter sets the delay after which the request is requeued while
+// any workload is not ready. A value of zero disables the requeue.
+func WithRequeueAfter(d time.Duration) ActionOpts {
+	return func(action *Action) {
+		action.requeueAfter = d
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	obj, ok := rr.Instance.(types.ResourceObject)
+	if !ok {
+		return fmt.Errorf("resource instance %v is not a ResourceObject", rr.Instance)
+	}
+
+	total := 0
+	ready := 0
+	failed := make([]string, 0)
+
+	for i := range rr.Resources {
+		res := &rr.Resources[i]
+
+		// hooks only exist while the component is being removed
+		if resources.GetAnnotation(res, annotations.Hook) != "" {
+			continue
+		}
+
+		var (
+			state workloadState
+			err   error
+		)
+
+		switch res.GroupVersionKind() {
+		case gvk.Deployment:
+			state, err = deploymentState(ctx, rr.Client, res)
+		case gvk.StatefulSet:
+			state, err = statefulSetState(ctx, rr.Client, res)
+		case gvk.Job:
+			state, err = jobState(ctx, rr, res)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("error checking readiness of %s %s/%s: %w",
+				res.GetKind(), res.GetNamespace(), res.GetName(), err)
+		}
+
+		total++
+
+		switch state {
+		case workloadReady:
+			ready++
+		case workloadFailed:
+			failed = append(failed, res.GetNamespace()+"/"+res.GetName())
+		}
+	}
+
+	s := obj.GetStatus()
+
+	if len(failed) != 0 {
+		rr.Conditions.MarkFalse(
+			status.ConditionWorkloadsAvailable,
+			conditions.WithObservedGeneration(s.ObservedGeneration),
+			conditions.WithReason(status.ConditionWorkloadsFailedReason),
+			conditions.WithMessage("%d/%d workloads ready, failed jobs: %s", ready, total, strings.Join(failed, ", ")),
+		)
+
+		return nil
+	}
+
+	if ready != total {
+		rr.Conditions.MarkFalse(
+			status.ConditionWorkloadsAvailable,
+			conditions.WithObservedGeneration(s.ObservedGeneration),
+			conditions.WithReason(status.ConditionWorkloadsNotAvailableReason),
+			conditions.WithMessage("%d/%d workloads ready", ready, total),
+		)
+
+		rr.RequeueAfterAtMost(a.requeueAfter)
+
+		return nil
+	}
+
+	rr.Conditions.MarkTrue(
+		status.ConditionWorkloadsAvailable,
+		conditions.WithObservedGeneration(s.ObservedGeneration),
+	)
+
+	return nil
+}
+
+func deploymentState(ctx context.Context, cli client.Client, res *unstructured.Unstructured) (workloadState, error) {
+	d := appsv1.Deployment{}
+	if found, err := get(ctx, cli, res, &d); err != nil || !found {
+		return workloadNotReady, err
+	}
+
+	if d.Status.ObservedGeneration < d.Generation {
+		return workloadNotReady, nil
+	}
+
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentAvailable && c.Status == corev1.ConditionTrue {
+			return workloadReady, nil
+		}
+	}
+
+	return workloadNotReady, nil
+}
+
+func statefulSetState(ctx context.Context, cli client.Client, res *unstructured.Unstructured) (workloadState, error) {
+	s := appsv1.StatefulSet{}
+	if found, err := get(ctx, cli, res, &s); err != nil || !found {
+		return workloadNotReady, err
+	}
+
+	if s.Status.ObservedGeneration < s.Generation {
+		return workloadNotReady, nil
+	}
+
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+
+	if s.Status.ReadyReplicas < replicas {
+		return workloadNotReady, nil
+	}
+
+	return workloadReady, nil
+}
+
+func jobState(ctx context.Context, rr *types.ReconciliationRequest, res *unstructured.Unstructured) (workloadState, error) {
+	j := batchv1.Job{}
+
+	found, err := get(ctx, rr.Client, res, &j)
+	if err != nil {
+		return workloadNotReady, err
+	}
+
+	// a Job run once is not created again once removed, i.e. by
+	// ttlSecondsAfterFinished, it is done if it has been recorded as run
+	if !found {
+		recorded, err := deploy.JobRecorded(ctx, rr, res)
+		if err != nil || !recorded {
+			return workloadNotReady, err
+		}
+
+		return workloadReady, nil
+	}
+
+	for _, c := range j.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch c.Type {
+		case batchv1.JobComplete:
+			return workloadReady, nil
+		case batchv1.JobFailed:
+			return workloadFailed, nil
+		}
+	}
+
+	return workloadNotReady, nil
+}
+
+func get(ctx context.Context, cli client.Client, res *unstructured.Unstructured, obj client.Object) (bool, error) {
+	err := cli.Get(ctx, client.ObjectKeyFromObject(res), obj)
+	switch {
+	case k8serr.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	default:
+		return true, nil
+	}
+}
+
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{
+		requeueAfter: DefaultRequeueAfter,
+	}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
@@ -0,0 +1,346 @@
+package workloads_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega/gstruct"
+	"github.com/rs/xid"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/hooks"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/workloads"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakerequest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers"
+
+	. "github.com/onsi/gomega"
+)
+
+func newWorkloads(ns string, ready bool) []client.Object {
+	available := corev1.ConditionFalse
+	readyReplicas := int32(0)
+	if ready {
+		available = corev1.ConditionTrue
+		readyReplicas = 1
+	}
+
+	return []client.Object{
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-deployment", Namespace: ns},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:   appsv1.DeploymentAvailable,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+		},
+		&appsv1.StatefulSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "StatefulSet"},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-statefulset", Namespace: ns},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: readyReplicas},
+		},
+		&batchv1.Job{
+			TypeMeta:   metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-job", Namespace: ns},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{
+					Type:   batchv1.JobComplete,
+					Status: available,
+				}},
+			},
+		},
+	}
+}
+
+// newConfigMap returns a rendered resource which is not a workload, and so must
+// be ignored.
+func newConfigMap(ns string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "my-config", "namespace": ns},
+	}}
+}
+
+func TestWorkloadsAvailableActionNotReady(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	objs := newWorkloads(ns, false)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithObjects(objs...)),
+		fakerequest.WithResources(objs...),
+		fakerequest.WithResources(newConfigMap(ns)),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	action := workloads.NewAction(workloads.WithRequeueAfter(5 * time.Second))
+
+	err = action(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.RequeueAfter).Should(Equal(5 * time.Second))
+
+	g.Expect(rr.Instance).Should(
+		WithTransform(
+			matchers.ExtractStatusCondition(status.ConditionTypeReady),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Status": Equal(metav1.ConditionFalse),
+			}),
+		),
+	)
+	g.Expect(rr.Instance).Should(
+		WithTransform(
+			matchers.ExtractStatusCondition(status.ConditionWorkloadsAvailable),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Reason":  Equal(status.ConditionWorkloadsNotAvailableReason),
+				"Message": Equal("1/3 workloads ready"),
+			}),
+		),
+	)
+}
+
+func TestWorkloadsAvailableActionNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	// the workloads are rendered but not yet created
+	rr, err := fakerequest.New(
+		fakerequest.WithResources(newWorkloads(ns, true)...),
+		fakerequest.WithResources(newConfigMap(ns)),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	action := workloads.NewAction()
+
+	err = action(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.RequeueAfter).Should(Equal(workloads.DefaultRequeueAfter))
+
+	g.Expect(rr.Instance).Should(
+		WithTransform(
+			matchers.ExtractStatusCondition(status.ConditionWorkloadsAvailable),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Message": Equal("0/3 workloads ready"),
+			}),
+		),
+	)
+}
+
+func TestWorkloadsAvailableActionReady(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	objs := newWorkloads(ns, true)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithObjects(objs...)),
+		fakerequest.WithResources(objs...),
+		fakerequest.WithResources(newConfigMap(ns)),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	action := workloads.NewAction()
+
+	err = action(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.RequeueAfter).Should(BeZero())
+
+	g.Expect(rr.Instance).Should(
+		WithTransform(
+			matchers.ExtractStatusCondition(status.ConditionTypeReady),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Status": Equal(metav1.ConditionTrue),
+			}),
+		),
+	)
+	g.Expect(rr.Instance).Should(
+		WithTransform(
+			matchers.ExtractStatusCondition(status.ConditionWorkloadsAvailable),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Status": Equal(metav1.ConditionTrue),
+			}),
+		),
+	)
+}
+
+func TestWorkloadsAvailableActionHooks(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	objs := newWorkloads(ns, true)
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithObjects(objs...)),
+		fakerequest.WithResources(objs...),
+		fakerequest.WithResources(newConfigMap(ns)),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	// a pre-delete hook, which does not exist until the component is removed
+	err = rr.AddResources(&batchv1.Job{
+		TypeMeta: metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-hook",
+			Namespace:   ns,
+			Annotations: map[string]string{annotations.Hook: string(hooks.PreDelete)},
+		},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = workloads.NewAction()(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.RequeueAfter).Should(BeZero())
+
+	g.Expect(rr.Instance).Should(
+		WithTransform(
+			matchers.ExtractStatusCondition(status.ConditionWorkloadsAvailable),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Status": Equal(metav1.ConditionTrue),
+			}),
+		),
+	)
+}
+
+func TestWorkloadsAvailableActionJobFailed(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	objs := newWorkloads(ns, true)
+	objs = append(objs, &batchv1.Job{
+		TypeMeta:   metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-failed-job", Namespace: ns},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{
+				Type:   batchv1.JobFailed,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+	})
+
+	rr, err := fakerequest.New(
+		fakerequest.WithClientOpts(fakeclient.WithObjects(objs...)),
+		fakerequest.WithResources(objs...),
+		fakerequest.WithResources(newConfigMap(ns)),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	err = workloads.NewAction()(ctx, rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.RequeueAfter).Should(BeZero())
+
+	g.Expect(rr.Instance).Should(
+		WithTransform(
+			matchers.ExtractStatusCondition(status.ConditionTypeReady),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Status": Equal(metav1.ConditionFalse),
+			}),
+		),
+	)
+	g.Expect(rr.Instance).Should(
+		WithTransform(
+			matchers.ExtractStatusCondition(status.ConditionWorkloadsAvailable),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Reason":  Equal(status.ConditionWorkloadsFailedReason),
+				"Message": Equal("3/4 workloads ready, failed jobs: " + ns + "/my-failed-job"),
+			}),
+		),
+	)
+}
+
+func TestWorkloadsAvailableActionJobRunOnce(t *testing.T) {
+	tests := []struct {
+		name     string
+		recorded bool
+		status   metav1.ConditionStatus
+	}{
+		{name: "removed after its run", recorded: true, status: metav1.ConditionTrue},
+		{name: "not run yet", recorded: false, status: metav1.ConditionFalse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := t.Context()
+			ns := xid.New().String()
+
+			// the Job has been removed by ttlSecondsAfterFinished, only the
+			// record of its run is left
+			existing := make([]client.Object, 0)
+			if tt.recorded {
+				existing = append(existing, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "dashboard-default-dashboard-jobs"},
+					Data:       map[string]string{"my-job": "2025-01-01T00:00:00Z"},
+				})
+			}
+
+			rr, err := fakerequest.New(
+				fakerequest.WithClientOpts(fakeclient.WithObjects(existing...)),
+				fakerequest.WithInstance(&componentApi.Dashboard{
+					ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+				}),
+				fakerequest.WithResources(&batchv1.Job{
+					TypeMeta: metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "my-job",
+						Namespace:   ns,
+						Annotations: map[string]string{annotations.JobRerun: string(deploy.JobRunOnce)},
+					},
+				}),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+			err = workloads.NewAction()(ctx, rr)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			g.Expect(rr.Instance).Should(
+				WithTransform(
+					matchers.ExtractStatusCondition(status.ConditionWorkloadsAvailable),
+					gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+						"Status": Equal(tt.status),
+					}),
+				),
+			)
+		})
+	}
+}
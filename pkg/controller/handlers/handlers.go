@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -91,3 +94,51 @@ func ToAddonParamReq() handler.EventHandler {
 		return nil
 	})
 }
+
+// Debounce returns an handler.EventHandler that delays the requests enqueued by the
+// given handler by the provided window. Since the workqueue de-duplicates the items
+// that are waiting to be added, a burst of events mapped to the same request within
+// the window (i.e. the status updates of a Deployment rollout) results in a single
+// reconciliation.
+func Debounce(window time.Duration, delegate handler.EventHandler) handler.EventHandler {
+	if window <= 0 {
+		return delegate
+	}
+
+	return &debounceHandler{
+		window:   window,
+		delegate: delegate,
+	}
+}
+
+type debounceHandler struct {
+	window   time.Duration
+	delegate handler.EventHandler
+}
+
+func (h *debounceHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.delegate.Create(ctx, e, &debounceQueue{TypedRateLimitingInterface: q, window: h.window})
+}
+
+func (h *debounceHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.delegate.Update(ctx, e, &debounceQueue{TypedRateLimitingInterface: q, window: h.window})
+}
+
+func (h *debounceHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.delegate.Delete(ctx, e, &debounceQueue{TypedRateLimitingInterface: q, window: h.window})
+}
+
+func (h *debounceHandler) Generic(ctx context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.delegate.Generic(ctx, e, &debounceQueue{TypedRateLimitingInterface: q, window: h.window})
+}
+
+// debounceQueue turns any immediate add into a delayed one.
+type debounceQueue struct {
+	workqueue.TypedRateLimitingInterface[reconcile.Request]
+
+	window time.Duration
+}
+
+func (q *debounceQueue) Add(item reconcile.Request) {
+	q.TypedRateLimitingInterface.AddAfter(item, q.window)
+}
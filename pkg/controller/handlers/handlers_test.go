@@ -0,0 +1,60 @@
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/handlers"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDebounce(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	q := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+		workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{Clock: clock},
+	)
+	defer q.ShutDown()
+
+	h := handlers.Debounce(10*time.Second, handlers.ToNamed("default-dashboard"))
+
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	for range 10 {
+		h.Update(ctx, event.UpdateEvent{ObjectOld: obj, ObjectNew: obj}, q)
+	}
+
+	g.Consistently(q.Len).
+		WithTimeout(100 * time.Millisecond).
+		Should(BeZero())
+
+	clock.Step(10 * time.Second)
+
+	g.Eventually(q.Len).
+		Should(Equal(1))
+}
+
+func TestDebounceDisabled(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	q := workqueue.NewTypedRateLimitingQueue(
+		workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+	)
+	defer q.ShutDown()
+
+	h := handlers.Debounce(0, handlers.ToNamed("default-dashboard"))
+	h.Create(ctx, event.CreateEvent{Object: &corev1.ConfigMap{}}, q)
+
+	g.Expect(q.Len()).Should(Equal(1))
+}
@@ -0,0 +1,59 @@
+// Package indexes provides the field indexes registered on the manager cache to
+// efficiently lookup the resources rendered and deployed by the controllers.
+package indexes
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// PartOf indexes objects by the value of the platform.opendatahub.io/part-of
+// label, which the deploy action sets to the name of the owning controller.
+const PartOf = "metadata.labels.part-of"
+
+// Objects returns the types that are indexed on the manager cache.
+func Objects() []client.Object {
+	return []client.Object{
+		&appsv1.Deployment{},
+	}
+}
+
+// Fields returns the indexed fields and the related extractor functions.
+func Fields() map[string]client.IndexerFunc {
+	return map[string]client.IndexerFunc{
+		PartOf: PartOfFn,
+	}
+}
+
+// PartOfFn extracts the value of the platform.opendatahub.io/part-of label.
+func PartOfFn(obj client.Object) []string {
+	v := obj.GetLabels()[labels.PlatformPartOf]
+	if v == "" {
+		return nil
+	}
+
+	return []string{v}
+}
+
+// Register registers all the indexed fields for the given objects, or for the
+// default ones if none is provided.
+func Register(ctx context.Context, indexer client.FieldIndexer, objs ...client.Object) error {
+	if len(objs) == 0 {
+		objs = Objects()
+	}
+
+	for _, obj := range objs {
+		for field, fn := range Fields() {
+			if err := indexer.IndexField(ctx, obj, field, fn); err != nil {
+				return fmt.Errorf("unable to index field %s for %T: %w", field, obj, err)
+			}
+		}
+	}
+
+	return nil
+}
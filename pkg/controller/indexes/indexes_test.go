@@ -0,0 +1,56 @@
+package indexes_test
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/indexes"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestIndexFunctions(t *testing.T) {
+	g := NewWithT(t)
+
+	d := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				labels.PlatformPartOf: "dashboard",
+			},
+		},
+	}
+
+	g.Expect(indexes.PartOfFn(&d)).Should(ConsistOf("dashboard"))
+
+	g.Expect(indexes.PartOfFn(&appsv1.Deployment{})).Should(BeEmpty())
+}
+
+func TestIndexLookup(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "d1",
+			Namespace: "ns",
+			Labels:    map[string]string{labels.PlatformPartOf: "dashboard"},
+		}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "d2",
+			Namespace: "ns",
+			Labels:    map[string]string{labels.PlatformPartOf: "ray"},
+		}},
+	))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	items := appsv1.DeploymentList{}
+	err = cl.List(ctx, &items, client.MatchingFields{indexes.PartOf: "dashboard"})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(items.Items).Should(HaveLen(1))
+	g.Expect(items.Items[0].Name).Should(Equal("d1"))
+}
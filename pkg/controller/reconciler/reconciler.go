@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,6 +32,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
 )
 
 type gvkInfo struct {
@@ -45,6 +49,23 @@ func WithConditionsManagerFactory(happy string, dependants ...string) Reconciler
 	}
 }
 
+// WithProgressConditions enables the Progressing and Degraded conditions, which are
+// computed out of the outcome of the actions and of the happy condition.
+func WithProgressConditions() ReconcilerOpt {
+	return func(reconciler *Reconciler) {
+		reconciler.progressConditions = true
+	}
+}
+
+// WithResyncPeriod makes the reconciler requeue each successfully reconciled
+// request after the given period, so that drifts are corrected even when no
+// watch event is triggered (i.e. for kinds that are not watched).
+func WithResyncPeriod(period time.Duration) ReconcilerOpt {
+	return func(reconciler *Reconciler) {
+		reconciler.resyncPeriod = period
+	}
+}
+
 const platformFinalizer = "platform.opendatahub.io/finalizer"
 
 // Reconciler provides generic reconciliation functionality for ODH objects.
@@ -65,6 +86,8 @@ type Reconciler struct {
 	instanceFactory          func() (common.PlatformObject, error)
 	conditionsManagerFactory func(common.ConditionsAccessor) *conditions.Manager
 	gvks                     map[schema.GroupVersionKind]gvkInfo
+	progressConditions       bool
+	resyncPeriod             time.Duration
 }
 
 // NewReconciler creates a new reconciler for the given type.
@@ -129,6 +152,10 @@ func (r *Reconciler) GetDynamicClient() dynamic.Interface {
 	return r.dynamicClient
 }
 
+func (r *Reconciler) GetEventRecorder() record.EventRecorder {
+	return r.Recorder
+}
+
 func (r *Reconciler) AddOwnedType(gvk schema.GroupVersionKind) {
 	r.gvks[gvk] = gvkInfo{
 		owned: true,
@@ -149,6 +176,21 @@ func (r *Reconciler) AddFinalizer(action actions.Fn) {
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.Tracer().Start(ctx, r.name+".reconcile",
+		trace.WithAttributes(
+			attribute.String("controller", r.name),
+			attribute.String("namespace", req.Namespace),
+			attribute.String("name", req.Name),
+		),
+	)
+
+	result, err := r.reconcile(ctx, req)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	l := log.FromContext(ctx)
 	l.Info("reconcile")
 
@@ -172,6 +214,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 
 		if err := r.delete(ctx, res); err != nil {
+			// a finalizer waiting for a condition to be met, i.e. a pre-delete
+			// hook to complete, is not a failure
+			we := odherrors.WaitError{}
+			if errors.As(err, &we) {
+				l.Info("waiting for finalizers", "reason", we.Error())
+				return ctrl.Result{RequeueAfter: we.RequeueAfter()}, nil
+			}
+
 			return ctrl.Result{}, err
 		}
 
@@ -184,9 +234,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			return ctrl.Result{}, err
 		}
 
-		if err := r.apply(ctx, res); err != nil {
-			return ctrl.Result{}, err
-		}
+		return r.apply(ctx, res)
 	}
 
 	return ctrl.Result{}, nil
@@ -240,10 +288,15 @@ func (r *Reconciler) delete(ctx context.Context, res common.PlatformObject) erro
 
 		// The DSCI should not be required when deleting a component, if the
 		// component requires some additional info, then such info should be
-		// stored as part of the spec/status
+		// stored as part of the spec/status. It is only looked up so that
+		// finalizers rendering pre-delete hooks can use it when available.
 		DSCI: nil,
 	}
 
+	if dsci, err := cluster.GetDSCI(ctx, r.Client); err == nil {
+		rr.DSCI = dsci.DeepCopy()
+	}
+
 	// Execute finalizers
 	for _, action := range r.Finalizer {
 		l.V(3).Info("Executing finalizer", "action", action)
@@ -253,7 +306,15 @@ func (r *Reconciler) delete(ctx context.Context, res common.PlatformObject) erro
 			l.WithName(actions.ActionGroup).WithName(action.String()),
 		)
 
-		if err := action(actx, &rr); err != nil {
+		actx, span := tracing.Tracer().Start(actx, action.String())
+		err := action(actx, &rr)
+		tracing.End(span, err)
+
+		if err != nil {
+			if errors.As(err, &odherrors.WaitError{}) {
+				return err
+			}
+
 			se := odherrors.StopError{}
 			if !errors.As(err, &se) {
 				l.Error(err, "Failed to execute finalizer", "action", action)
@@ -268,10 +329,12 @@ func (r *Reconciler) delete(ctx context.Context, res common.PlatformObject) erro
 	return nil
 }
 
-func (r *Reconciler) apply(ctx context.Context, res common.PlatformObject) error {
+func (r *Reconciler) apply(ctx context.Context, res common.PlatformObject) (ctrl.Result, error) {
 	l := log.FromContext(ctx)
 	l.Info("apply")
 
+	start := time.Now()
+
 	rr := types.ReconciliationRequest{
 		Client:     r.Client,
 		Controller: r,
@@ -306,40 +369,94 @@ func (r *Reconciler) apply(ctx context.Context, res common.PlatformObject) error
 				l.WithName(actions.ActionGroup).WithName(action.String()),
 			)
 
+			actx, span := tracing.Tracer().Start(actx, action.String())
 			provisionErr = action(actx, &rr)
+			tracing.End(span, provisionErr)
+
 			if provisionErr != nil {
 				break
 			}
 		}
 	}
 
-	if provisionErr != nil {
+	var we odherrors.WaitError
+
+	switch {
+	case errors.As(provisionErr, &we):
+		// an action waiting for a condition to be met is not a failure, the
+		// remaining actions have been skipped and the request is requeued
+		rr.Conditions.MarkFalse(
+			status.ConditionTypeProvisioningSucceeded,
+			conditions.WithReason(status.WaitingReason),
+			conditions.WithMessage("%s", we.Error()),
+			conditions.WithObservedGeneration(rr.Instance.GetGeneration()),
+		)
+
+		rr.RequeueAfterAtMost(we.RequeueAfter())
+
+		provisionErr = nil
+	case provisionErr != nil:
 		rr.Conditions.MarkFalse(
 			status.ConditionTypeProvisioningSucceeded,
 			conditions.WithError(provisionErr),
+			conditions.WithReason(provisioningFailureReason(provisionErr)),
 			conditions.WithObservedGeneration(rr.Instance.GetGeneration()),
 		)
-	} else {
+	default:
 		rr.Conditions.MarkTrue(
 			status.ConditionTypeProvisioningSucceeded,
 			conditions.WithObservedGeneration(rr.Instance.GetGeneration()),
 		)
 	}
 
+	// dev flags replace the shipped manifests, flag the instance so that
+	// support can tell it runs a custom configuration, without making it
+	// unhappy, and drop the flag once they are removed
+	if resources.InstanceHasDevFlags(rr.Instance) {
+		rr.Conditions.MarkFalse(
+			status.ConditionSupportedConfiguration,
+			conditions.WithReason(status.DevFlagsSetReason),
+			conditions.WithMessage(status.DevFlagsSetMessage),
+			conditions.WithSeverity(common.ConditionSeverityInfo),
+			conditions.WithObservedGeneration(rr.Instance.GetGeneration()),
+		)
+	} else if err := rr.Conditions.ClearCondition(status.ConditionSupportedConfiguration); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	reconciled := ReconcileInfo{
+		Time:      start,
+		Duration:  time.Since(start).String(),
+		Resources: len(rr.Resources),
+	}
+	if provisionErr != nil {
+		reconciled.Error = provisionErr.Error()
+	}
+
+	controllers.reconciled(r.name, reconciled)
+
 	is := rr.Instance.GetStatus()
 	is.Phase = status.PhaseNotReady
 
+	// the status always reflects the outcome of the reconciliation of the
+	// current generation, regardless of it being successful or not, so that
+	// clients can tell whether the status is up to date with the spec
+	is.ObservedGeneration = rr.Instance.GetGeneration()
+
 	// Update happiness to cover the case where conditions were
 	// not set using the provided helper functions
 	rr.Conditions.RecomputeHappiness("")
 
+	if r.progressConditions {
+		markProgress(rr.Conditions, provisionErr, rr.Instance.GetGeneration())
+	}
+
 	// keep conditions sorted, keeping general conditions on the
 	// top, other conditions after
 	rr.Conditions.Sort()
 
 	if rr.Conditions.IsHappy() {
 		is.Phase = status.PhaseReady
-		is.ObservedGeneration = rr.Instance.GetGeneration()
 	}
 
 	err := resources.ApplyStatus(
@@ -358,7 +475,7 @@ func (r *Reconciler) apply(ctx context.Context, res common.PlatformObject) error
 			err.Error(),
 		)
 
-		return fmt.Errorf("reconcile failed: %w", err)
+		return ctrl.Result{}, fmt.Errorf("reconcile failed: %w", err)
 	}
 
 	if provisionErr != nil {
@@ -369,8 +486,83 @@ func (r *Reconciler) apply(ctx context.Context, res common.PlatformObject) error
 			provisionErr.Error(),
 		)
 
-		return fmt.Errorf("provisioning failed: %w", provisionErr)
+		return ctrl.Result{}, fmt.Errorf("provisioning failed: %w", provisionErr)
 	}
 
-	return nil
+	rr.RequeueAfterAtMost(r.resyncPeriod)
+
+	return ctrl.Result{RequeueAfter: rr.RequeueAfter}, nil
+}
+
+// provisioningFailureReason maps the error returned by an action to the reason of
+// the ProvisioningSucceeded condition.
+func provisioningFailureReason(err error) string {
+	var re odherrors.RenderError
+	var le odherrors.LimitExceededError
+	var ree odherrors.RetriesExhaustedError
+	var ve odherrors.ValidationError
+
+	switch {
+	case errors.As(err, &le):
+		return status.RenderLimitExceededReason
+	case errors.As(err, &re):
+		return status.RenderErrorReason
+	case errors.As(err, &ree):
+		return status.ApplyRetriesExhaustedReason
+	case errors.As(err, &ve):
+		return status.ValidationFailedReason
+	case k8serr.IsConflict(err):
+		return status.ApplyConflictReason
+	default:
+		return status.ErrorReason
+	}
+}
+
+// markProgress sets the Progressing and Degraded conditions:
+//   - Degraded is True when any action has failed
+//   - Progressing is True while the resource is not yet ready but no action has failed
+//   - both are False once the resource is ready.
+func markProgress(m *conditions.Manager, err error, generation int64) {
+	switch {
+	case err != nil:
+		m.MarkTrue(
+			status.ConditionTypeDegraded,
+			conditions.WithReason(provisioningFailureReason(err)),
+			conditions.WithMessage("%s", err.Error()),
+			conditions.WithObservedGeneration(generation),
+		)
+		m.MarkFalse(
+			status.ConditionTypeProgressing,
+			conditions.WithReason(status.DegradedReason),
+			conditions.WithObservedGeneration(generation),
+		)
+	case m.IsHappy():
+		m.MarkFalse(
+			status.ConditionTypeDegraded,
+			conditions.WithReason(status.ReadyReason),
+			conditions.WithObservedGeneration(generation),
+		)
+		m.MarkFalse(
+			status.ConditionTypeProgressing,
+			conditions.WithReason(status.ReadyReason),
+			conditions.WithObservedGeneration(generation),
+		)
+	default:
+		msg := ""
+		if c := m.GetTopLevelCondition(); c != nil {
+			msg = c.Message
+		}
+
+		m.MarkFalse(
+			status.ConditionTypeDegraded,
+			conditions.WithReason(status.NotReadyReason),
+			conditions.WithObservedGeneration(generation),
+		)
+		m.MarkTrue(
+			status.ConditionTypeProgressing,
+			conditions.WithReason(status.NotReadyReason),
+			conditions.WithMessage("%s", msg),
+			conditions.WithObservedGeneration(generation),
+		)
+	}
 }
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 )
@@ -20,10 +22,13 @@ type dynamicWatchAction struct {
 	fn      dynamicWatchFn
 	watches []watchInput
 	watched map[schema.GroupVersionKind]struct{}
+	// onWatch, if set, is notified of each registered watch
+	onWatch func(schema.GroupVersionKind)
 }
 
 func (a *dynamicWatchAction) run(ctx context.Context, rr *types.ReconciliationRequest) error {
 	controllerName := strings.ToLower(rr.Instance.GetObjectKind().GroupVersionKind().Kind)
+	refreshed := false
 
 	for i := range a.watches {
 		w := a.watches[i]
@@ -39,6 +44,14 @@ func (a *dynamicWatchAction) run(ctx context.Context, rr *types.ReconciliationRe
 			continue
 		}
 
+		// the API of the resource has just been installed, make sure it is known
+		// before the watch is registered and resources of that kind are applied
+		// by the next actions
+		if !refreshed {
+			refreshAPIs(rr)
+			refreshed = true
+		}
+
 		err := a.fn(w.object, w.eventHandler, w.predicates...)
 		if err != nil {
 			return fmt.Errorf("failed to create watcher for %s: %w", w.object.GetObjectKind().GroupVersionKind(), err)
@@ -46,8 +59,14 @@ func (a *dynamicWatchAction) run(ctx context.Context, rr *types.ReconciliationRe
 
 		a.watched[gvk] = struct{}{}
 		DynamicWatchResourcesTotal.WithLabelValues(controllerName).Inc()
+
+		if a.onWatch != nil {
+			a.onWatch(gvk)
+		}
 	}
 
+	DynamicWatchResourcesPending.WithLabelValues(controllerName).Set(float64(len(a.watches) - len(a.watched)))
+
 	return nil
 }
 
@@ -62,6 +81,22 @@ func (a *dynamicWatchAction) shouldWatch(ctx context.Context, in watchInput, rr
 	return true
 }
 
+// refreshAPIs drops the discovery data cached by the client RESTMapper, if it
+// supports being reset, and by the cluster.APICache attached to the client, if
+// any. The manager is created with a cluster.ResettableRESTMapper for the
+// former.
+func refreshAPIs(rr *types.ReconciliationRequest) {
+	if rr.Client == nil {
+		return
+	}
+
+	cluster.InvalidateAPICache(rr.Client)
+
+	if m, ok := rr.Client.RESTMapper().(meta.ResettableRESTMapper); ok {
+		m.Reset()
+	}
+}
+
 func newDynamicWatch(fn dynamicWatchFn, watches []watchInput) *dynamicWatchAction {
 	action := dynamicWatchAction{
 		fn:      fn,
@@ -80,7 +115,9 @@ func newDynamicWatch(fn dynamicWatchFn, watches []watchInput) *dynamicWatchActio
 	return &action
 }
 
-func newDynamicWatchAction(fn dynamicWatchFn, watches []watchInput) actions.Fn {
+func newDynamicWatchAction(fn dynamicWatchFn, watches []watchInput, onWatch func(schema.GroupVersionKind)) actions.Fn {
 	action := newDynamicWatch(fn, watches)
+	action.onWatch = onWatch
+
 	return action.run
 }
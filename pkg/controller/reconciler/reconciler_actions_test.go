@@ -8,6 +8,7 @@ import (
 	gTypes "github.com/onsi/gomega/types"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rs/xid"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -18,6 +19,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
 
 	. "github.com/onsi/gomega"
 )
@@ -159,6 +161,7 @@ func TestDynamicWatchAction_Inputs(t *testing.T) {
 
 	DynamicWatchResourcesTotal.Reset()
 	DynamicWatchResourcesTotal.WithLabelValues("dashboard").Add(0)
+	DynamicWatchResourcesPending.Reset()
 
 	watches := []watchInput{
 		{
@@ -191,6 +194,8 @@ func TestDynamicWatchAction_Inputs(t *testing.T) {
 		ShouldNot(HaveOccurred())
 	g.Expect(testutil.ToFloat64(DynamicWatchResourcesTotal)).
 		Should(BeNumerically("==", 1))
+	g.Expect(testutil.ToFloat64(DynamicWatchResourcesPending)).
+		Should(BeNumerically("==", 1))
 	g.Expect(action.watched).
 		Should(And(
 			HaveLen(1),
@@ -253,3 +258,55 @@ func TestDynamicWatchAction_NotTwice(t *testing.T) {
 			HaveKey(gvk.ConfigMap)),
 		)
 }
+
+type resettableMapper struct {
+	meta.RESTMapper
+
+	resets int
+}
+
+func (m *resettableMapper) Reset() {
+	m.resets++
+}
+
+type resettableClient struct {
+	client.Client
+
+	mapper *resettableMapper
+}
+
+func (c *resettableClient) RESTMapper() meta.RESTMapper {
+	return c.mapper
+}
+
+func TestDynamicWatchAction_ResetRESTMapper(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	mockFn := func(_ client.Object, _ handler.EventHandler, _ ...predicate.Predicate) error {
+		return nil
+	}
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rc := &resettableClient{Client: cl, mapper: &resettableMapper{RESTMapper: cl.RESTMapper()}}
+
+	watches := []watchInput{
+		{object: resources.GvkToUnstructured(gvk.ConfigMap), dynamic: true},
+		{object: resources.GvkToUnstructured(gvk.Secret), dynamic: true},
+	}
+
+	action := newDynamicWatch(mockFn, watches)
+	rr := &types.ReconciliationRequest{
+		Client:   rc,
+		Instance: &componentApi.Dashboard{TypeMeta: metav1.TypeMeta{Kind: gvk.Dashboard.Kind}},
+	}
+
+	g.Expect(action.run(ctx, rr)).Should(Succeed())
+	g.Expect(rc.mapper.resets).Should(Equal(1))
+
+	// no new watch, no reset
+	g.Expect(action.run(ctx, rr)).Should(Succeed())
+	g.Expect(rc.mapper.resets).Should(Equal(1))
+}
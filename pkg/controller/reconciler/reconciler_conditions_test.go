@@ -0,0 +1,100 @@
+//nolint:testpackage
+package reconciler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestProvisioningFailureReason(t *testing.T) {
+	g := NewWithT(t)
+
+	renderErr := fmt.Errorf("wrapped: %w", odherrors.NewRenderError(errors.New("render")))
+	conflictErr := k8serr.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm", errors.New("conflict"))
+
+	g.Expect(provisioningFailureReason(renderErr)).Should(Equal(status.RenderErrorReason))
+	g.Expect(provisioningFailureReason(odherrors.NewRenderError(odherrors.NewLimitExceededError("limit")))).Should(Equal(status.RenderLimitExceededReason))
+	g.Expect(provisioningFailureReason(conflictErr)).Should(Equal(status.ApplyConflictReason))
+	g.Expect(provisioningFailureReason(odherrors.NewRetriesExhaustedError(3, conflictErr))).Should(Equal(status.ApplyRetriesExhaustedReason))
+	g.Expect(provisioningFailureReason(odherrors.NewValidationError(errors.New("invalid")))).Should(Equal(status.ValidationFailedReason))
+	g.Expect(provisioningFailureReason(errors.New("failure"))).Should(Equal(status.ErrorReason))
+}
+
+func TestMarkProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		ready       bool
+		degraded    metav1.ConditionStatus
+		progressing metav1.ConditionStatus
+		reason      string
+	}{
+		{
+			name:        "failure",
+			err:         odherrors.NewRenderError(errors.New("render")),
+			degraded:    metav1.ConditionTrue,
+			progressing: metav1.ConditionFalse,
+			reason:      status.RenderErrorReason,
+		},
+		{
+			name:        "ready",
+			ready:       true,
+			degraded:    metav1.ConditionFalse,
+			progressing: metav1.ConditionFalse,
+			reason:      status.ReadyReason,
+		},
+		{
+			name:        "waiting",
+			degraded:    metav1.ConditionFalse,
+			progressing: metav1.ConditionTrue,
+			reason:      status.NotReadyReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dash := componentApi.Dashboard{}
+			m := conditions.NewManager(&dash, status.ConditionTypeReady, status.ConditionDeploymentsAvailable)
+
+			if tt.ready {
+				m.MarkTrue(status.ConditionDeploymentsAvailable)
+			} else {
+				m.MarkFalse(status.ConditionDeploymentsAvailable, conditions.WithReason(status.NotReadyReason))
+			}
+
+			markProgress(m, tt.err, 3)
+
+			degraded := m.GetCondition(status.ConditionTypeDegraded)
+			g.Expect(degraded).ShouldNot(BeNil())
+			g.Expect(degraded.Status).Should(Equal(tt.degraded))
+			g.Expect(degraded.ObservedGeneration).Should(Equal(int64(3)))
+
+			progressing := m.GetCondition(status.ConditionTypeProgressing)
+			g.Expect(progressing).ShouldNot(BeNil())
+			g.Expect(progressing.Status).Should(Equal(tt.progressing))
+			g.Expect(progressing.ObservedGeneration).Should(Equal(int64(3)))
+
+			if tt.degraded == metav1.ConditionTrue {
+				g.Expect(degraded.Reason).Should(Equal(tt.reason))
+			} else {
+				g.Expect(progressing.Reason).Should(Equal(tt.reason))
+			}
+
+			g.Expect(m.IsHappy()).Should(Equal(tt.ready))
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
 	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
 	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 )
 
@@ -251,3 +252,43 @@ func TestFinalizer_Remove(t *testing.T) {
 	g.Expect(err).To(gomega.HaveOccurred())
 	g.Expect(client.IgnoreNotFound(err)).To(gomega.Succeed())
 }
+
+func TestFinalizer_Wait(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mockDashboard := &componentApi.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       mockDashboardName,
+			Finalizers: []string{platformFinalizer},
+			DeletionTimestamp: &metav1.Time{
+				Time: time.Now(),
+			},
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       componentApi.DashboardKind,
+			APIVersion: componentApi.GroupVersion.Version,
+		},
+	}
+
+	ctx, mgr, cli := setupTest(mockDashboard)
+
+	r, err := ReconcilerFor(mgr, mockDashboard).
+		WithFinalizer(func(ctx context.Context, rr *odhtypes.ReconciliationRequest) error {
+			g.Expect(rr.DSCI).ShouldNot(gomega.BeNil())
+			return odherrors.NewWaitError(time.Minute, "waiting for cleanup")
+		}).
+		Build(ctx)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	res, err := r.Reconcile(ctx, reconcile.Request{
+		NamespacedName: client.ObjectKey{
+			Name: mockDashboardName,
+		},
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(res.RequeueAfter).To(gomega.Equal(time.Minute))
+
+	d := &componentApi.Dashboard{}
+	g.Expect(cli.Get(ctx, client.ObjectKey{Name: mockDashboardName}, d)).To(gomega.Succeed())
+	g.Expect(controllerutil.ContainsFinalizer(d, finalizerName)).To(gomega.BeTrue())
+}
@@ -0,0 +1,137 @@
+package reconciler
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// IntrospectionPath is the path the introspection handler is served at by
+// the metrics server.
+const IntrospectionPath = "/debug/controllers"
+
+// WatchInfo describes a resource watched by a controller.
+type WatchInfo struct {
+	GVK     string `json:"gvk"`
+	Owned   bool   `json:"owned"`
+	Dynamic bool   `json:"dynamic"`
+	// Active is false for dynamic watches not registered yet, i.e. while
+	// the CRD of the resource is not installed.
+	Active bool `json:"active"`
+}
+
+// ReconcileInfo describes the last reconciliation of a controller.
+type ReconcileInfo struct {
+	Time      time.Time `json:"time"`
+	Duration  string    `json:"duration"`
+	Resources int       `json:"resources"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ControllerInfo describes a controller built with the ReconcilerBuilder, for
+// support data collection.
+type ControllerInfo struct {
+	Name          string         `json:"name"`
+	Kind          string         `json:"kind"`
+	Actions       int            `json:"actions"`
+	Finalizers    int            `json:"finalizers"`
+	Watches       []WatchInfo    `json:"watches"`
+	LastReconcile *ReconcileInfo `json:"lastReconcile,omitempty"`
+}
+
+// introspection keeps track of the controllers and of their state, it is
+// shared by all the controllers of the manager.
+type introspection struct {
+	mu          sync.RWMutex
+	controllers map[string]*ControllerInfo
+}
+
+var controllers = &introspection{
+	controllers: map[string]*ControllerInfo{},
+}
+
+func (i *introspection) register(info ControllerInfo) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.controllers[info.Name] = &info
+}
+
+func (i *introspection) activate(name string, gvk schema.GroupVersionKind) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	info, ok := i.controllers[name]
+	if !ok {
+		return
+	}
+
+	for w := range info.Watches {
+		if info.Watches[w].GVK == gvk.String() {
+			info.Watches[w].Active = true
+		}
+	}
+}
+
+func (i *introspection) reconciled(name string, in ReconcileInfo) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	info, ok := i.controllers[name]
+	if !ok {
+		return
+	}
+
+	info.LastReconcile = &in
+}
+
+func (i *introspection) list() []ControllerInfo {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	res := make([]ControllerInfo, 0, len(i.controllers))
+	for _, info := range i.controllers {
+		c := *info
+		c.Watches = slices.Clone(info.Watches)
+
+		res = append(res, c)
+	}
+
+	slices.SortFunc(res, func(a ControllerInfo, b ControllerInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return res
+}
+
+// Controllers returns the description of the controllers built with the
+// ReconcilerBuilder, sorted by name.
+func Controllers() []ControllerInfo {
+	return controllers.list()
+}
+
+// IntrospectionHandler returns a read-only handler serving, as JSON, the
+// registered controllers with their watches, the dynamic watches pending
+// registration and the outcome of their last reconciliation.
+func IntrospectionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(Controllers()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
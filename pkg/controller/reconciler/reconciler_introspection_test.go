@@ -0,0 +1,61 @@
+//nolint:testpackage
+package reconciler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestIntrospectionHandler(t *testing.T) {
+	g := NewWithT(t)
+
+	controllers.register(ControllerInfo{
+		Name: "introspection-test",
+		Kind: "Dashboard",
+		Watches: []WatchInfo{
+			{GVK: gvk.Deployment.String(), Owned: true, Active: true},
+			{GVK: gvk.ServiceMonitor.String(), Owned: true, Dynamic: true},
+			{GVK: gvk.PrometheusRule.String(), Owned: true, Dynamic: true},
+		},
+	})
+
+	controllers.activate("introspection-test", gvk.ServiceMonitor)
+	controllers.reconciled("introspection-test", ReconcileInfo{
+		Time:      time.Now(),
+		Duration:  time.Second.String(),
+		Resources: 3,
+		Error:     "failure",
+	})
+
+	rec := httptest.NewRecorder()
+	IntrospectionHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, IntrospectionPath, nil))
+
+	g.Expect(rec.Code).Should(Equal(http.StatusOK))
+	g.Expect(rec.Header().Get("Content-Type")).Should(Equal("application/json"))
+
+	var infos []ControllerInfo
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &infos)).Should(Succeed())
+
+	g.Expect(infos).Should(ContainElement(And(
+		HaveField("Name", "introspection-test"),
+		HaveField("Watches", ConsistOf(
+			WatchInfo{GVK: gvk.Deployment.String(), Owned: true, Active: true},
+			WatchInfo{GVK: gvk.ServiceMonitor.String(), Owned: true, Dynamic: true, Active: true},
+			WatchInfo{GVK: gvk.PrometheusRule.String(), Owned: true, Dynamic: true},
+		)),
+		HaveField("LastReconcile.Resources", 3),
+		HaveField("LastReconcile.Error", "failure"),
+	)))
+
+	rec = httptest.NewRecorder()
+	IntrospectionHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, IntrospectionPath, nil))
+
+	g.Expect(rec.Code).Should(Equal(http.StatusMethodNotAllowed))
+}
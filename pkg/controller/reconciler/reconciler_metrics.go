@@ -19,6 +19,74 @@ var (
 			"controller",
 		},
 	)
+
+	// DynamicWatchResourcesPending is a prometheus gauge metrics which holds the
+	// number of dynamic watches that have been declared but not yet registered
+	// (i.e. because the related CRD is not available) per controller.
+	// It has one labels.
+	// controller label refers to the controller name.
+	DynamicWatchResourcesPending = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "action_dynamic_watch_pending",
+			Help: "Number of dynamic watches not yet registered",
+		},
+		[]string{
+			"controller",
+		},
+	)
+
+	// WatchEventsTotal is a prometheus counter metrics which holds the total
+	// number of events received by the watches of a controller.
+	// It has three labels.
+	// controller label refers to the controller name.
+	// resource label refers to the watched GroupKind.
+	// event label refers to the event type (create, update, delete, generic).
+	WatchEventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "controller_watch_events_total",
+			Help: "Number of events received by watches",
+		},
+		[]string{
+			"controller",
+			"resource",
+			"event",
+		},
+	)
+
+	// WatchEventsFilteredTotal is a prometheus counter metrics which holds the total
+	// number of events received by the watches of a controller and discarded by the
+	// watch predicates.
+	// It has three labels.
+	// controller label refers to the controller name.
+	// resource label refers to the watched GroupKind.
+	// event label refers to the event type (create, update, delete, generic).
+	WatchEventsFilteredTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "controller_watch_events_filtered_total",
+			Help: "Number of events filtered out by watch predicates",
+		},
+		[]string{
+			"controller",
+			"resource",
+			"event",
+		},
+	)
+
+	// ReconcileTriggersTotal is a prometheus counter metrics which holds the total
+	// number of reconcile requests enqueued per controller and source.
+	// It has two labels.
+	// controller label refers to the controller name.
+	// source label refers to the GroupKind of the resource that triggered the request.
+	ReconcileTriggersTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "controller_reconcile_triggers_total",
+			Help: "Number of reconcile requests enqueued by source",
+		},
+		[]string{
+			"controller",
+			"source",
+		},
+	)
 )
 
 // init register metrics to the global registry from controller-runtime/pkg/metrics.
@@ -27,4 +95,8 @@ var (
 //nolint:gochecknoinits
 func init() {
 	metrics.Registry.MustRegister(DynamicWatchResourcesTotal)
+	metrics.Registry.MustRegister(DynamicWatchResourcesPending)
+	metrics.Registry.MustRegister(WatchEventsTotal)
+	metrics.Registry.MustRegister(WatchEventsFilteredTotal)
+	metrics.Registry.MustRegister(ReconcileTriggersTotal)
 }
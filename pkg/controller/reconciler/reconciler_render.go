@@ -0,0 +1,73 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+type renderHookKey struct{}
+
+// RenderHookIntoContext returns a copy of ctx that makes the builders hand the
+// reconcilers they build over to fn instead of setting up their controllers, so
+// that the actions of a component can be run out of the operator with Render.
+func RenderHookIntoContext(ctx context.Context, fn func(*Reconciler)) context.Context {
+	return context.WithValue(ctx, renderHookKey{}, fn)
+}
+
+func renderHookFromContext(ctx context.Context) func(*Reconciler) {
+	fn, _ := ctx.Value(renderHookKey{}).(func(*Reconciler))
+
+	return fn
+}
+
+// Render runs the actions of the reconciler for the given instance, as a
+// reconciliation would, but stops at the deploy action and returns the request
+// holding the rendered resources. The DSCInitialization is looked up with the
+// client of the reconciler, which may then be a fake or a dry-run one.
+func (r *Reconciler) Render(ctx context.Context, instance common.PlatformObject) (*types.ReconciliationRequest, error) {
+	l := log.FromContext(ctx)
+
+	rr := types.ReconciliationRequest{
+		Client:     r.Client,
+		Controller: r,
+		Instance:   instance,
+		Conditions: r.conditionsManagerFactory(instance),
+		Release:    r.Release,
+		Manifests:  make([]types.ManifestInfo, 0),
+	}
+
+	dsci, err := cluster.GetDSCI(ctx, r.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DSCInitialization: %w", err)
+	}
+
+	rr.DSCI = dsci.DeepCopy()
+
+	ctx = types.RenderOnlyIntoContext(ctx)
+
+	for _, action := range r.Actions {
+		actx := log.IntoContext(
+			ctx,
+			l.WithName(actions.ActionGroup).WithName(action.String()),
+		)
+
+		if err := action(actx, &rr); err != nil {
+			if errors.As(err, &odherrors.StopError{}) {
+				break
+			}
+
+			return nil, fmt.Errorf("failed to execute action %s: %w", action, err)
+		}
+	}
+
+	return &rr, nil
+}
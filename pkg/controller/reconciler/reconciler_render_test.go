@@ -0,0 +1,62 @@
+//nolint:testpackage
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	odhtype "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+
+	dsci := dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+		Spec: dsciv2.DSCInitializationSpec{
+			ApplicationsNamespace: "odh-apps",
+		},
+	}
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(&dsci))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	postDeploy := false
+
+	r := createReconciler(cl)
+	r.AddAction(func(_ context.Context, rr *odhtype.ReconciliationRequest) error {
+		return rr.AddResources(&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "rendered", Namespace: rr.DSCI.Spec.ApplicationsNamespace},
+		})
+	})
+	r.AddAction(deploy.NewAction())
+	r.AddAction(func(_ context.Context, _ *odhtype.ReconciliationRequest) error {
+		postDeploy = true
+		return nil
+	})
+
+	rr, err := r.Render(t.Context(), &componentApi.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// the actions stop at the deploy one and nothing is applied
+	g.Expect(postDeploy).Should(BeFalse())
+	g.Expect(rr.Resources).Should(HaveLen(1))
+	g.Expect(rr.Resources[0].GetNamespace()).Should(Equal("odh-apps"))
+
+	err = cl.Get(t.Context(), types.NamespacedName{Namespace: "odh-apps", Name: "rendered"}, &corev1.ConfigMap{})
+	g.Expect(k8serr.IsNotFound(err)).Should(BeTrue())
+}
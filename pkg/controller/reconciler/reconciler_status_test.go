@@ -0,0 +1,308 @@
+//nolint:testpackage
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	odhtype "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestObservedGeneration(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		phase string
+	}{
+		{
+			name:  "ready",
+			err:   nil,
+			phase: status.PhaseReady,
+		},
+		{
+			name:  "failure",
+			err:   errors.New("failure"),
+			phase: status.PhaseNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			var patched *unstructured.Unstructured
+
+			cli, err := fakeclient.New(
+				fakeclient.WithObjects(
+					&dsciv2.DSCInitialization{
+						ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+					},
+					&componentApi.Dashboard{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       componentApi.DashboardInstanceName,
+							Generation: 2,
+						},
+					},
+				),
+				fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+					SubResourcePatch: func(_ context.Context, _ client.Client, _ string, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						if u, ok := obj.(*unstructured.Unstructured); ok {
+							patched = u.DeepCopy()
+						}
+
+						return nil
+					},
+				}),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			cc := createReconciler(cli)
+			cc.AddAction(func(_ context.Context, _ *odhtype.ReconciliationRequest) error {
+				return tt.err
+			})
+
+			_, err = cc.Reconcile(ctx, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: componentApi.DashboardInstanceName},
+			})
+
+			if tt.err != nil {
+				g.Expect(err).Should(MatchError(tt.err))
+			} else {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			}
+
+			g.Expect(patched).ShouldNot(BeNil())
+			g.Expect(patched).Should(And(
+				jq.Match(`.status.observedGeneration == 2`),
+				jq.Match(`.status.phase == "%s"`, tt.phase),
+			))
+		})
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		resync   time.Duration
+		requests []time.Duration
+		expected time.Duration
+	}{
+		{
+			name:     "none",
+			expected: 0,
+		},
+		{
+			name:     "earliest action request",
+			requests: []time.Duration{time.Minute, 10 * time.Second, 30 * time.Second},
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "resync",
+			resync:   5 * time.Minute,
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "action request before resync",
+			resync:   5 * time.Minute,
+			requests: []time.Duration{10 * time.Second},
+			expected: 10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			cli, err := fakeclient.New(
+				fakeclient.WithObjects(
+					&dsciv2.DSCInitialization{
+						ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+					},
+					&componentApi.Dashboard{
+						ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+					},
+				),
+				fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+					SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						return nil
+					},
+				}),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			cc := createReconciler(cli)
+			WithResyncPeriod(tt.resync)(cc)
+
+			for _, d := range tt.requests {
+				cc.AddAction(func(_ context.Context, rr *odhtype.ReconciliationRequest) error {
+					rr.RequeueAfterAtMost(d)
+					return nil
+				})
+			}
+
+			res, err := cc.Reconcile(ctx, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: componentApi.DashboardInstanceName},
+			})
+
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(res.RequeueAfter).Should(Equal(tt.expected))
+		})
+	}
+}
+
+func TestWaitError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	var patched *unstructured.Unstructured
+
+	cli, err := fakeclient.New(
+		fakeclient.WithObjects(
+			&dsciv2.DSCInitialization{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			},
+			&componentApi.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+			},
+		),
+		fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				if u, ok := obj.(*unstructured.Unstructured); ok {
+					patched = u.DeepCopy()
+				}
+
+				return nil
+			},
+		}),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	executed := false
+
+	cc := createReconciler(cli)
+	cc.AddAction(func(_ context.Context, _ *odhtype.ReconciliationRequest) error {
+		return odherrors.NewWaitError(15*time.Second, "waiting for %s", "migration")
+	})
+	cc.AddAction(func(_ context.Context, _ *odhtype.ReconciliationRequest) error {
+		executed = true
+		return nil
+	})
+
+	res, err := cc.Reconcile(ctx, ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: componentApi.DashboardInstanceName},
+	})
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(res.RequeueAfter).Should(Equal(15 * time.Second))
+	g.Expect(executed).Should(BeFalse())
+
+	g.Expect(patched).Should(And(
+		jq.Match(`.status.conditions[] | select(.type == "%s") | .status == "False"`, status.ConditionTypeProvisioningSucceeded),
+		jq.Match(`.status.conditions[] | select(.type == "%s") | .reason == "%s"`, status.ConditionTypeProvisioningSucceeded, status.WaitingReason),
+		jq.Match(`.status.conditions[] | select(.type == "%s") | .message == "waiting for migration"`, status.ConditionTypeProvisioningSucceeded),
+	))
+}
+
+func TestDevFlagsCondition(t *testing.T) {
+	tests := []struct {
+		name     string
+		devFlags *common.DevFlags
+		conds    []common.Condition
+		matcher  func(g *WithT, patched *unstructured.Unstructured)
+	}{
+		{
+			name: "set",
+			devFlags: &common.DevFlags{
+				Manifests: []common.ManifestsConfig{{URI: "https://example.com/manifests.tar.gz"}},
+			},
+			matcher: func(g *WithT, patched *unstructured.Unstructured) {
+				// the instance is flagged but stays ready
+				g.Expect(patched).Should(And(
+					jq.Match(`.status.phase == "%s"`, status.PhaseReady),
+					jq.Match(`.status.conditions[] | select(.type == "%s") | .status == "False"`, status.ConditionSupportedConfiguration),
+					jq.Match(`.status.conditions[] | select(.type == "%s") | .reason == "%s"`, status.ConditionSupportedConfiguration, status.DevFlagsSetReason),
+					jq.Match(`.status.conditions[] | select(.type == "%s") | .severity == "Info"`, status.ConditionSupportedConfiguration),
+				))
+			},
+		},
+		{
+			name: "cleared",
+			// set by a previous reconciliation, before the devFlags were removed
+			conds: []common.Condition{{
+				Type:     status.ConditionSupportedConfiguration,
+				Status:   metav1.ConditionFalse,
+				Reason:   status.DevFlagsSetReason,
+				Severity: common.ConditionSeverityInfo,
+			}},
+			matcher: func(g *WithT, patched *unstructured.Unstructured) {
+				g.Expect(patched).Should(And(
+					jq.Match(`.status.phase == "%s"`, status.PhaseReady),
+					jq.Match(`[.status.conditions[] | select(.type == "%s")] | length == 0`, status.ConditionSupportedConfiguration),
+				))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			var patched *unstructured.Unstructured
+
+			dashboard := componentApi.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: componentApi.DashboardInstanceName},
+			}
+			dashboard.Spec.DevFlags = tt.devFlags
+			dashboard.Status.Conditions = tt.conds
+
+			cli, err := fakeclient.New(
+				fakeclient.WithObjects(
+					&dsciv2.DSCInitialization{
+						ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+					},
+					&dashboard,
+				),
+				fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+					SubResourcePatch: func(_ context.Context, _ client.Client, _ string, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						if u, ok := obj.(*unstructured.Unstructured); ok {
+							patched = u.DeepCopy()
+						}
+
+						return nil
+					},
+				}),
+			)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			cc := createReconciler(cli)
+
+			_, err = cc.Reconcile(ctx, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: componentApi.DashboardInstanceName},
+			})
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			tt.matcher(g, patched)
+		})
+	}
+}
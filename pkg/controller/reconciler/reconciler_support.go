@@ -6,14 +6,19 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
@@ -44,6 +49,7 @@ type watchInput struct {
 	owned        bool
 	dynamic      bool
 	dynamicPred  []DynamicPredicate
+	debounce     time.Duration
 }
 
 type WatchOpts func(*watchInput)
@@ -66,6 +72,15 @@ func WithEventMapper(value handler.MapFunc) WatchOpts {
 	}
 }
 
+// WithDebounce delays the reconcile requests generated by the watch by the given
+// window, so that a burst of events (i.e. a Deployment rollout) collapses in a
+// single reconciliation of the owner.
+func WithDebounce(window time.Duration) WatchOpts {
+	return func(a *watchInput) {
+		a.debounce = window
+	}
+}
+
 func Dynamic(predicates ...DynamicPredicate) WatchOpts {
 	return func(a *watchInput) {
 		a.dynamic = true
@@ -95,6 +110,9 @@ type ReconcilerBuilder[T common.PlatformObject] struct {
 	errors              error
 	happyCondition      string
 	dependantConditions []string
+	progressConditions  bool
+	controllerOptions   controller.Options
+	resyncPeriod        time.Duration
 }
 
 func ReconcilerFor[T common.PlatformObject](mgr ctrl.Manager, object T, opts ...builder.ForOption) *ReconcilerBuilder[T] {
@@ -130,6 +148,43 @@ func (b *ReconcilerBuilder[T]) WithConditions(dependants ...string) *ReconcilerB
 	return b
 }
 
+// WithProgressConditions makes the reconciler maintain the Progressing and Degraded
+// conditions alongside the happy one.
+func (b *ReconcilerBuilder[T]) WithProgressConditions() *ReconcilerBuilder[T] {
+	b.progressConditions = true
+	return b
+}
+
+// WithResyncPeriod makes the reconciler periodically reconcile the instance
+// even if no watch event happens, acting as a safety net for drifts. If not
+// set, the resync period of the operator settings carried by the context given
+// to Build is used.
+func (b *ReconcilerBuilder[T]) WithResyncPeriod(period time.Duration) *ReconcilerBuilder[T] {
+	b.resyncPeriod = period
+	return b
+}
+
+// WithMaxConcurrentReconciles sets the maximum number of concurrent reconciles
+// for the controller, defaults to the value configured at manager level (1).
+func (b *ReconcilerBuilder[T]) WithMaxConcurrentReconciles(value int) *ReconcilerBuilder[T] {
+	b.controllerOptions.MaxConcurrentReconciles = value
+	return b
+}
+
+// WithRateLimiter sets the rate limiter used by the controller work queue,
+// defaults to the controller-runtime default rate limiter.
+func (b *ReconcilerBuilder[T]) WithRateLimiter(value workqueue.TypedRateLimiter[reconcile.Request]) *ReconcilerBuilder[T] {
+	b.controllerOptions.RateLimiter = value
+	return b
+}
+
+// WithCacheSyncTimeout sets the time limit for waiting the controller caches to
+// sync, defaults to the value configured at manager level (2 minutes).
+func (b *ReconcilerBuilder[T]) WithCacheSyncTimeout(value time.Duration) *ReconcilerBuilder[T] {
+	b.controllerOptions.CacheSyncTimeout = value
+	return b
+}
+
 func (b *ReconcilerBuilder[T]) WithInstanceName(instanceName string) *ReconcilerBuilder[T] {
 	b.instanceName = instanceName
 	return b
@@ -214,7 +269,7 @@ func (b *ReconcilerBuilder[T]) OwnsGVK(gvk schema.GroupVersionKind, opts ...Watc
 	return b.Owns(resources.GvkToUnstructured(gvk), opts...)
 }
 
-func (b *ReconcilerBuilder[T]) Build(_ context.Context) (*Reconciler, error) {
+func (b *ReconcilerBuilder[T]) Build(ctx context.Context) (*Reconciler, error) {
 	if b.errors != nil {
 		return nil, b.errors
 	}
@@ -228,12 +283,42 @@ func (b *ReconcilerBuilder[T]) Build(_ context.Context) (*Reconciler, error) {
 		return nil, errors.New("invalid type for object")
 	}
 
-	r, err := NewReconciler(b.mgr, name, obj, WithConditionsManagerFactory(b.happyCondition, b.dependantConditions...))
+	ropts := []ReconcilerOpt{
+		WithConditionsManagerFactory(b.happyCondition, b.dependantConditions...),
+	}
+	if b.progressConditions {
+		ropts = append(ropts, WithProgressConditions())
+	}
+
+	resyncPeriod := b.resyncPeriod
+	if resyncPeriod == 0 {
+		resyncPeriod = types.SettingsFromContext(ctx).ResyncPeriod
+	}
+	if resyncPeriod > 0 {
+		ropts = append(ropts, WithResyncPeriod(resyncPeriod))
+	}
+
+	r, err := NewReconciler(b.mgr, name, obj, ropts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create reconciler for component %s: %w", name, err)
 	}
 
-	c := ctrl.NewControllerManagedBy(b.mgr)
+	for i := range b.actions {
+		r.AddAction(b.actions[i])
+	}
+	for i := range b.finalizers {
+		r.AddFinalizer(b.finalizers[i])
+	}
+
+	// the reconciler is only needed to render the resources of the component,
+	// do not set up its controller
+	if fn := renderHookFromContext(ctx); fn != nil {
+		fn(r)
+		return r, nil
+	}
+
+	c := ctrl.NewControllerManagedBy(b.mgr).
+		WithOptions(b.controllerOptions)
 
 	// automatically add default predicates to the watched API if no
 	// predicates are provided
@@ -246,9 +331,42 @@ func (b *ReconcilerBuilder[T]) Build(_ context.Context) (*Reconciler, error) {
 		)))
 	}
 
+	// account for the events of the primary resource that are going
+	// to trigger a reconciliation
+	forOpts = append(forOpts, builder.WithPredicates(
+		newTriggerMetricsPredicate(name, b.input.gvk.GroupKind().String()),
+	))
+
 	c = c.For(b.input.object, forOpts...)
 
+	info := ControllerInfo{
+		Name:    name,
+		Kind:    b.input.gvk.Kind,
+		Watches: make([]WatchInfo, 0, len(b.watches)),
+	}
+
 	for i := range b.watches {
+		wgvk, err := apiutil.GVKForObject(b.watches[i].object, b.mgr.GetScheme())
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine GVK of watched resource: %w", err)
+		}
+
+		info.Watches = append(info.Watches, WatchInfo{
+			GVK:     wgvk.String(),
+			Owned:   b.watches[i].owned,
+			Dynamic: b.watches[i].dynamic,
+			Active:  !b.watches[i].dynamic,
+		})
+
+		b.watches[i].predicates = []predicate.Predicate{
+			newWatchMetricsPredicate(name, wgvk.GroupKind().String(), b.watches[i].predicates...),
+		}
+		b.watches[i].eventHandler = newWatchMetricsHandler(
+			name,
+			wgvk.GroupKind().String(),
+			handlers.Debounce(b.watches[i].debounce, b.watches[i].eventHandler),
+		)
+
 		if b.watches[i].owned {
 			kinds, _, err := b.mgr.GetScheme().ObjectKinds(b.watches[i].object)
 			if err != nil {
@@ -277,13 +395,6 @@ func (b *ReconcilerBuilder[T]) Build(_ context.Context) (*Reconciler, error) {
 		c = c.WithEventFilter(b.predicates[i])
 	}
 
-	for i := range b.actions {
-		r.AddAction(b.actions[i])
-	}
-	for i := range b.finalizers {
-		r.AddFinalizer(b.finalizers[i])
-	}
-
 	cc, err := c.Build(r)
 	if err != nil {
 		return nil, err
@@ -296,8 +407,16 @@ func (b *ReconcilerBuilder[T]) Build(_ context.Context) (*Reconciler, error) {
 				return cc.Watch(source.Kind(b.mgr.GetCache(), obj, eventHandler, predicates...))
 			},
 			b.watches,
+			func(gvk schema.GroupVersionKind) {
+				controllers.activate(name, gvk)
+			},
 		),
 	)
 
+	info.Actions = len(r.Actions)
+	info.Finalizers = len(r.Finalizer)
+
+	controllers.register(info)
+
 	return r, nil
 }
@@ -0,0 +1,121 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	eventCreate  = "create"
+	eventUpdate  = "update"
+	eventDelete  = "delete"
+	eventGeneric = "generic"
+)
+
+// newWatchMetricsPredicate wraps the given predicates in a single predicate that
+// accounts for the events received by a watch and the ones discarded by any of
+// the wrapped predicates.
+func newWatchMetricsPredicate(controllerName string, resource string, predicates ...predicate.Predicate) predicate.Predicate {
+	p := predicate.And(predicates...)
+
+	account := func(eventType string, accepted bool) bool {
+		WatchEventsTotal.WithLabelValues(controllerName, resource, eventType).Inc()
+		if !accepted {
+			WatchEventsFilteredTotal.WithLabelValues(controllerName, resource, eventType).Inc()
+		}
+
+		return accepted
+	}
+
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return account(eventCreate, p.Create(e))
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return account(eventUpdate, p.Update(e))
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return account(eventDelete, p.Delete(e))
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return account(eventGeneric, p.Generic(e))
+		},
+	}
+}
+
+// newTriggerMetricsPredicate returns a pass-through predicate that accounts for every
+// event it sees as a reconcile trigger. It is meant to be the last predicate of the
+// chain so only the events that are about to be enqueued are accounted.
+func newTriggerMetricsPredicate(controllerName string, source string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(_ client.Object) bool {
+		ReconcileTriggersTotal.WithLabelValues(controllerName, source).Inc()
+		return true
+	})
+}
+
+// watchMetricsHandler is an handler.EventHandler that accounts for the reconcile
+// requests enqueued by the delegate handler.
+type watchMetricsHandler struct {
+	delegate handler.EventHandler
+	counter  prometheus.Counter
+}
+
+func newWatchMetricsHandler(controllerName string, source string, delegate handler.EventHandler) handler.EventHandler {
+	return &watchMetricsHandler{
+		delegate: delegate,
+		counter:  ReconcileTriggersTotal.WithLabelValues(controllerName, source),
+	}
+}
+
+func (h *watchMetricsHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.delegate.Create(ctx, e, h.wrap(q))
+}
+
+func (h *watchMetricsHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.delegate.Update(ctx, e, h.wrap(q))
+}
+
+func (h *watchMetricsHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.delegate.Delete(ctx, e, h.wrap(q))
+}
+
+func (h *watchMetricsHandler) Generic(ctx context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.delegate.Generic(ctx, e, h.wrap(q))
+}
+
+func (h *watchMetricsHandler) wrap(q workqueue.TypedRateLimitingInterface[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	return &watchMetricsQueue{
+		TypedRateLimitingInterface: q,
+		counter:                    h.counter,
+	}
+}
+
+// watchMetricsQueue decorates a workqueue to account for the items added to it.
+type watchMetricsQueue struct {
+	workqueue.TypedRateLimitingInterface[reconcile.Request]
+
+	counter prometheus.Counter
+}
+
+func (q *watchMetricsQueue) Add(item reconcile.Request) {
+	q.counter.Inc()
+	q.TypedRateLimitingInterface.Add(item)
+}
+
+func (q *watchMetricsQueue) AddRateLimited(item reconcile.Request) {
+	q.counter.Inc()
+	q.TypedRateLimitingInterface.AddRateLimited(item)
+}
+
+func (q *watchMetricsQueue) AddAfter(item reconcile.Request, duration time.Duration) {
+	q.counter.Inc()
+	q.TypedRateLimitingInterface.AddAfter(item, duration)
+}
@@ -0,0 +1,86 @@
+//nolint:testpackage
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWatchMetricsPredicate(t *testing.T) {
+	g := NewWithT(t)
+
+	WatchEventsTotal.Reset()
+	WatchEventsFilteredTotal.Reset()
+
+	p := newWatchMetricsPredicate("dashboard", "ConfigMap",
+		predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == "accepted"
+		}),
+	)
+
+	accepted := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "accepted"}}
+	rejected := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "rejected"}}
+
+	g.Expect(p.Create(event.CreateEvent{Object: accepted})).Should(BeTrue())
+	g.Expect(p.Create(event.CreateEvent{Object: rejected})).Should(BeFalse())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: rejected, ObjectNew: rejected})).Should(BeFalse())
+
+	g.Expect(testutil.ToFloat64(WatchEventsTotal.WithLabelValues("dashboard", "ConfigMap", eventCreate))).
+		Should(BeNumerically("==", 2))
+	g.Expect(testutil.ToFloat64(WatchEventsFilteredTotal.WithLabelValues("dashboard", "ConfigMap", eventCreate))).
+		Should(BeNumerically("==", 1))
+	g.Expect(testutil.ToFloat64(WatchEventsTotal.WithLabelValues("dashboard", "ConfigMap", eventUpdate))).
+		Should(BeNumerically("==", 1))
+	g.Expect(testutil.ToFloat64(WatchEventsFilteredTotal.WithLabelValues("dashboard", "ConfigMap", eventUpdate))).
+		Should(BeNumerically("==", 1))
+}
+
+func TestWatchMetricsHandler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	ReconcileTriggersTotal.Reset()
+
+	h := newWatchMetricsHandler("dashboard", "ConfigMap", handler.EnqueueRequestsFromMapFunc(
+		func(_ context.Context, obj client.Object) []reconcile.Request {
+			return []reconcile.Request{
+				{NamespacedName: client.ObjectKey{Name: "a"}},
+				{NamespacedName: client.ObjectKey{Name: "b"}},
+			}
+		},
+	))
+
+	q := workqueue.NewTypedRateLimitingQueue[reconcile.Request](
+		workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+	)
+	defer q.ShutDown()
+
+	h.Create(ctx, event.CreateEvent{Object: &corev1.ConfigMap{}}, q)
+
+	g.Expect(q.Len()).Should(Equal(2))
+	g.Expect(testutil.ToFloat64(ReconcileTriggersTotal.WithLabelValues("dashboard", "ConfigMap"))).
+		Should(BeNumerically("==", 2))
+}
+
+func TestTriggerMetricsPredicate(t *testing.T) {
+	g := NewWithT(t)
+
+	ReconcileTriggersTotal.Reset()
+
+	p := newTriggerMetricsPredicate("dashboard", "Dashboard.components.platform.opendatahub.io")
+
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: &corev1.ConfigMap{}, ObjectNew: &corev1.ConfigMap{}})).Should(BeTrue())
+	g.Expect(testutil.ToFloat64(ReconcileTriggersTotal)).Should(BeNumerically("==", 1))
+}
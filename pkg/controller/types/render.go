@@ -0,0 +1,21 @@
+package types
+
+import (
+	"context"
+)
+
+type renderOnlyKey struct{}
+
+// RenderOnlyIntoContext returns a copy of ctx that makes the deploy actions stop
+// the reconciliation before anything is applied, so that the resources of a
+// component can be rendered out of the operator.
+func RenderOnlyIntoContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, renderOnlyKey{}, true)
+}
+
+// IsRenderOnly returns true if ctx has been set up with RenderOnlyIntoContext.
+func IsRenderOnly(ctx context.Context) bool {
+	v, _ := ctx.Value(renderOnlyKey{}).(bool)
+
+	return v
+}
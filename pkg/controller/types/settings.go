@@ -0,0 +1,39 @@
+package types
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+type settingsKey struct{}
+
+// Settings holds the operator configuration the controllers are built with. It
+// is set up by the manager and handed over to the reconciler constructors
+// through their context.
+type Settings struct {
+	// WatchNamespaces lists the namespaces the operator is restricted to, all
+	// of them if empty.
+	WatchNamespaces []string
+	// ClusterScoped sets how the cluster-scoped resources are handled when the
+	// operator is restricted to WatchNamespaces.
+	ClusterScoped string
+	// ResyncPeriod is the period after which the instances are reconciled
+	// again even if no watch event happens, unless set on the reconciler.
+	ResyncPeriod time.Duration
+}
+
+// SettingsIntoContext returns a copy of ctx carrying the given settings.
+func SettingsIntoContext(ctx context.Context, s Settings) context.Context {
+	s.WatchNamespaces = slices.Clone(s.WatchNamespaces)
+
+	return context.WithValue(ctx, settingsKey{}, s)
+}
+
+// SettingsFromContext returns the settings carried by ctx, or the zero value if
+// none was set.
+func SettingsFromContext(ctx context.Context) Settings {
+	s, _ := ctx.Value(settingsKey{}).(Settings)
+
+	return s
+}
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"io/fs"
 	"path"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
@@ -44,6 +46,12 @@ type WithLogger interface {
 	GetLogger() logr.Logger
 }
 
+// WithEventRecorder is implemented by controllers able to record Kubernetes events
+// on the objects they reconcile.
+type WithEventRecorder interface {
+	GetEventRecorder() record.EventRecorder
+}
+
 type ManifestInfo struct {
 	Path       string
 	ContextDir string
@@ -109,6 +117,11 @@ type ReconciliationRequest struct {
 	//       replaced with a better way of describing resources and
 	//       their origin
 	Generated bool
+
+	// RequeueAfter, if set by any action, makes the reconciler requeue the
+	// request after the given duration even if the reconciliation succeeded,
+	// i.e. to wait for a condition that is not signaled by any watch.
+	RequeueAfter time.Duration
 }
 
 // AddResources adds one or more resources to the ReconciliationRequest's Resources slice.
@@ -185,6 +198,27 @@ func (rr *ReconciliationRequest) RemoveResources(predicate func(*unstructured.Un
 	return nil
 }
 
+// RecordEvent records a Kubernetes event on the instance being reconciled. It is a
+// no-op if the controller does not implement WithEventRecorder, so actions can call
+// it unconditionally.
+func (rr *ReconciliationRequest) RecordEvent(eventType string, reason string, messageFmt string, args ...any) {
+	if rr.Instance == nil {
+		return
+	}
+
+	wr, ok := rr.Controller.(WithEventRecorder)
+	if !ok {
+		return
+	}
+
+	recorder := wr.GetEventRecorder()
+	if recorder == nil {
+		return
+	}
+
+	recorder.Eventf(rr.Instance, eventType, reason, messageFmt, args...)
+}
+
 func Hash(rr *ReconciliationRequest) ([]byte, error) {
 	hash := sha256.New()
 
@@ -232,3 +266,15 @@ func HashStr(rr *ReconciliationRequest) (string, error) {
 
 	return resources.EncodeToString(h), nil
 }
+
+// RequeueAfterAtMost sets RequeueAfter to the given duration unless an earlier
+// requeue has already been requested by another action.
+func (rr *ReconciliationRequest) RequeueAfterAtMost(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	if rr.RequeueAfter == 0 || d < rr.RequeueAfter {
+		rr.RequeueAfter = d
+	}
+}
@@ -5,7 +5,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+const (
+	// FipsEnabledParamsKey is set by ApplyParams to whether the cluster runs in
+	// FIPS mode, if declared in the params.env file of a component.
+	FipsEnabledParamsKey = "FIPSENABLED"
+
+	// FipsImageSuffix is appended to the name of RELATED_IMAGE_* variables to
+	// look up the FIPS compliant variant of an image. When the cluster runs in
+	// FIPS mode and the variable is set, it takes precedence over the default one.
+	FipsImageSuffix = "_FIPS"
 )
 
 func parseParams(fileName string) (map[string]string, error) {
@@ -63,13 +77,31 @@ func updateMap(m *map[string]string, key, val string) int {
 	return 1
 }
 
+// relatedImage returns the value of the given RELATED_IMAGE_* variable, or of
+// its FIPS variant if set and fipsEnabled is true.
+func relatedImage(name string, fipsEnabled bool) string {
+	if name == "" {
+		return ""
+	}
+
+	if fipsEnabled {
+		if value := os.Getenv(name + FipsImageSuffix); value != "" {
+			return value
+		}
+	}
+
+	return os.Getenv(name)
+}
+
 /*
 overwrite values in components' manifests params.env file
 This is useful for air gapped cluster
 priority of image values (from high to low):
 - image values set in manifests params.env if manifestsURI is set
+- RELATED_IMAGE_*_FIPS values from CSV (if it is set and the cluster runs in FIPS mode)
 - RELATED_IMAGE_* values from CSV (if it is set)
 - image values set in manifests params.env if manifestsURI is not set.
+FIPSENABLED is set to whether the cluster runs in FIPS mode, if present in params.env.
 extraParamsMaps is used to set extra parameters which are not carried from ENV variable. this can be passed per component.
 */
 func ApplyParams(componentPath string, file string, imageParamsMap map[string]string, extraParamsMaps ...map[string]string) error {
@@ -89,16 +121,23 @@ func ApplyParams(componentPath string, file string, imageParamsMap map[string]st
 	// Could use sum, but safe from hypothetically integer overflow
 	updated := 0
 
+	fipsEnabled := cluster.GetClusterInfo().FipsEnabled
+
 	// 1. Update images with env variables
 	// e.g "odh-kuberay-operator-controller-image": "RELATED_IMAGE_ODH_KUBERAY_OPERATOR_CONTROLLER_IMAGE",
 	for i := range paramsEnvMap {
-		relatedImageValue := os.Getenv(imageParamsMap[i])
+		relatedImageValue := relatedImage(imageParamsMap[i], fipsEnabled)
 		if relatedImageValue != "" {
 			updated |= updateMap(&paramsEnvMap, i, relatedImageValue)
 		}
 	}
 
-	// 2. Update other fields with extraParamsMap which are not carried from component
+	// 2. Propagate the FIPS mode to the components that declare it
+	if _, ok := paramsEnvMap[FipsEnabledParamsKey]; ok {
+		updated |= updateMap(&paramsEnvMap, FipsEnabledParamsKey, strconv.FormatBool(fipsEnabled))
+	}
+
+	// 3. Update other fields with extraParamsMap which are not carried from component
 	for _, extraParamsMap := range extraParamsMaps {
 		for eKey, eValue := range extraParamsMap {
 			updated |= updateMap(&paramsEnvMap, eKey, eValue)
@@ -0,0 +1,21 @@
+//nolint:testpackage
+package deploy
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRelatedImage(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Setenv("RELATED_IMAGE_FOO", "quay.io/foo:1")
+	t.Setenv("RELATED_IMAGE_FOO"+FipsImageSuffix, "quay.io/foo:1-fips")
+	t.Setenv("RELATED_IMAGE_BAR", "quay.io/bar:1")
+
+	g.Expect(relatedImage("", true)).Should(BeEmpty())
+	g.Expect(relatedImage("RELATED_IMAGE_FOO", false)).Should(Equal("quay.io/foo:1"))
+	g.Expect(relatedImage("RELATED_IMAGE_FOO", true)).Should(Equal("quay.io/foo:1-fips"))
+	g.Expect(relatedImage("RELATED_IMAGE_BAR", true)).Should(Equal("quay.io/bar:1"))
+}
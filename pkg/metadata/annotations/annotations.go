@@ -25,6 +25,57 @@ const (
 	InstanceUID        = "platform.opendatahub.io/instance.uid"
 )
 
+// Hook marks a rendered Job as a hook, to be run by the hooks action at the given
+// phase (pre-deploy, post-deploy, pre-delete) rather than being deployed with the
+// other resources.
+const (
+	Hook         = "platform.opendatahub.io/hook"
+	HookRevision = "platform.opendatahub.io/hook.revision"
+)
+
+// ConfigChecksum is set on the pod template of the rendered workloads to the hash
+// of the rendered ConfigMaps and Secrets they consume, so that the pods are rolled
+// when their configuration changes.
+const ConfigChecksum = "checksum/config"
+
+// ExternalSecretKey, set on a rendered Secret, has it provisioned by the External
+// Secrets Operator, from the given key of the secret store, rather than deployed
+// with its rendered content. ExternalSecretStore optionally selects the store, as
+// <kind>/<name> or <name> for a namespaced SecretStore.
+const (
+	ExternalSecretKey   = "platform.opendatahub.io/external-secret.key"
+	ExternalSecretStore = "platform.opendatahub.io/external-secret.store"
+)
+
+// PinnedData, set to true on a rendered Secret, keeps the values of its keys
+// already stored in the cluster, so that values generated at render time, i.e.
+// random passwords, are not rotated on every reconciliation.
+const PinnedData = "platform.opendatahub.io/pinned-data"
+
+// JobRerun sets how a rendered Job is run again when its rendered spec changes
+// or once it has been removed, see the deploy action. JobSpecHash is set by the
+// deploy action to the hash of the rendered spec of such Jobs.
+const (
+	JobRerun    = "platform.opendatahub.io/job.rerun"
+	JobSpecHash = "platform.opendatahub.io/job.spec-hash"
+)
+
+// ApprovalRequired, when set to true on a component CR, makes its changes wait
+// for a human approval: the plan of the changes is published and the resources
+// are only deployed once ApprovedPlan is set on the CR to the hash of the plan.
+const (
+	ApprovalRequired = "platform.opendatahub.io/approval-required"
+	ApprovedPlan     = "platform.opendatahub.io/approved-plan"
+)
+
+// TelemetryEnabled disables, when set to false on the DSCInitialization, the
+// telemetry of the platform: the segment.io key config read by the dashboard is
+// not deployed, and removed if already there.
+const TelemetryEnabled = "platform.opendatahub.io/telemetry-enabled"
+
+// ArgoCDTrackingID is the annotation ArgoCD uses to track the application a resource belongs to.
+const ArgoCDTrackingID = "argocd.argoproj.io/tracking-id"
+
 // Connection annotation for referencing secrets containing connection information.
 const Connection = "opendatahub.io/connections"
 
@@ -38,3 +89,15 @@ const ConnectionTypeProtocol = "opendatahub.io/connection-type-protocol"
 // ConnectionPath annotation for specifying the path under bucket(s3) to use for the connection.
 // TODO: extend to oci.
 const ConnectionPath = "opendatahub.io/connection-path"
+
+// PolicyExempt lists, comma separated, the policy rules a rendered resource is
+// exempted from (i.e. a node agent legitimately mounting a hostPath volume).
+const PolicyExempt = "platform.opendatahub.io/policy-exempt"
+
+// ServingCertSecretName requests the OpenShift service CA operator to generate
+// a TLS Secret with the given name for the annotated Service.
+const ServingCertSecretName = "service.beta.openshift.io/serving-cert-secret-name"
+
+// ServingCertHash is set on the pod template of workloads mounting a serving
+// certificate, so that they are rolled out when the certificate is rotated.
+const ServingCertHash = "platform.opendatahub.io/serving-cert.hash"
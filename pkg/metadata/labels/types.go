@@ -8,18 +8,28 @@ const (
 	ClusterMonitoring      = "openshift.io/cluster-monitoring"
 	PlatformPartOf         = ODHPlatformPrefix + "/part-of"
 	PlatformDependency     = ODHPlatformPrefix + "/dependency"
+	PlatformInstanceUID    = ODHPlatformPrefix + "/instance.uid"
+	PlatformVersion        = ODHPlatformPrefix + "/version"
 	Platform               = "platform"
 	True                   = "true"
 	CustomizedAppNamespace = "opendatahub.io/application-namespace"
+	SidecarInject          = "sidecar.istio.io/inject"
+	MonitoringScrape       = "monitoring.opendatahub.io/scrape"
+	ConsoleDashboard       = "console.openshift.io/dashboard"
+	GrafanaDashboard       = "grafana_dashboard"
 )
 
 // K8SCommon keeps common kubernetes labels [1]
 // used across the project.
 // [1] (https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/#labels)
 var K8SCommon = struct {
-	PartOf string
+	PartOf   string
+	Instance string
+	Version  string
 }{
-	PartOf: "app.kubernetes.io/part-of",
+	PartOf:   "app.kubernetes.io/part-of",
+	Instance: "app.kubernetes.io/instance",
+	Version:  "app.kubernetes.io/version",
 }
 
 // ODH holds Open Data Hub specific labels grouped by types.
@@ -13,8 +13,11 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	routev1 "github.com/openshift/api/route/v1"
 	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -137,6 +140,90 @@ func GvkToUnstructured(gvk schema.GroupVersionKind) *unstructured.Unstructured {
 	return &u
 }
 
+// PodSpecPath returns the path of the pod spec embedded in resources of the given
+// kind, and false if the kind is not a workload.
+func PodSpecPath(gk schema.GroupKind) ([]string, bool) {
+	switch gk {
+	case schema.GroupKind{Group: corev1.GroupName, Kind: "Pod"}:
+		return []string{"spec"}, true
+	case schema.GroupKind{Group: appsv1.GroupName, Kind: "Deployment"},
+		schema.GroupKind{Group: appsv1.GroupName, Kind: "StatefulSet"},
+		schema.GroupKind{Group: appsv1.GroupName, Kind: "DaemonSet"},
+		schema.GroupKind{Group: appsv1.GroupName, Kind: "ReplicaSet"},
+		schema.GroupKind{Group: batchv1.GroupName, Kind: "Job"}:
+		return []string{"spec", "template", "spec"}, true
+	case schema.GroupKind{Group: batchv1.GroupName, Kind: "CronJob"}:
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}, true
+	default:
+		return nil, false
+	}
+}
+
+// Normalize rewrites the given object in the form the API server stores it, so
+// that it can be compared with the live object without spurious differences:
+// null creation timestamps and empty status blocks are dropped, and the resource
+// requests and limits of the containers of workloads are canonicalized, i.e.
+// 1000m to 1.
+func Normalize(obj *unstructured.Unstructured) error {
+	if status, ok := obj.Object["status"]; ok {
+		if m, isMap := status.(map[string]any); status == nil || (isMap && len(m) == 0) {
+			delete(obj.Object, "status")
+		}
+	}
+
+	if ts, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "metadata", "creationTimestamp"); found && ts == nil {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	}
+
+	path, ok := PodSpecPath(obj.GroupVersionKind().GroupKind())
+	if !ok {
+		return nil
+	}
+
+	if len(path) > 1 {
+		tsPath := append(slices.Clone(path[:len(path)-1]), "metadata", "creationTimestamp")
+		if ts, found, _ := unstructured.NestedFieldNoCopy(obj.Object, tsPath...); found && ts == nil {
+			unstructured.RemoveNestedField(obj.Object, tsPath...)
+		}
+	}
+
+	for _, field := range []string{"containers", "initContainers"} {
+		containers, _, _ := unstructured.NestedFieldNoCopy(obj.Object, append(slices.Clone(path), field)...)
+
+		list, ok := containers.([]any)
+		if !ok {
+			continue
+		}
+
+		for _, c := range list {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			for _, kind := range []string{"requests", "limits"} {
+				values, _, _ := unstructured.NestedFieldNoCopy(container, "resources", kind)
+
+				quantities, ok := values.(map[string]any)
+				if !ok {
+					continue
+				}
+
+				for name, v := range quantities {
+					q, err := resource.ParseQuantity(fmt.Sprint(v))
+					if err != nil {
+						return fmt.Errorf("invalid %s %s of container %v: %w", kind, name, container["name"], err)
+					}
+
+					quantities[name] = q.String()
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func IngressHost(r routev1.Route) string {
 	if len(r.Status.Ingress) != 1 {
 		return ""
@@ -28,6 +28,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
 	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
 
@@ -343,6 +344,73 @@ func TestFormatObjectReference(t *testing.T) {
 	}
 }
 
+func TestPodSpecPath(t *testing.T) {
+	g := NewWithT(t)
+
+	path, ok := resources.PodSpecPath(gvk.Pod.GroupKind())
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(path).Should(Equal([]string{"spec"}))
+
+	path, ok = resources.PodSpecPath(gvk.Deployment.GroupKind())
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(path).Should(Equal([]string{"spec", "template", "spec"}))
+
+	path, ok = resources.PodSpecPath(gvk.CronJob.GroupKind())
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(path).Should(Equal([]string{"spec", "jobTemplate", "spec", "template", "spec"}))
+
+	_, ok = resources.PodSpecPath(gvk.ConfigMap.GroupKind())
+	g.Expect(ok).Should(BeFalse())
+}
+
+func TestNormalize(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":              "test",
+			"creationTimestamp": nil,
+		},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"creationTimestamp": nil,
+				},
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{
+							"name": "test",
+							"resources": map[string]any{
+								"requests": map[string]any{"cpu": "1000m", "memory": "1024Mi"},
+								"limits":   map[string]any{"cpu": int64(2)},
+							},
+						},
+					},
+				},
+			},
+		},
+		"status": map[string]any{},
+	}}
+
+	g.Expect(resources.Normalize(&obj)).Should(Succeed())
+
+	g.Expect(obj).Should(And(
+		jq.Match(`.metadata | has("creationTimestamp") | not`),
+		jq.Match(`.spec.template.metadata | has("creationTimestamp") | not`),
+		jq.Match(`has("status") | not`),
+		jq.Match(`.spec.template.spec.containers[0].resources.requests.cpu == "1"`),
+		jq.Match(`.spec.template.spec.containers[0].resources.requests.memory == "1Gi"`),
+		jq.Match(`.spec.template.spec.containers[0].resources.limits.cpu == "2"`),
+	))
+
+	obj.Object["status"] = map[string]any{"ready": true}
+
+	g.Expect(resources.Normalize(&obj)).Should(Succeed())
+	g.Expect(obj).Should(jq.Match(`.status.ready == true`))
+}
+
 func TestHasCRD(t *testing.T) {
 	g := NewWithT(t)
 	ctx := t.Context()
@@ -0,0 +1,51 @@
+package tracing_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSetupDisabled(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	shutdown, err := tracing.Setup(ctx, "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(shutdown(ctx)).Should(Succeed())
+
+	_, span := tracing.Tracer().Start(ctx, "test")
+	g.Expect(span.SpanContext().IsValid()).Should(BeFalse())
+}
+
+func TestEnd(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracing.TracerName)
+
+	_, ok := tracer.Start(ctx, "ok")
+	tracing.End(ok, nil)
+
+	_, ko := tracer.Start(ctx, "ko")
+	tracing.End(ko, errors.New("failure"))
+
+	spans := recorder.Ended()
+	g.Expect(spans).Should(HaveLen(2))
+
+	g.Expect(spans[0].Name()).Should(Equal("ok"))
+	g.Expect(spans[0].Status().Code).Should(Equal(codes.Unset))
+
+	g.Expect(spans[1].Name()).Should(Equal("ko"))
+	g.Expect(spans[1].Status().Code).Should(Equal(codes.Error))
+	g.Expect(spans[1].Status().Description).Should(Equal("failure"))
+	g.Expect(spans[1].Events()).Should(HaveLen(1))
+}
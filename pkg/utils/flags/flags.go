@@ -2,6 +2,7 @@ package flags
 
 import (
 	"flag"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -31,6 +32,41 @@ func AddOperatorFlagsAndEnvvars(envvarPrefix string) error {
 		return err
 	}
 
+	pflag.String("tracing-endpoint", "", "The OTLP/HTTP endpoint traces are exported to, tracing is disabled if empty.")
+	if err := viper.BindEnv("tracing-endpoint", envvarPrefix+"_TRACING_ENDPOINT"); err != nil {
+		return err
+	}
+
+	pflag.Int("max-concurrent-renders", 0, "The number of manifests renderings allowed to run at the same time, defaults to GOMAXPROCS if not set.")
+	if err := viper.BindEnv("max-concurrent-renders", envvarPrefix+"_MAX_CONCURRENT_RENDERS"); err != nil {
+		return err
+	}
+
+	pflag.String("component-startup-policy", "fail-fast", "How components failing to start are handled: fail-fast stops the operator, degraded reports them as failed in the DataScienceCluster status and keeps the other components running.")
+	if err := viper.BindEnv("component-startup-policy", envvarPrefix+"_COMPONENT_STARTUP_POLICY"); err != nil {
+		return err
+	}
+
+	pflag.StringSlice("watch-namespaces", nil, "The namespaces the operator is restricted to, so that it can run with namespace-scoped RBAC. The operator watches and deploys to all namespaces if not set.")
+	if err := viper.BindEnv("watch-namespaces", envvarPrefix+"_WATCH_NAMESPACES"); err != nil {
+		return err
+	}
+
+	pflag.String("cluster-scoped-resources", "reject", "How rendered cluster-scoped resources are handled when watch-namespaces is set: reject fails the deployment of the component, strip drops them.")
+	if err := viper.BindEnv("cluster-scoped-resources", envvarPrefix+"_CLUSTER_SCOPED_RESOURCES"); err != nil {
+		return err
+	}
+
+	pflag.Duration("api-cache-ttl", 30*time.Second, "How long the APIs served by the cluster are cached for, caching is disabled if 0.")
+	if err := viper.BindEnv("api-cache-ttl", envvarPrefix+"_API_CACHE_TTL"); err != nil {
+		return err
+	}
+
+	pflag.Duration("resync-period", 0, "How often the instances are reconciled again even if nothing changed, to correct drifts of unwatched resources. Periodic reconciliation is disabled if 0.")
+	if err := viper.BindEnv("resync-period", envvarPrefix+"_RESYNC_PERIOD"); err != nil {
+		return err
+	}
+
 	// zap logging flags
 	// these are taken from https://github.com/kubernetes-sigs/controller-runtime/blob/4161b012d114e6c1ea861fd8afcebf7ba2417b49/pkg/log/zap/zap.go#L255
 	// and need to be kept in sync.
@@ -0,0 +1,138 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/golden"
+)
+
+// Fixture provides a request to render the resources of a component for, i.e.
+// the component instance with a given spec. A new request must be returned on
+// each invocation as rendering mutates it.
+type Fixture struct {
+	Name    string
+	Request func(ctx context.Context) (*types.ReconciliationRequest, error)
+}
+
+type options struct {
+	requiredLabels      []string
+	requiredAnnotations []string
+}
+
+type Opts func(*options)
+
+// WithRequiredLabels requires every rendered resource to have the given labels.
+func WithRequiredLabels(values ...string) Opts {
+	return func(o *options) {
+		o.requiredLabels = append(o.requiredLabels, values...)
+	}
+}
+
+// WithRequiredAnnotations requires every rendered resource to have the given annotations.
+func WithRequiredAnnotations(values ...string) Opts {
+	return func(o *options) {
+		o.requiredAnnotations = append(o.requiredAnnotations, values...)
+	}
+}
+
+// RunComponentConformance verifies the rendering contract of a component for each
+// of the given fixtures, by running the given render actions and checking that:
+//   - the rendering is deterministic
+//   - every resource has an apiVersion, a kind and a name
+//   - every resource kind can be resolved by the RESTMapper of the request client
+//   - namespaced resources have a namespace, cluster scoped ones have none
+//   - every resource has the required labels and annotations
+func RunComponentConformance(t *testing.T, name string, fns []actions.Fn, fixtures []Fixture, opts ...Opts) {
+	t.Helper()
+
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for _, f := range fixtures {
+		t.Run(name+"/"+f.Name, func(t *testing.T) {
+			ctx := t.Context()
+
+			rr, res := render(ctx, t, f, fns)
+
+			t.Run("deterministic", func(t *testing.T) {
+				g := gomega.NewWithT(t)
+
+				_, again := render(ctx, t, f, fns)
+
+				expected, err := golden.Marshal(res)
+				g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+				g.Expect(again).Should(gomega.WithTransform(golden.Marshal, gomega.Equal(expected)))
+			})
+
+			t.Run("valid", func(t *testing.T) {
+				g := gomega.NewWithT(t)
+
+				for i := range res {
+					g.Expect(res[i].GetAPIVersion()).ShouldNot(gomega.BeEmpty(), "apiVersion of resource %d", i)
+					g.Expect(res[i].GetKind()).ShouldNot(gomega.BeEmpty(), "kind of resource %d", i)
+					g.Expect(res[i].GetName()).ShouldNot(gomega.BeEmpty(), "name of resource %d", i)
+				}
+			})
+
+			t.Run("scope", func(t *testing.T) {
+				g := gomega.NewWithT(t)
+
+				for i := range res {
+					mapping, err := rr.Client.RESTMapper().RESTMapping(
+						res[i].GroupVersionKind().GroupKind(),
+						res[i].GroupVersionKind().Version,
+					)
+
+					g.Expect(err).ShouldNot(gomega.HaveOccurred(), "unable to resolve %s", res[i].GroupVersionKind())
+
+					if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+						g.Expect(res[i].GetNamespace()).ShouldNot(gomega.BeEmpty(), "namespace of %s", describe(&res[i]))
+					} else {
+						g.Expect(res[i].GetNamespace()).Should(gomega.BeEmpty(), "namespace of %s", describe(&res[i]))
+					}
+				}
+			})
+
+			t.Run("metadata", func(t *testing.T) {
+				g := gomega.NewWithT(t)
+
+				for i := range res {
+					for _, l := range o.requiredLabels {
+						g.Expect(res[i].GetLabels()).Should(gomega.HaveKey(l), "labels of %s", describe(&res[i]))
+					}
+					for _, a := range o.requiredAnnotations {
+						g.Expect(res[i].GetAnnotations()).Should(gomega.HaveKey(a), "annotations of %s", describe(&res[i]))
+					}
+				}
+			})
+		})
+	}
+}
+
+func render(ctx context.Context, t *testing.T, f Fixture, fns []actions.Fn) (*types.ReconciliationRequest, []unstructured.Unstructured) {
+	t.Helper()
+
+	g := gomega.NewWithT(t)
+
+	rr, err := f.Request(ctx)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	res, err := golden.Render(ctx, rr, fns...)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	return rr, res
+}
+
+func describe(obj *unstructured.Unstructured) string {
+	return obj.GroupVersionKind().String() + " " + obj.GetNamespace() + "/" + obj.GetName()
+}
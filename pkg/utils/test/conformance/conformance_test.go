@@ -0,0 +1,76 @@
+package conformance_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/template"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/conformance"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+)
+
+var templates = fstest.MapFS{
+	"resources/configmap.tmpl.yaml": &fstest.MapFile{
+		Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Component.Name }}
+  namespace: {{ .DSCI.Spec.ApplicationsNamespace }}
+  labels:
+    platform.opendatahub.io/part-of: dashboard
+`),
+	},
+	"resources/clusterrole.tmpl.yaml": &fstest.MapFile{
+		Data: []byte(`apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ .Component.Name }}
+  labels:
+    platform.opendatahub.io/part-of: dashboard
+`),
+	},
+}
+
+func fixture(name string, ns string) conformance.Fixture {
+	return conformance.Fixture{
+		Name: name,
+		Request: func(_ context.Context) (*types.ReconciliationRequest, error) {
+			cl, err := fakeclient.New()
+			if err != nil {
+				return nil, err
+			}
+
+			rr := types.ReconciliationRequest{
+				Client:   cl,
+				Instance: &componentApi.Dashboard{},
+				DSCI: &dsciv2.DSCInitialization{
+					Spec: dsciv2.DSCInitializationSpec{ApplicationsNamespace: ns},
+				},
+				Templates: []types.TemplateInfo{{FS: templates, Path: "resources/*.tmpl.yaml"}},
+			}
+
+			rr.Instance.SetName(componentApi.DashboardInstanceName)
+
+			return &rr, nil
+		},
+	}
+}
+
+func TestRunComponentConformance(t *testing.T) {
+	conformance.RunComponentConformance(t, "dashboard",
+		[]actions.Fn{
+			template.NewAction(template.WithCache(false)),
+		},
+		[]conformance.Fixture{
+			fixture("odh", "opendatahub"),
+			fixture("rhoai", "redhat-ods-applications"),
+		},
+		conformance.WithRequiredLabels(labels.PlatformPartOf),
+	)
+}
@@ -6,10 +6,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/indexes"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
 )
@@ -91,5 +93,16 @@ func New(opts ...ClientOpts) (client.Client, error) {
 	b = b.WithObjects(co.objects...)
 	b = b.WithInterceptorFuncs(co.interceptor)
 
+	for _, o := range indexes.Objects() {
+		// skip types that are not part of the provided scheme
+		if _, err := apiutil.GVKForObject(o, s); err != nil {
+			continue
+		}
+
+		for field, fn := range indexes.Fields() {
+			b = b.WithIndex(o, field, fn)
+		}
+	}
+
 	return b.Build(), nil
 }
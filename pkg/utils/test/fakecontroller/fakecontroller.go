@@ -0,0 +1,172 @@
+package fakecontroller
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+var (
+	_ controller.Controller   = &Controller{}
+	_ types.Controller        = &Controller{}
+	_ types.WithEventRecorder = &Controller{}
+)
+
+// Controller is a fake implementation of both the controller-runtime and the
+// platform controller interfaces. It records the sources it is asked to watch
+// and can be configured to fail watch registrations.
+type Controller struct {
+	client          client.Client
+	discoveryClient discovery.DiscoveryInterface
+	dynamicClient   dynamic.Interface
+	recorder        record.EventRecorder
+	reconciler      reconcile.Reconciler
+	owned           map[schema.GroupVersionKind]struct{}
+	watchErr        error
+
+	lock    sync.Mutex
+	sources []source.Source
+}
+
+type Opts func(*Controller)
+
+func WithClient(value client.Client) Opts {
+	return func(c *Controller) {
+		c.client = value
+	}
+}
+
+func WithDiscoveryClient(value discovery.DiscoveryInterface) Opts {
+	return func(c *Controller) {
+		c.discoveryClient = value
+	}
+}
+
+func WithDynamicClient(value dynamic.Interface) Opts {
+	return func(c *Controller) {
+		c.dynamicClient = value
+	}
+}
+
+// WithEventRecorder sets the recorder returned by GetEventRecorder, defaults
+// to a record.FakeRecorder.
+func WithEventRecorder(value record.EventRecorder) Opts {
+	return func(c *Controller) {
+		c.recorder = value
+	}
+}
+
+// WithReconciler sets the reconciler Reconcile calls are delegated to.
+func WithReconciler(value reconcile.Reconciler) Opts {
+	return func(c *Controller) {
+		c.reconciler = value
+	}
+}
+
+// WithOwnedTypes sets the types Owns returns true for.
+func WithOwnedTypes(values ...schema.GroupVersionKind) Opts {
+	return func(c *Controller) {
+		for _, v := range values {
+			c.owned[v] = struct{}{}
+		}
+	}
+}
+
+// WithWatchError makes every Watch call fail with the given error.
+func WithWatchError(value error) Opts {
+	return func(c *Controller) {
+		c.watchErr = value
+	}
+}
+
+func New(opts ...Opts) *Controller {
+	c := Controller{
+		recorder: record.NewFakeRecorder(100),
+		owned:    map[schema.GroupVersionKind]struct{}{},
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &c
+}
+
+func (c *Controller) Owns(gvk schema.GroupVersionKind) bool {
+	_, ok := c.owned[gvk]
+	return ok
+}
+
+func (c *Controller) GetClient() client.Client {
+	return c.client
+}
+
+func (c *Controller) GetDiscoveryClient() discovery.DiscoveryInterface {
+	return c.discoveryClient
+}
+
+func (c *Controller) GetDynamicClient() dynamic.Interface {
+	return c.dynamicClient
+}
+
+func (c *Controller) GetEventRecorder() record.EventRecorder {
+	return c.recorder
+}
+
+func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if c.reconciler == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return c.reconciler.Reconcile(ctx, req)
+}
+
+func (c *Controller) Watch(src source.Source) error {
+	if c.watchErr != nil {
+		return c.watchErr
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sources = append(c.sources, src)
+
+	return nil
+}
+
+func (c *Controller) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (c *Controller) GetLogger() logr.Logger {
+	return logr.Discard()
+}
+
+// Watches returns the sources registered so far.
+func (c *Controller) Watches() []source.Source {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return slices.Clone(c.sources)
+}
+
+// WatchCount returns the number of sources registered so far.
+func (c *Controller) WatchCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.sources)
+}
@@ -0,0 +1,45 @@
+package fakecontroller_test
+
+import (
+	"errors"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakecontroller"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWatch(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fakecontroller.New()
+
+	g.Expect(c.Watch(source.Func(nil))).Should(Succeed())
+	g.Expect(c.Watch(source.Func(nil))).Should(Succeed())
+
+	g.Expect(c.WatchCount()).Should(Equal(2))
+	g.Expect(c.Watches()).Should(HaveLen(2))
+}
+
+func TestWatchError(t *testing.T) {
+	g := NewWithT(t)
+
+	watchErr := errors.New("watch-error")
+	c := fakecontroller.New(fakecontroller.WithWatchError(watchErr))
+
+	g.Expect(c.Watch(source.Func(nil))).Should(MatchError(watchErr))
+	g.Expect(c.WatchCount()).Should(BeZero())
+}
+
+func TestOwns(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fakecontroller.New(fakecontroller.WithOwnedTypes(gvk.ConfigMap))
+
+	g.Expect(c.Owns(gvk.ConfigMap)).Should(BeTrue())
+	g.Expect(c.Owns(gvk.Secret)).Should(BeFalse())
+	g.Expect(c.GetEventRecorder()).ShouldNot(BeNil())
+}
@@ -0,0 +1,118 @@
+package fakerequest
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+)
+
+type requestOptions struct {
+	client     client.Client
+	clientOpts []fakeclient.ClientOpts
+	instance   common.PlatformObject
+	dsci       *dsciv2.DSCInitialization
+	release    common.Release
+	controller types.Controller
+	templates  []types.TemplateInfo
+	resources  []client.Object
+}
+
+type RequestOpts func(*requestOptions)
+
+// WithClient sets the client of the request, by default a fake client is
+// created with the options given by WithClientOpts.
+func WithClient(value client.Client) RequestOpts {
+	return func(o *requestOptions) {
+		o.client = value
+	}
+}
+
+func WithClientOpts(values ...fakeclient.ClientOpts) RequestOpts {
+	return func(o *requestOptions) {
+		o.clientOpts = append(o.clientOpts, values...)
+	}
+}
+
+// WithInstance sets the reconciled instance, defaults to an empty Dashboard.
+func WithInstance(value common.PlatformObject) RequestOpts {
+	return func(o *requestOptions) {
+		o.instance = value
+	}
+}
+
+func WithDSCI(value *dsciv2.DSCInitialization) RequestOpts {
+	return func(o *requestOptions) {
+		o.dsci = value
+	}
+}
+
+func WithRelease(value common.Release) RequestOpts {
+	return func(o *requestOptions) {
+		o.release = value
+	}
+}
+
+func WithController(value types.Controller) RequestOpts {
+	return func(o *requestOptions) {
+		o.controller = value
+	}
+}
+
+func WithTemplates(values ...types.TemplateInfo) RequestOpts {
+	return func(o *requestOptions) {
+		o.templates = append(o.templates, values...)
+	}
+}
+
+// WithResources adds the given objects to the resources of the request, as if
+// they had been rendered.
+func WithResources(values ...client.Object) RequestOpts {
+	return func(o *requestOptions) {
+		o.resources = append(o.resources, values...)
+	}
+}
+
+// New returns a ReconciliationRequest as received by the actions of a
+// reconciler, for actions to be tested in isolation.
+func New(opts ...RequestOpts) (*types.ReconciliationRequest, error) {
+	ro := requestOptions{}
+	for _, o := range opts {
+		o(&ro)
+	}
+
+	cl := ro.client
+	if cl == nil {
+		fc, err := fakeclient.New(ro.clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create fake client: %w", err)
+		}
+
+		cl = fc
+	}
+
+	instance := ro.instance
+	if instance == nil {
+		instance = &componentApi.Dashboard{}
+	}
+
+	rr := types.ReconciliationRequest{
+		Client:     cl,
+		Instance:   instance,
+		DSCI:       ro.dsci,
+		Release:    ro.release,
+		Controller: ro.controller,
+		Templates:  ro.templates,
+	}
+
+	if err := rr.AddResources(ro.resources...); err != nil {
+		return nil, err
+	}
+
+	return &rr, nil
+}
@@ -0,0 +1,139 @@
+package golden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+// UpdateEnv is the environment variable that, when set to true, makes MatchFile
+// (re)write the golden files with the actual content instead of comparing it.
+//
+//	UPDATE_GOLDEN=true go test ./...
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Render runs the given actions (typically the ones that initialize and render the
+// manifests of a component) against the given request and returns the rendered
+// resources.
+func Render(ctx context.Context, rr *odhtypes.ReconciliationRequest, fns ...actions.Fn) ([]unstructured.Unstructured, error) {
+	for _, fn := range fns {
+		if err := fn(ctx, rr); err != nil {
+			return nil, fmt.Errorf("failure running action %s: %w", fn, err)
+		}
+	}
+
+	return rr.Resources, nil
+}
+
+// Marshal serializes the given resources as a multi-document YAML stream. Resources
+// are sorted by apiVersion, kind, namespace and name so that the output does not
+// depend on the rendering order.
+func Marshal(objs []unstructured.Unstructured) ([]byte, error) {
+	sorted := slices.Clone(objs)
+	slices.SortStableFunc(sorted, func(a unstructured.Unstructured, b unstructured.Unstructured) int {
+		return strings.Compare(sortKey(&a), sortKey(&b))
+	})
+
+	var buf bytes.Buffer
+
+	for i := range sorted {
+		data, err := yaml.Marshal(sorted[i].Object)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal %s %s/%s: %w",
+				sorted[i].GetKind(), sorted[i].GetNamespace(), sorted[i].GetName(), err)
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func sortKey(obj *unstructured.Unstructured) string {
+	return strings.Join([]string{obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName()}, "/")
+}
+
+// MatchFile succeeds if the actual value, either a list of resources or raw bytes,
+// matches the content of the given golden file. If the UpdateEnv environment
+// variable is set to true, the golden file is written instead.
+func MatchFile(path string) *Matcher {
+	return &Matcher{
+		path: path,
+	}
+}
+
+var _ types.GomegaMatcher = &Matcher{}
+
+type Matcher struct {
+	path     string
+	actual   string
+	expected string
+}
+
+func (matcher *Matcher) Match(actual interface{}) (bool, error) {
+	var data []byte
+
+	switch v := actual.(type) {
+	case []unstructured.Unstructured:
+		b, err := Marshal(v)
+		if err != nil {
+			return false, err
+		}
+
+		data = b
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return false, fmt.Errorf("unsupported type %T", actual)
+	}
+
+	matcher.actual = string(data)
+
+	if update, _ := strconv.ParseBool(os.Getenv(UpdateEnv)); update {
+		if err := os.MkdirAll(filepath.Dir(matcher.path), 0o755); err != nil {
+			return false, fmt.Errorf("unable to create golden file directory: %w", err)
+		}
+
+		if err := os.WriteFile(matcher.path, data, 0o600); err != nil {
+			return false, fmt.Errorf("unable to write golden file %s: %w", matcher.path, err)
+		}
+	}
+
+	expected, err := os.ReadFile(matcher.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return false, fmt.Errorf("golden file %s does not exist, run the test with %s=true to create it", matcher.path, UpdateEnv)
+	case err != nil:
+		return false, fmt.Errorf("unable to read golden file %s: %w", matcher.path, err)
+	}
+
+	matcher.expected = string(expected)
+
+	return matcher.actual == matcher.expected, nil
+}
+
+func (matcher *Matcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.actual, "to match golden file "+matcher.path, matcher.expected) +
+		fmt.Sprintf("\n\nrun the test with %s=true to update the golden file", UpdateEnv)
+}
+
+func (matcher *Matcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.actual, "not to match golden file "+matcher.path, matcher.expected)
+}
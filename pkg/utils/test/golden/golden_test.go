@@ -0,0 +1,80 @@
+package golden_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/golden"
+
+	. "github.com/onsi/gomega"
+)
+
+func newResource(apiVersion string, kind string, name string) unstructured.Unstructured {
+	u := unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace("ns")
+	u.SetName(name)
+
+	return u
+}
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+
+	rr := types.ReconciliationRequest{}
+
+	res, err := golden.Render(t.Context(), &rr,
+		func(_ context.Context, rr *types.ReconciliationRequest) error {
+			rr.Resources = append(rr.Resources, newResource("v1", "ConfigMap", "b"))
+			return nil
+		},
+		func(_ context.Context, rr *types.ReconciliationRequest) error {
+			rr.Resources = append(rr.Resources, newResource("v1", "ConfigMap", "a"))
+			rr.Resources = append(rr.Resources, newResource("apps/v1", "Deployment", "a"))
+			return nil
+		},
+	)
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(res).Should(golden.MatchFile("testdata/resources.golden.yaml"))
+}
+
+func TestMatchFileMismatch(t *testing.T) {
+	g := NewWithT(t)
+
+	res := []unstructured.Unstructured{
+		newResource("v1", "ConfigMap", "a"),
+	}
+
+	g.Expect(res).ShouldNot(golden.MatchFile("testdata/resources.golden.yaml"))
+}
+
+func TestMatchFileUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Setenv(golden.UpdateEnv, "true")
+
+	path := filepath.Join(t.TempDir(), "update", "resources.golden.yaml")
+	res := []unstructured.Unstructured{
+		newResource("v1", "ConfigMap", "a"),
+	}
+
+	g.Expect(res).Should(golden.MatchFile(path))
+
+	data, err := os.ReadFile(path)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).Should(ContainSubstring("name: a"))
+}
+
+func TestMatchFileMissing(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := golden.MatchFile(filepath.Join(t.TempDir(), "missing.yaml")).Match("")
+	g.Expect(err).Should(MatchError(ContainSubstring(golden.UpdateEnv)))
+}